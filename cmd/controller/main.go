@@ -21,6 +21,7 @@ import (
 	"log"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
@@ -60,6 +61,10 @@ func main() {
 	flag.StringVar(&opts.Images.PRImage, "pr-image", "", "The container image containing our PR binary.")
 	flag.StringVar(&opts.Images.ImageDigestExporterImage, "imagedigest-exporter-image", "", "The container image containing our image digest exporter binary.")
 	flag.StringVar(&opts.Images.WorkingDirInitImage, "workingdirinit-image", "", "The container image containing our working dir init binary.")
+	flag.StringVar(&opts.Images.S3CopyImage, "s3-copy-image", "", "The container image containing our binary copying files to and from S3-compatible storages.")
+	flag.StringVar(&opts.EntrypointCache.ConfigMapName, "entrypoint-cache-configmap", "", "The ConfigMap used to share the image metadata looked up to resolve step entrypoints across controller replicas. Optional, the metadata is only cached in memory if not set.")
+	flag.DurationVar(&opts.EntrypointCache.TTL, "entrypoint-cache-ttl", 24*time.Hour, "How long the image metadata shared through the entrypoint cache ConfigMap is used before being looked up again.")
+	flag.IntVar(&opts.EntrypointCache.MaxEntries, "entrypoint-cache-max-entries", 200, "The maximum number of images in the entrypoint cache ConfigMap.")
 
 	// This parses flags.
 	cfg := injection.ParseAndGetRESTConfigOrDie()
@@ -23,10 +23,12 @@ import (
 	"log"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/containerd/containerd/platforms"
 	"github.com/tektoncd/pipeline/cmd/entrypoint/subcommands"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -34,6 +36,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/credentials/dockercreds"
 	"github.com/tektoncd/pipeline/pkg/credentials/gitcreds"
 	"github.com/tektoncd/pipeline/pkg/entrypoint"
+	"github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/termination"
 )
 
@@ -50,12 +53,16 @@ var (
 	breakpointOnFailure = flag.Bool("breakpoint_on_failure", false, "If specified, expect steps to not skip on failure")
 	onError             = flag.String("on_error", "", "Set to \"continue\" to ignore an error and continue when a container terminates with a non-zero exit code."+
 		" Set to \"stopAndFail\" to declare a failure with a step error and stop executing the rest of the steps.")
-	stepMetadataDir = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
+	stepMetadataDir  = flag.String("step_metadata_dir", "", "If specified, create directory to store the step metadata e.g. /tekton/steps/<step-name>/")
+	stepLogsURL      = flag.String("step_logs_url", "", "If specified, s3, http or https URL to upload the stdout and stderr of the step to once it finished")
+	stepLogsEndpoint = flag.String("step_logs_endpoint", "", "If specified, endpoint of the S3-compatible storage the step logs are uploaded to")
+	stepLogsRegion   = flag.String("step_logs_region", "", "If specified, region of the S3-compatible storage the step logs are uploaded to")
 )
 
 const (
 	defaultWaitPollingInterval = time.Second
 	breakpointExitSuffix       = ".breakpointexit"
+	stepLogsFile               = "logs"
 )
 
 func checkForBreakpointOnFailure(e entrypoint.Entrypointer, breakpointExitPostFile string) {
@@ -131,17 +138,18 @@ func main() {
 		}
 	}
 
+	runner := &realRunner{
+		stdoutPath: *stdoutPath,
+		stderrPath: *stderrPath,
+	}
 	e := entrypoint.Entrypointer{
-		Command:         append(cmd, commandArgs...),
-		WaitFiles:       strings.Split(*waitFiles, ","),
-		WaitFileContent: *waitFileContent,
-		PostFile:        *postFile,
-		TerminationPath: *terminationPath,
-		Waiter:          &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
-		Runner: &realRunner{
-			stdoutPath: *stdoutPath,
-			stderrPath: *stderrPath,
-		},
+		Command:             append(cmd, commandArgs...),
+		WaitFiles:           strings.Split(*waitFiles, ","),
+		WaitFileContent:     *waitFileContent,
+		PostFile:            *postFile,
+		TerminationPath:     *terminationPath,
+		Waiter:              &realWaiter{waitPollingInterval: defaultWaitPollingInterval, breakpointOnFailure: *breakpointOnFailure},
+		Runner:              runner,
 		PostWriter:          &realPostWriter{},
 		Results:             strings.Split(*results, ","),
 		Timeout:             timeout,
@@ -149,6 +157,10 @@ func main() {
 		OnError:             *onError,
 		StepMetadataDir:     *stepMetadataDir,
 	}
+	if *stepLogsURL != "" {
+		runner.logPath = filepath.Join(*stepMetadataDir, stepLogsFile)
+		e.LogUploader = newStepLogsUploader(runner.logPath)
+	}
 
 	// Copy any creds injected by the controller into the $HOME directory of the current
 	// user so that they're discoverable by git / ssh.
@@ -190,6 +202,31 @@ func main() {
 	}
 }
 
+// newStepLogsUploader returns the uploader of the logs of the step written to the path.
+// The credentials are removed from the environment so that the step command doesn't
+// inherit them. This doesn't hide them from the step: they can still be read from the
+// initial environment of the entrypoint, e.g. in /proc/1/environ.
+func newStepLogsUploader(path string) *entrypoint.StepLogsUploader {
+	u := &entrypoint.StepLogsUploader{
+		Path:     path,
+		URL:      *stepLogsURL,
+		Endpoint: *stepLogsEndpoint,
+		Region:   *stepLogsRegion,
+		Token:    os.Getenv(pod.StepLogsTokenEnvVar),
+	}
+	if accessKeyID := os.Getenv(pod.StepLogsAccessKeyIDEnvVar); accessKeyID != "" {
+		u.Credentials = &aws.Credentials{
+			AccessKeyID:     accessKeyID,
+			SecretAccessKey: os.Getenv(pod.StepLogsSecretAccessKeyEnvVar),
+			SessionToken:    os.Getenv(pod.StepLogsSessionTokenEnvVar),
+		}
+	}
+	for _, env := range []string{pod.StepLogsTokenEnvVar, pod.StepLogsAccessKeyIDEnvVar, pod.StepLogsSecretAccessKeyEnvVar, pod.StepLogsSessionTokenEnvVar} {
+		os.Unsetenv(env)
+	}
+	return u
+}
+
 func selectCommandForPlatform(cmds map[string][]string, plat string) ([]string, error) {
 	cmd, found := cmds[plat]
 	if found {
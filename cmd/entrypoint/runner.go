@@ -43,6 +43,9 @@ type realRunner struct {
 	signalsClosed bool
 	stdoutPath    string
 	stderrPath    string
+	// logPath is the file both stdout and stderr are copied to, for the logs of
+	// the step to be uploaded once it finished.
+	logPath string
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -83,47 +86,85 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 
 	cmd := exec.CommandContext(ctx, name, args...)
 	stopCh := make(chan struct{}, 1)
-	defer close(stopCh)
+	// The copies to the log file must be complete when Run returns for the logs
+	// of the step to be uploaded.
+	var logFile *os.File
+	var logCopies sync.WaitGroup
+	defer func() {
+		close(stopCh)
+		logCopies.Wait()
+		if logFile != nil {
+			logFile.Close()
+		}
+	}()
+
+	if rr.logPath != "" {
+		var err error
+		if err = os.MkdirAll(filepath.Dir(rr.logPath), os.ModePerm); err != nil {
+			return err
+		}
+		if logFile, err = os.Create(rr.logPath); err != nil {
+			return err
+		}
+	}
 
 	cmd.Stdout = os.Stdout
 	var stdoutFile *os.File
-	if rr.stdoutPath != "" {
+	if rr.stdoutPath != "" || logFile != nil {
 		var err error
 		var doneCh <-chan error
-		// Create directory if it doesn't already exist
-		if err = os.MkdirAll(filepath.Dir(rr.stdoutPath), os.ModePerm); err != nil {
-			return err
+		stdout := []io.Writer{os.Stdout}
+		if rr.stdoutPath != "" {
+			// Create directory if it doesn't already exist
+			if err = os.MkdirAll(filepath.Dir(rr.stdoutPath), os.ModePerm); err != nil {
+				return err
+			}
+			if stdoutFile, err = os.Create(rr.stdoutPath); err != nil {
+				return err
+			}
+			stdout = append(stdout, stdoutFile)
 		}
-		if stdoutFile, err = os.Create(rr.stdoutPath); err != nil {
-			return err
+		if logFile != nil {
+			stdout = append(stdout, logFile)
 		}
 		// We use os.Pipe in asyncWriter to copy stdout instead of cmd.StdoutPipe or providing an
 		// io.Writer directly because otherwise Go would wait for the underlying fd to be closed by the
 		// child process before returning from cmd.Wait even if the process is no longer running. This
 		// would cause a deadlock if the child spawns a long running descendant process before exiting.
-		if cmd.Stdout, doneCh, err = asyncWriter(io.MultiWriter(os.Stdout, stdoutFile), stopCh); err != nil {
+		if cmd.Stdout, doneCh, err = asyncWriter(io.MultiWriter(stdout...), stopCh); err != nil {
 			return err
 		}
+		if logFile != nil {
+			logCopies.Add(1)
+		}
 		go func() {
 			if err := <-doneCh; err != nil {
 				log.Fatalf("Copying stdout: %v", err)
 			}
-			stdoutFile.Close()
+			if stdoutFile != nil {
+				stdoutFile.Close()
+			}
+			if logFile != nil {
+				logCopies.Done()
+			}
 		}()
 	}
 
 	cmd.Stderr = os.Stderr
 	var stderrFile *os.File
-	if rr.stderrPath != "" {
+	if rr.stderrPath != "" || logFile != nil {
 		var err error
 		var doneCh <-chan error
-		if rr.stderrPath == rr.stdoutPath {
+		stderr := []io.Writer{os.Stderr}
+		switch {
+		case rr.stderrPath == "":
+		case rr.stderrPath == rr.stdoutPath:
 			fd, err := syscall.Dup(int(stdoutFile.Fd()))
 			if err != nil {
 				return err
 			}
 			stderrFile = os.NewFile(uintptr(fd), rr.stderrPath)
-		} else {
+		default:
 			// Create directory if it doesn't already exist
 			if err = os.MkdirAll(filepath.Dir(rr.stderrPath), os.ModePerm); err != nil {
 				return err
@@ -132,18 +173,32 @@ func (rr *realRunner) Run(ctx context.Context, args ...string) error {
 				return err
 			}
 		}
+		if stderrFile != nil {
+			stderr = append(stderr, stderrFile)
+		}
+		if logFile != nil {
+			stderr = append(stderr, logFile)
+		}
 		// We use os.Pipe in asyncWriter to copy stderr instead of cmd.StderrPipe or providing an
 		// io.Writer directly because otherwise Go would wait for the underlying fd to be closed by the
 		// child process before returning from cmd.Wait even if the process is no longer running. This
 		// would cause a deadlock if the child spawns a long running descendant process before exiting.
-		if cmd.Stderr, doneCh, err = asyncWriter(io.MultiWriter(os.Stderr, stderrFile), stopCh); err != nil {
+		if cmd.Stderr, doneCh, err = asyncWriter(io.MultiWriter(stderr...), stopCh); err != nil {
 			return err
 		}
+		if logFile != nil {
+			logCopies.Add(1)
+		}
 		go func() {
 			if err := <-doneCh; err != nil {
 				log.Fatalf("Copying stderr: %v", err)
 			}
-			stderrFile.Close()
+			if stderrFile != nil {
+				stderrFile.Close()
+			}
+			if logFile != nil {
+				logCopies.Done()
+			}
 		}()
 	}
 
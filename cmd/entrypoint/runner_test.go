@@ -79,6 +79,37 @@ func TestRealRunnerStdoutAndStderrSamePath(t *testing.T) {
 	}
 }
 
+func TestRealRunnerLogPath(t *testing.T) {
+	tmp, err := ioutil.TempDir("", "")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer os.RemoveAll(tmp)
+
+	expectedString := "hello world"
+	rr := realRunner{
+		stdoutPath: filepath.Join(tmp, "stdout"),
+		logPath:    filepath.Join(tmp, "status", "logs"),
+	}
+	if err := rr.Run(context.Background(), "sh", "-c", fmt.Sprintf("echo %s && echo %s >&2", expectedString, expectedString)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The log file is complete as soon as Run returns. Since writes to stdout and
+	// stderr might be racy, we only check for lengths here.
+	expectedSize := (len(expectedString) + 1) * 2
+	if got, err := ioutil.ReadFile(rr.logPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if gotSize := len(got); gotSize != expectedSize {
+		t.Errorf("got: %v, wanted: %v", gotSize, expectedSize)
+	}
+	if got, err := ioutil.ReadFile(rr.stdoutPath); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	} else if gotString := strings.TrimSpace(string(got)); gotString != expectedString {
+		t.Errorf("stdout: got: %v, wanted: %v", gotString, expectedString)
+	}
+}
+
 func TestRealRunnerStdoutPathWithSignal(t *testing.T) {
 	tmp, err := ioutil.TempDir("", "")
 	if err != nil {
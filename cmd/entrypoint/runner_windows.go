@@ -35,6 +35,8 @@ import (
 type realRunner struct {
 	stdoutPath string
 	stderrPath string
+	// logPath is ignored on Windows, the logs of the step are not uploaded.
+	logPath string
 }
 
 var _ entrypoint.Runner = (*realRunner)(nil)
@@ -18,6 +18,8 @@ package main
 import (
 	"flag"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/git"
@@ -40,6 +42,9 @@ func init() {
 	flag.UintVar(&fetchSpec.Depth, "depth", 1, "Perform a shallow clone to this depth")
 	flag.StringVar(&terminationMessagePath, "terminationMessagePath", "/tekton/termination", "Location of file containing termination message")
 	flag.StringVar(&fetchSpec.SparseCheckoutDirectories, "sparseCheckoutDirectories", "", "String of directory patterns separated by a comma")
+	flag.StringVar(&fetchSpec.Filter, "filter", "", "Perform a partial clone with this object filter, e.g. blob:none (optional)")
+	flag.BoolVar(&fetchSpec.LFS, "lfs", false, "Fetch Git LFS objects")
+	flag.StringVar(&fetchSpec.TrustedKeysPath, "trustedKeysPath", "", "Path of directory containing the GPG and SSH public keys trusted to sign the revision (optional)")
 }
 
 func main() {
@@ -50,11 +55,12 @@ func main() {
 		_ = logger.Sync()
 	}()
 
-	if err := git.Fetch(logger, fetchSpec); err != nil {
+	signature, err := git.Fetch(logger, fetchSpec)
+	if err != nil {
 		logger.Fatalf("Error fetching git repository: %s", err)
 	}
 
-	commit, err := git.ShowCommit(logger, "HEAD", fetchSpec.Path)
+	commit, err := git.ShowCommitMetadata(logger, "HEAD", fetchSpec.Path)
 	if err != nil {
 		logger.Fatalf("Error parsing revision %s of git repository: %s", fetchSpec.Revision, err)
 	}
@@ -62,7 +68,7 @@ func main() {
 	output := []v1beta1.PipelineResourceResult{
 		{
 			Key:          "commit",
-			Value:        commit,
+			Value:        commit.SHA,
 			ResourceName: resourceName,
 		},
 		{
@@ -70,6 +76,63 @@ func main() {
 			Value:        fetchSpec.URL,
 			ResourceName: resourceName,
 		},
+		{
+			Key:          "author",
+			Value:        commit.Author,
+			ResourceName: resourceName,
+		},
+		{
+			Key:          "authorEmail",
+			Value:        commit.AuthorEmail,
+			ResourceName: resourceName,
+		},
+		{
+			Key:          "committer",
+			Value:        commit.Committer,
+			ResourceName: resourceName,
+		},
+		{
+			Key:          "committerEmail",
+			Value:        commit.CommitterEmail,
+			ResourceName: resourceName,
+		},
+		{
+			Key:          "commitTimestamp",
+			Value:        strconv.FormatInt(commit.Timestamp, 10),
+			ResourceName: resourceName,
+		},
+		{
+			Key:          "subject",
+			Value:        commit.Subject,
+			ResourceName: resourceName,
+		},
+		{
+			Key:          "tags",
+			Value:        strings.Join(commit.Tags, ","),
+			ResourceName: resourceName,
+		},
+	}
+	if signature != nil {
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:          "signer",
+			Value:        signature.Signer,
+			ResourceName: resourceName,
+		}, v1beta1.PipelineResourceResult{
+			Key:          "signingKey",
+			Value:        signature.Key,
+			ResourceName: resourceName,
+		})
+	}
+	if fetchSpec.LFS {
+		lfsFiles, err := git.CountLFSFiles(logger, fetchSpec.Path)
+		if err != nil {
+			logger.Fatalf("Error listing lfs files of git repository: %s", err)
+		}
+		output = append(output, v1beta1.PipelineResourceResult{
+			Key:          "lfsFiles",
+			Value:        strconv.Itoa(lfsFiles),
+			ResourceName: resourceName,
+		})
 	}
 
 	if err := termination.WriteMessage(terminationMessagePath, output); err != nil {
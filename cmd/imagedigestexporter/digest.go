@@ -1,7 +1,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 )
 
 // GetDigest returns the digest of an OCI image index. If there is only one image in the index, the
@@ -16,3 +25,64 @@ func GetDigest(ii v1.ImageIndex) (v1.Hash, error) {
 	}
 	return ii.Digest()
 }
+
+// GetDigestFromLayout returns the digest of the image in the OCI image layout at path.
+func GetDigestFromLayout(path string) (v1.Hash, error) {
+	ii, err := layout.ImageIndexFromPath(path)
+	if err != nil {
+		return v1.Hash{}, fmt.Errorf("no index.json found in %s: %w", path, err)
+	}
+	return GetDigest(ii)
+}
+
+// GetDigestFromFile returns the digest of the image at url from the file at path. The file contains
+// either the digest, or one or more image references with digests, one per line, as written by tools
+// that push images directly to a registry. The digest of the reference to the repository of url is
+// returned when there are several.
+func GetDigestFromFile(path, url string) (v1.Hash, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	var lines []string
+	for _, line := range strings.Split(string(b), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return v1.Hash{}, fmt.Errorf("%s is empty", path)
+	}
+	if len(lines) == 1 && !strings.Contains(lines[0], "@") {
+		return v1.NewHash(lines[0])
+	}
+
+	repo, err := name.ParseReference(url)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	for _, line := range lines {
+		ref, err := name.NewDigest(line)
+		if err != nil {
+			return v1.Hash{}, fmt.Errorf("error parsing image reference %q in %s: %w", line, path, err)
+		}
+		if len(lines) == 1 || ref.Context() == repo.Context() {
+			return v1.NewHash(ref.DigestStr())
+		}
+	}
+	return v1.Hash{}, fmt.Errorf("no image reference to %s in %s", repo.Context(), path)
+}
+
+// GetDigestFromRegistry returns the digest of the image at url in the registry, using the credentials
+// of the keychain.
+func GetDigestFromRegistry(ctx context.Context, url string, kc authn.Keychain) (v1.Hash, error) {
+	ref, err := name.ParseReference(url)
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	desc, err := remote.Head(ref, remote.WithContext(ctx), remote.WithAuthFromKeychain(kc))
+	if err != nil {
+		return v1.Hash{}, err
+	}
+	return desc.Digest, nil
+}
@@ -1,12 +1,23 @@
 package main
 
 import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/layout"
 	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
 )
 
 func TestGetDigest(t *testing.T) {
@@ -63,3 +74,132 @@ func TestGetDigest(t *testing.T) {
 		})
 	}
 }
+
+func TestGetDigestFromLayout(t *testing.T) {
+	ii, err := random.Index(1024, 4, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	im, err := ii.IndexManifest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	if _, err := layout.Write(dir, ii); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetDigestFromLayout(dir)
+	if err != nil {
+		t.Fatalf("GetDigestFromLayout() = %v", err)
+	}
+	if d := cmp.Diff(im.Manifests[0].Digest, got); d != "" {
+		t.Errorf("GetDigestFromLayout() -want +got: %s", d)
+	}
+
+	// The image wasn't written to the output directory.
+	if _, err := GetDigestFromLayout(t.TempDir()); err == nil {
+		t.Error("Expected an error without index.json")
+	}
+}
+
+func TestGetDigestFromFile(t *testing.T) {
+	const (
+		digest      = "sha256:6fec7f3c4cd4cd8ab8ec2e7e8a4b7e0e1b11b5bcc46d6a4e3a5c8d8f1f4f9d2a"
+		otherDigest = "sha256:0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+	)
+	for _, tc := range []struct {
+		name    string
+		content string
+		url     string
+		want    string
+		wantErr bool
+	}{{
+		name:    "digest",
+		content: digest + "\n",
+		url:     "gcr.io/foo/bar",
+		want:    digest,
+	}, {
+		name:    "single reference",
+		content: "gcr.io/foo/bar@" + digest,
+		url:     "gcr.io/foo/bar:latest",
+		want:    digest,
+	}, {
+		name:    "multiple references",
+		content: fmt.Sprintf("gcr.io/foo/baz@%s\ngcr.io/foo/bar@%s\n", otherDigest, digest),
+		url:     "gcr.io/foo/bar:v1",
+		want:    digest,
+	}, {
+		name:    "no reference to the image",
+		content: fmt.Sprintf("gcr.io/foo/baz@%s\ngcr.io/foo/qux@%s\n", otherDigest, digest),
+		url:     "gcr.io/foo/bar",
+		wantErr: true,
+	}, {
+		name:    "empty",
+		content: "\n",
+		url:     "gcr.io/foo/bar",
+		wantErr: true,
+	}, {
+		name:    "invalid digest",
+		content: "latest",
+		url:     "gcr.io/foo/bar",
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "digest")
+			if err := ioutil.WriteFile(path, []byte(tc.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+			got, err := GetDigestFromFile(path, tc.url)
+			if tc.wantErr {
+				if err == nil {
+					t.Errorf("expected an error, got digest %s", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("cannot get digest: %s", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("got digest %s, want %s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetDigestFromRegistry(t *testing.T) {
+	s := httptest.NewServer(registry.New())
+	defer s.Close()
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := random.Image(1024, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ref, err := name.ParseReference(u.Host + "/foo/bar:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := remote.Write(ref, img); err != nil {
+		t.Fatal(err)
+	}
+	want, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetDigestFromRegistry(context.Background(), ref.String(), authn.DefaultKeychain)
+	if err != nil {
+		t.Fatalf("cannot get digest: %s", err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("get digest: -want +got: %s", diff)
+	}
+
+	if _, err := GetDigestFromRegistry(context.Background(), u.Host+"/foo/missing:latest", authn.DefaultKeychain); err == nil {
+		t.Error("expected an error for a missing image")
+	}
+}
@@ -17,13 +17,17 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
+	"path/filepath"
 
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"knative.dev/pkg/logging"
 
-	"github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	v1beta1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/image"
 )
@@ -35,8 +39,9 @@ var (
 
 /* The input of this go program will be a JSON string with all the output PipelineResources of type
 Image, which will include the path to where the index.json file will be located. The program will
-read the related index.json file(s) and log another JSON string including the name of the image resource
-and the digests.
+read the related index.json file(s), or the digest file(s), or resolve the digest(s) from the registry,
+depending on the digestSource of each image, and log another JSON string including the name of the
+image resource and the digests.
 The input is an array of ImageResource, ex: [{"name":"srcimg1","type":"image","url":"gcr.io/some-image-1","digest":""}]
 The output is an array of PipelineResourceResult, ex: [{"name":"image","digest":"sha256:eed29..660"}]
 */
@@ -52,14 +57,27 @@ func main() {
 		logger.Fatalf("Error reading images array: %v", err)
 	}
 
+	ctx := context.Background()
+	var kc authn.Keychain
 	output := []v1beta1.PipelineResourceResult{}
 	for _, imageResource := range imageResources {
-		ii, err := layout.ImageIndexFromPath(imageResource.OutputImageDir)
-		if err != nil {
-			logger.Infof("No index.json found for: %s", imageResource.Name)
-			continue
+		var digest v1.Hash
+		var err error
+		switch imageResource.DigestSource {
+		case image.DigestSourceFile:
+			digest, err = GetDigestFromFile(filepath.Join(imageResource.OutputImageDir, image.DigestFile), imageResource.URL)
+		case image.DigestSourceRegistry:
+			if kc == nil {
+				// The Pod can't read the image pull secrets, so rely on the Docker config
+				// written by creds-init, and on the workload identity of the cloud providers.
+				if kc, err = k8schain.NewNoClient(ctx); err != nil {
+					logger.Fatalf("Error creating keychain: %v", err)
+				}
+			}
+			digest, err = GetDigestFromRegistry(ctx, imageResource.URL, kc)
+		default:
+			digest, err = GetDigestFromLayout(imageResource.OutputImageDir)
 		}
-		digest, err := GetDigest(ii)
 		if err != nil {
 			logger.Fatalf("Unexpected error getting image digest for %s: %v", imageResource.Name, err)
 		}
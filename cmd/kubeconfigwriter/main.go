@@ -53,6 +53,28 @@ func main() {
 }
 
 func createKubeconfigFile(resource *cluster.Resource, logger *zap.SugaredLogger, destinationDir *string) {
+	c := newKubeconfig(resource)
+
+	// kubeconfig file location
+	var destinationFile string
+
+	// If the destination Directory is provided, kubeconfig will be written to the given directory.
+	// otherwise it will use default location i.e. "/workspace/<cluster-name>/
+	if *destinationDir != "" {
+		destinationFile = filepath.Join(*destinationDir, "kubeconfig")
+	} else {
+		destinationFile = filepath.Join("/workspace", resource.Name, "kubeconfig")
+	}
+
+	if err := clientcmd.WriteToFile(*c, destinationFile); err != nil {
+		logger.Fatalf("Error writing kubeconfig to file: %v", err)
+	}
+	logger.Infof("kubeconfig file successfully written to %s", destinationFile)
+}
+
+// newKubeconfig returns the kubeconfig of the cluster resource, with the values of
+// its secrets read from the environment.
+func newKubeconfig(resource *cluster.Resource) *clientcmdapi.Config {
 	cluster := &clientcmdapi.Cluster{
 		Server:                   resource.URL,
 		InsecureSkipTLSVerify:    resource.Insecure,
@@ -71,48 +93,67 @@ func createKubeconfigFile(resource *cluster.Resource, logger *zap.SugaredLogger,
 		resource.Password = passwordFromEnv
 	}
 	// only one authentication technique per user is allowed in a kubeconfig, so clear out the password if a token is provided
+	// and the token, token file and password if a credential plugin is provided
 	user := resource.Username
 	pass := resource.Password
+	token := resource.Token
+	tokenFile := resource.TokenFile
 	clientKeyData := resource.ClientKeyData
 	clientCertificateData := resource.ClientCertificateData
-	if resource.Token != "" {
+	if token != "" || tokenFile != "" {
 		user = ""
 		pass = ""
 	}
+	if token != "" {
+		tokenFile = ""
+	}
+	var exec *clientcmdapi.ExecConfig
+	if resource.Exec != nil {
+		user, pass, token, tokenFile = "", "", "", ""
+		exec = &clientcmdapi.ExecConfig{
+			Command:            resource.Exec.Command,
+			Args:               resource.Exec.Args,
+			APIVersion:         resource.Exec.APIVersion,
+			ProvideClusterInfo: resource.Exec.ProvideClusterInfo,
+			// Steps have no terminal to prompt the user from.
+			InteractiveMode: clientcmdapi.NeverExecInteractiveMode,
+		}
+		for _, env := range resource.Exec.Env {
+			exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: env.Name, Value: env.Value})
+		}
+	}
 	auth := &clientcmdapi.AuthInfo{
-		Token:                 resource.Token,
+		Token:                 token,
+		TokenFile:             tokenFile,
 		Username:              user,
 		Password:              pass,
 		ClientKeyData:         clientKeyData,
 		ClientCertificateData: clientCertificateData,
+		Exec:                  exec,
+	}
+	// The user is named after the resource when authenticating without a username.
+	authInfoName := resource.Username
+	if authInfoName == "" {
+		authInfoName = resource.Name
 	}
-	context := &clientcmdapi.Context{
+	c := clientcmdapi.NewConfig()
+	c.Clusters[resource.Name] = cluster
+	c.AuthInfos[authInfoName] = auth
+	c.Contexts[resource.Name] = &clientcmdapi.Context{
 		Cluster:  resource.Name,
-		AuthInfo: resource.Username,
+		AuthInfo: authInfoName,
 		// Namespace isn't written to kubeconfig if this is empty
 		Namespace: resource.Namespace,
 	}
-	c := clientcmdapi.NewConfig()
-	c.Clusters[resource.Name] = cluster
-	c.AuthInfos[resource.Username] = auth
-	c.Contexts[resource.Name] = context
+	for _, context := range resource.Contexts {
+		c.Contexts[context.Name] = &clientcmdapi.Context{
+			Cluster:   resource.Name,
+			AuthInfo:  authInfoName,
+			Namespace: context.Namespace,
+		}
+	}
 	c.CurrentContext = resource.Name
 	c.APIVersion = "v1"
 	c.Kind = "Config"
-
-	// kubeconfig file location
-	var destinationFile string
-
-	// If the destination Directory is provided, kubeconfig will be written to the given directory.
-	// otherwise it will use default location i.e. "/workspace/<cluster-name>/
-	if *destinationDir != "" {
-		destinationFile = filepath.Join(*destinationDir, "kubeconfig")
-	} else {
-		destinationFile = filepath.Join("/workspace", resource.Name, "kubeconfig")
-	}
-
-	if err := clientcmd.WriteToFile(*c, destinationFile); err != nil {
-		logger.Fatalf("Error writing kubeconfig to file: %v", err)
-	}
-	logger.Infof("kubeconfig file successfully written to %s", destinationFile)
+	return c
 }
@@ -0,0 +1,162 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/cluster"
+	"github.com/tektoncd/pipeline/test/diff"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"knative.dev/pkg/logging"
+)
+
+func TestNewKubeconfig(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		resource     *cluster.Resource
+		env          map[string]string
+		wantAuthName string
+		wantAuth     *clientcmdapi.AuthInfo
+		wantContexts map[string]string
+	}{{
+		desc: "token",
+		resource: &cluster.Resource{
+			Name:     "test-cluster",
+			Username: "admin",
+			Password: "pass",
+			Token:    "my-token",
+		},
+		wantAuthName: "admin",
+		wantAuth:     &clientcmdapi.AuthInfo{Token: "my-token"},
+	}, {
+		desc: "token from environment overrides token file",
+		resource: &cluster.Resource{
+			Name:      "test-cluster",
+			TokenFile: "/var/run/secrets/tokens/cluster-token",
+		},
+		env:          map[string]string{"TOKEN": "my-token\n"},
+		wantAuthName: "test-cluster",
+		wantAuth:     &clientcmdapi.AuthInfo{Token: "my-token"},
+	}, {
+		desc: "token file",
+		resource: &cluster.Resource{
+			Name:      "test-cluster",
+			Username:  "admin",
+			Password:  "pass",
+			TokenFile: "/var/run/secrets/tokens/cluster-token",
+		},
+		wantAuthName: "admin",
+		wantAuth:     &clientcmdapi.AuthInfo{TokenFile: "/var/run/secrets/tokens/cluster-token"},
+	}, {
+		desc: "exec",
+		resource: &cluster.Resource{
+			Name:      "test-cluster",
+			Token:     "my-token",
+			TokenFile: "/var/run/secrets/tokens/cluster-token",
+			Exec: &cluster.ExecConfig{
+				Command:            "aws",
+				Args:               []string{"eks", "get-token", "--cluster-name", "prod"},
+				Env:                []cluster.ExecEnvVar{{Name: "AWS_REGION", Value: "eu-west-1"}},
+				APIVersion:         "client.authentication.k8s.io/v1",
+				ProvideClusterInfo: true,
+			},
+		},
+		wantAuthName: "test-cluster",
+		wantAuth: &clientcmdapi.AuthInfo{
+			Exec: &clientcmdapi.ExecConfig{
+				Command:            "aws",
+				Args:               []string{"eks", "get-token", "--cluster-name", "prod"},
+				Env:                []clientcmdapi.ExecEnvVar{{Name: "AWS_REGION", Value: "eu-west-1"}},
+				APIVersion:         "client.authentication.k8s.io/v1",
+				ProvideClusterInfo: true,
+				InteractiveMode:    clientcmdapi.NeverExecInteractiveMode,
+			},
+		},
+	}, {
+		desc: "contexts",
+		resource: &cluster.Resource{
+			Name:      "test-cluster",
+			Namespace: "default",
+			Username:  "admin",
+			Password:  "pass",
+			Contexts: []cluster.Context{{
+				Name:      "staging",
+				Namespace: "staging",
+			}, {
+				Name: "all-namespaces",
+			}},
+		},
+		wantAuthName: "admin",
+		wantAuth:     &clientcmdapi.AuthInfo{Username: "admin", Password: "pass"},
+		wantContexts: map[string]string{"staging": "staging", "all-namespaces": ""},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			for _, k := range []string{"CADATA", "TOKEN", "USERNAME", "PASSWORD"} {
+				t.Setenv(k, c.env[k])
+			}
+			got := newKubeconfig(c.resource)
+
+			wantContexts := map[string]*clientcmdapi.Context{
+				c.resource.Name: {Cluster: c.resource.Name, AuthInfo: c.wantAuthName, Namespace: c.resource.Namespace},
+			}
+			for name, namespace := range c.wantContexts {
+				wantContexts[name] = &clientcmdapi.Context{Cluster: c.resource.Name, AuthInfo: c.wantAuthName, Namespace: namespace}
+			}
+			if d := cmp.Diff(wantContexts, got.Contexts); d != "" {
+				t.Errorf("Contexts %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(map[string]*clientcmdapi.AuthInfo{c.wantAuthName: c.wantAuth}, got.AuthInfos); d != "" {
+				t.Errorf("AuthInfos %s", diff.PrintWantGot(d))
+			}
+			if got.CurrentContext != c.resource.Name {
+				t.Errorf("CurrentContext = %s, want %s", got.CurrentContext, c.resource.Name)
+			}
+		})
+	}
+}
+
+func TestCreateKubeconfigFileWithExec(t *testing.T) {
+	resource := &cluster.Resource{
+		Name: "test-cluster",
+		URL:  "https://10.10.10.10",
+		Exec: &cluster.ExecConfig{
+			Command:    "aws",
+			Args:       []string{"eks", "get-token"},
+			APIVersion: cluster.DefaultExecAPIVersion,
+		},
+		Contexts: []cluster.Context{{Name: "staging", Namespace: "staging"}},
+	}
+	dir := t.TempDir()
+	logger, _ := logging.NewLogger("", "kubeconfig")
+	createKubeconfigFile(resource, logger, &dir)
+
+	// The written kubeconfig must be usable by the clients of the cluster.
+	config, err := clientcmd.LoadFromFile(filepath.Join(dir, "kubeconfig"))
+	if err != nil {
+		t.Fatalf("LoadFromFile() = %v", err)
+	}
+	if _, err := clientcmd.NewNonInteractiveClientConfig(*config, "staging", &clientcmd.ConfigOverrides{}, nil).ClientConfig(); err != nil {
+		t.Errorf("ClientConfig() = %v", err)
+	}
+	if got := config.AuthInfos["test-cluster"].Exec; got == nil || got.Command != "aws" {
+		t.Errorf("Exec = %v, want the aws command", got)
+	}
+}
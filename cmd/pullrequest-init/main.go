@@ -49,7 +49,7 @@ func main() {
 	token := os.Getenv("AUTH_TOKEN")
 	client, err := pullrequest.NewSCMHandler(logger, *prURL, *provider, token, *skipTLSVerify)
 	if err != nil {
-		logger.Fatalf("error creating GitHub client: %v", err)
+		logger.Fatalf("error creating SCM client: %v", err)
 	}
 
 	switch *mode {
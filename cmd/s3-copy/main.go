@@ -0,0 +1,75 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// s3-copy copies files between the local file system and the buckets of an
+// S3-compatible storage, such as AWS S3 or MinIO:
+//
+//	s3-copy [-endpoint URL] [-region REGION] [-path-style] [-r] SRC DST
+//
+// where one of SRC and DST is an s3://bucket/key URL. The credentials are read
+// from the AWS_ACCESS_KEY_ID, AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN
+// environment variables, the requests are anonymous when they are not set.
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/tektoncd/pipeline/pkg/s3copy"
+	"knative.dev/pkg/logging"
+)
+
+var (
+	endpoint  = flag.String("endpoint", "", "The endpoint of the S3-compatible storage, defaults to the AWS S3 endpoint of the region")
+	region    = flag.String("region", "us-east-1", "The region of the S3-compatible storage")
+	pathStyle = flag.Bool("path-style", true, "Address the buckets in the path of the requests instead of in the host name")
+	recursive = flag.Bool("r", false, "Copy the content of a directory, or all the objects under a key")
+)
+
+func main() {
+	flag.Parse()
+	logger, _ := logging.NewLogger("", "s3-copy")
+	defer func() {
+		_ = logger.Sync()
+	}()
+	if flag.NArg() != 2 {
+		logger.Fatalf("Expected a source and a destination, got %v", flag.Args())
+	}
+
+	var credentials *aws.Credentials
+	if id := os.Getenv("AWS_ACCESS_KEY_ID"); id != "" {
+		credentials = &aws.Credentials{
+			AccessKeyID:     id,
+			SecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+			SessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		}
+	}
+	opts := s3copy.Options{
+		Endpoint:    *endpoint,
+		Region:      *region,
+		PathStyle:   *pathStyle,
+		Credentials: credentials,
+		Recursive:   *recursive,
+	}
+
+	src, dst := flag.Arg(0), flag.Arg(1)
+	if err := s3copy.Copy(context.Background(), src, dst, opts); err != nil {
+		logger.Fatalf("Error copying %s to %s: %v", src, dst, err)
+	}
+	logger.Infof("Successfully copied %s to %s", src, dst)
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// archiveLocationKey is the configmap key for the URL the records of finished runs are archived to
+	archiveLocationKey = "location"
+	// archiveEndpointKey is the configmap key for the endpoint of the S3-compatible storage
+	archiveEndpointKey = "endpoint"
+	// archiveRegionKey is the configmap key for the region of the S3-compatible storage
+	archiveRegionKey = "region"
+	// archiveSecretNameKey is the configmap key for the name of the secret holding the
+	// credentials of the S3-compatible storage
+	archiveSecretNameKey = "secret-name"
+
+	// DefaultArchiveLocation is the default value for "location", archiving is disabled
+	DefaultArchiveLocation = ""
+	// DefaultArchiveRegion is the default value for "region"
+	DefaultArchiveRegion = "us-east-1"
+)
+
+// Archive holds the configurations for archiving the records of finished runs
+// +k8s:deepcopy-gen=true
+type Archive struct {
+	Location   string
+	Endpoint   string
+	Region     string
+	SecretName string
+}
+
+// GetArchiveConfigName returns the name of the configmap containing all
+// customizations for archiving.
+func GetArchiveConfigName() string {
+	if e := os.Getenv("CONFIG_ARCHIVE_NAME"); e != "" {
+		return e
+	}
+	return "config-archive"
+}
+
+// Enabled returns true if the records of finished runs are archived.
+func (cfg *Archive) Enabled() bool {
+	return cfg != nil && cfg.Location != ""
+}
+
+// Equals returns true if two Configs are identical
+func (cfg *Archive) Equals(other *Archive) bool {
+	if cfg == nil && other == nil {
+		return true
+	}
+
+	if cfg == nil || other == nil {
+		return false
+	}
+
+	return other.Location == cfg.Location &&
+		other.Endpoint == cfg.Endpoint &&
+		other.Region == cfg.Region &&
+		other.SecretName == cfg.SecretName
+}
+
+// NewArchiveFromMap returns a Config given a map corresponding to a ConfigMap
+func NewArchiveFromMap(cfgMap map[string]string) (*Archive, error) {
+	a := Archive{
+		Location: DefaultArchiveLocation,
+		Region:   DefaultArchiveRegion,
+	}
+
+	if location, ok := cfgMap[archiveLocationKey]; ok && location != "" {
+		u, err := url.Parse(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing archive config %q: %w", archiveLocationKey, err)
+		}
+		if u.Scheme == "" {
+			return nil, fmt.Errorf("archive config %q must be a URL, got %q", archiveLocationKey, location)
+		}
+		a.Location = location
+	}
+
+	if endpoint, ok := cfgMap[archiveEndpointKey]; ok && endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing archive config %q: %w", archiveEndpointKey, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("archive config %q must be an http or https URL, got %q", archiveEndpointKey, endpoint)
+		}
+		a.Endpoint = endpoint
+	}
+
+	if region, ok := cfgMap[archiveRegionKey]; ok && region != "" {
+		a.Region = region
+	}
+
+	if secretName, ok := cfgMap[archiveSecretNameKey]; ok {
+		a.SecretName = secretName
+	}
+
+	return &a, nil
+}
+
+// NewArchiveFromConfigMap returns a Config given a ConfigMap
+func NewArchiveFromConfigMap(config *corev1.ConfigMap) (*Archive, error) {
+	return NewArchiveFromMap(config.Data)
+}
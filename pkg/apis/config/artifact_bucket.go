@@ -17,7 +17,9 @@ limitations under the License.
 package config
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 
 	corev1 "k8s.io/api/core/v1"
 )
@@ -45,6 +47,29 @@ const (
 	// the field name that should be used for the service account.
 	// Valid values: GOOGLE_APPLICATION_CREDENTIALS, BOTO_CONFIG.
 	BucketServiceAccountFieldNameKey = "bucket.service.account.field.name"
+
+	// BucketS3EndpointKey is the name of the configmap entry that specifies the endpoint
+	// of the S3-compatible storage of an s3:// bucket, defaulting to the AWS S3 endpoint
+	// of the region.
+	BucketS3EndpointKey = "bucket.s3.endpoint"
+
+	// BucketS3RegionKey is the name of the configmap entry that specifies the region
+	// of the S3-compatible storage of an s3:// bucket.
+	BucketS3RegionKey = "bucket.s3.region"
+
+	// BucketS3PathStyleKey is the name of the configmap entry that specifies whether
+	// the s3:// bucket is addressed in the path of the requests, as most S3-compatible
+	// storages expect, instead of in the host name.
+	BucketS3PathStyleKey = "bucket.s3.path.style"
+
+	// BucketS3SecretNameKey is the name of the configmap entry that specifies the name
+	// of the secret holding the credentials of the S3-compatible storage of an s3:// bucket.
+	// This secret must have the keys access-key-id and secret-access-key, and optionally
+	// session-token.
+	BucketS3SecretNameKey = "bucket.s3.secret.name"
+
+	// DefaultBucketS3Region is the default value for "bucket.s3.region"
+	DefaultBucketS3Region = "us-east-1"
 )
 
 // ArtifactBucket holds the configurations for the artifacts PVC
@@ -54,6 +79,10 @@ type ArtifactBucket struct {
 	ServiceAccountSecretName string
 	ServiceAccountSecretKey  string
 	ServiceAccountFieldName  string
+	S3Endpoint               string
+	S3Region                 string
+	S3PathStyle              bool
+	S3SecretName             string
 }
 
 // GetArtifactBucketConfigName returns the name of the configmap containing all
@@ -78,13 +107,19 @@ func (cfg *ArtifactBucket) Equals(other *ArtifactBucket) bool {
 	return other.Location == cfg.Location &&
 		other.ServiceAccountSecretName == cfg.ServiceAccountSecretName &&
 		other.ServiceAccountSecretKey == cfg.ServiceAccountSecretKey &&
-		other.ServiceAccountFieldName == cfg.ServiceAccountFieldName
+		other.ServiceAccountFieldName == cfg.ServiceAccountFieldName &&
+		other.S3Endpoint == cfg.S3Endpoint &&
+		other.S3Region == cfg.S3Region &&
+		other.S3PathStyle == cfg.S3PathStyle &&
+		other.S3SecretName == cfg.S3SecretName
 }
 
 // NewArtifactBucketFromMap returns a Config given a map corresponding to a ConfigMap
 func NewArtifactBucketFromMap(cfgMap map[string]string) (*ArtifactBucket, error) {
 	tc := ArtifactBucket{
 		ServiceAccountFieldName: DefaultBucketServiceFieldName,
+		S3Region:                DefaultBucketS3Region,
+		S3PathStyle:             true,
 	}
 
 	if location, ok := cfgMap[BucketLocationKey]; ok {
@@ -103,6 +138,26 @@ func NewArtifactBucketFromMap(cfgMap map[string]string) (*ArtifactBucket, error)
 		tc.ServiceAccountFieldName = serviceAccountFieldName
 	}
 
+	if s3Endpoint, ok := cfgMap[BucketS3EndpointKey]; ok {
+		tc.S3Endpoint = s3Endpoint
+	}
+
+	if s3Region, ok := cfgMap[BucketS3RegionKey]; ok && s3Region != "" {
+		tc.S3Region = s3Region
+	}
+
+	if s3PathStyle, ok := cfgMap[BucketS3PathStyleKey]; ok && s3PathStyle != "" {
+		pathStyle, err := strconv.ParseBool(s3PathStyle)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing artifact bucket config %q: %w", BucketS3PathStyleKey, err)
+		}
+		tc.S3PathStyle = pathStyle
+	}
+
+	if s3SecretName, ok := cfgMap[BucketS3SecretNameKey]; ok {
+		tc.S3SecretName = s3SecretName
+	}
+
 	return &tc, nil
 }
 
@@ -37,6 +37,8 @@ func TestNewArtifactBucketFromConfigMap(t *testing.T) {
 			expectedConfig: &config.ArtifactBucket{
 				Location:                "gs://my-bucket",
 				ServiceAccountFieldName: "GOOGLE_APPLICATION_CREDENTIALS",
+				S3Region:                "us-east-1",
+				S3PathStyle:             true,
 			},
 			fileName: config.GetArtifactBucketConfigName(),
 		},
@@ -46,9 +48,22 @@ func TestNewArtifactBucketFromConfigMap(t *testing.T) {
 				ServiceAccountSecretName: "test-secret",
 				ServiceAccountSecretKey:  "key",
 				ServiceAccountFieldName:  "some-field",
+				S3Region:                 "us-east-1",
+				S3PathStyle:              true,
 			},
 			fileName: "config-artifact-bucket-all-set",
 		},
+		{
+			expectedConfig: &config.ArtifactBucket{
+				Location:                "s3://test-bucket",
+				ServiceAccountFieldName: "GOOGLE_APPLICATION_CREDENTIALS",
+				S3Endpoint:              "http://minio.minio.svc:9000",
+				S3Region:                "eu-west-1",
+				S3PathStyle:             false,
+				S3SecretName:            "minio-credentials",
+			},
+			fileName: "config-artifact-bucket-s3",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -60,10 +75,19 @@ func TestNewArtifactBucketFromEmptyConfigMap(t *testing.T) {
 	ArtifactBucketConfigEmptyName := "config-artifact-bucket-empty"
 	expectedConfig := &config.ArtifactBucket{
 		ServiceAccountFieldName: "GOOGLE_APPLICATION_CREDENTIALS",
+		S3Region:                "us-east-1",
+		S3PathStyle:             true,
 	}
 	verifyConfigFileWithExpectedArtifactBucketConfig(t, ArtifactBucketConfigEmptyName, expectedConfig)
 }
 
+func TestNewArtifactBucketFromInvalidConfigMap(t *testing.T) {
+	cm := test.ConfigMapFromTestFile(t, "config-artifact-bucket-invalid-s3-path-style")
+	if _, err := config.NewArtifactBucketFromConfigMap(cm); err == nil {
+		t.Error("NewArtifactBucketFromConfigMap() = nil, want an error")
+	}
+}
+
 func TestGetArtifactBucketConfigName(t *testing.T) {
 	for _, tc := range []struct {
 		description             string
@@ -25,6 +25,7 @@ import (
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"sigs.k8s.io/yaml"
 )
 
@@ -41,6 +42,15 @@ const (
 	DefaultCloudEventSinkValue = ""
 	// DefaultMaxMatrixCombinationsCount is used when no max matrix combinations count is specified.
 	DefaultMaxMatrixCombinationsCount = 256
+	// DefaultCacheWorkspaceTTLMinutes is used when no cache workspace TTL is specified.
+	DefaultCacheWorkspaceTTLMinutes = 7 * 24 * 60
+	// DefaultMaxCacheWorkspacesCount is used when no max cache workspaces count is specified.
+	DefaultMaxCacheWorkspacesCount = 10
+	// NoTTLSecondsAfterFinished is used when finished PipelineRuns and TaskRuns should never be deleted.
+	NoTTLSecondsAfterFinished = -1
+	// NoMaxCompletedPipelineRunsPerPipeline is used when completed PipelineRuns should not be deleted
+	// whatever their number.
+	NoMaxCompletedPipelineRunsPerPipeline = 0
 
 	defaultTimeoutMinutesKey             = "default-timeout-minutes"
 	defaultServiceAccountKey             = "default-service-account"
@@ -50,6 +60,11 @@ const (
 	defaultCloudEventsSinkKey            = "default-cloud-events-sink"
 	defaultTaskRunWorkspaceBinding       = "default-task-run-workspace-binding"
 	defaultMaxMatrixCombinationsCountKey = "default-max-matrix-combinations-count"
+	defaultCacheWorkspaceTTLMinutesKey   = "default-cache-workspace-ttl-minutes"
+	defaultMaxCacheWorkspacesCountKey    = "default-max-cache-workspaces-count"
+	defaultVolumeClaimTemplatePolicyKey  = "default-volume-claim-template-policy"
+	defaultTTLSecondsAfterFinishedKey    = "default-ttl-seconds-after-finished"
+	defaultMaxCompletedPipelineRunsKey   = "default-max-completed-pipelineruns-per-pipeline"
 )
 
 // Defaults holds the default configurations
@@ -63,6 +78,17 @@ type Defaults struct {
 	DefaultCloudEventsSink            string
 	DefaultTaskRunWorkspaceBinding    string
 	DefaultMaxMatrixCombinationsCount int
+	DefaultCacheWorkspaceTTLMinutes   int
+	DefaultMaxCacheWorkspacesCount    int
+	// DefaultVolumeClaimTemplatePolicies are the defaults and limits of the
+	// volumeClaimTemplates of workspaces, nil if there are none.
+	DefaultVolumeClaimTemplatePolicies *VolumeClaimTemplatePolicies
+	// DefaultTTLSecondsAfterFinished is the ttlSecondsAfterFinished of the PipelineRuns
+	// and TaskRuns not specifying one, NoTTLSecondsAfterFinished if they are kept.
+	DefaultTTLSecondsAfterFinished int
+	// DefaultMaxCompletedPipelineRunsPerPipeline is the number of completed PipelineRuns
+	// kept for each Pipeline, NoMaxCompletedPipelineRunsPerPipeline if they are all kept.
+	DefaultMaxCompletedPipelineRunsPerPipeline int
 }
 
 // GetDefaultsConfigName returns the name of the configmap containing all
@@ -91,7 +117,12 @@ func (cfg *Defaults) Equals(other *Defaults) bool {
 		other.DefaultAAPodTemplate.Equals(cfg.DefaultAAPodTemplate) &&
 		other.DefaultCloudEventsSink == cfg.DefaultCloudEventsSink &&
 		other.DefaultTaskRunWorkspaceBinding == cfg.DefaultTaskRunWorkspaceBinding &&
-		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount
+		other.DefaultMaxMatrixCombinationsCount == cfg.DefaultMaxMatrixCombinationsCount &&
+		other.DefaultCacheWorkspaceTTLMinutes == cfg.DefaultCacheWorkspaceTTLMinutes &&
+		other.DefaultMaxCacheWorkspacesCount == cfg.DefaultMaxCacheWorkspacesCount &&
+		equality.Semantic.DeepEqual(other.DefaultVolumeClaimTemplatePolicies, cfg.DefaultVolumeClaimTemplatePolicies) &&
+		other.DefaultTTLSecondsAfterFinished == cfg.DefaultTTLSecondsAfterFinished &&
+		other.DefaultMaxCompletedPipelineRunsPerPipeline == cfg.DefaultMaxCompletedPipelineRunsPerPipeline
 }
 
 // NewDefaultsFromMap returns a Config given a map corresponding to a ConfigMap
@@ -102,6 +133,9 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		DefaultManagedByLabelValue:        DefaultManagedByLabelValue,
 		DefaultCloudEventsSink:            DefaultCloudEventSinkValue,
 		DefaultMaxMatrixCombinationsCount: DefaultMaxMatrixCombinationsCount,
+		DefaultCacheWorkspaceTTLMinutes:   DefaultCacheWorkspaceTTLMinutes,
+		DefaultMaxCacheWorkspacesCount:    DefaultMaxCacheWorkspacesCount,
+		DefaultTTLSecondsAfterFinished:    NoTTLSecondsAfterFinished,
 	}
 
 	if defaultTimeoutMin, ok := cfgMap[defaultTimeoutMinutesKey]; ok {
@@ -152,6 +186,49 @@ func NewDefaultsFromMap(cfgMap map[string]string) (*Defaults, error) {
 		tc.DefaultMaxMatrixCombinationsCount = int(matrixCombinationsCount)
 	}
 
+	if defaultCacheWorkspaceTTLMinutes, ok := cfgMap[defaultCacheWorkspaceTTLMinutesKey]; ok {
+		ttl, err := strconv.ParseInt(defaultCacheWorkspaceTTLMinutes, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultCacheWorkspaceTTLMinutesKey)
+		}
+		tc.DefaultCacheWorkspaceTTLMinutes = int(ttl)
+	}
+
+	if defaultMaxCacheWorkspacesCount, ok := cfgMap[defaultMaxCacheWorkspacesCountKey]; ok {
+		count, err := strconv.ParseInt(defaultMaxCacheWorkspacesCount, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultMaxCacheWorkspacesCountKey)
+		}
+		tc.DefaultMaxCacheWorkspacesCount = int(count)
+	}
+
+	if defaultVolumeClaimTemplatePolicy, ok := cfgMap[defaultVolumeClaimTemplatePolicyKey]; ok {
+		var policies VolumeClaimTemplatePolicies
+		if err := yamlUnmarshal(defaultVolumeClaimTemplatePolicy, defaultVolumeClaimTemplatePolicyKey, &policies); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %v: %w", defaultVolumeClaimTemplatePolicy, err)
+		}
+		tc.DefaultVolumeClaimTemplatePolicies = &policies
+	}
+
+	if defaultTTLSecondsAfterFinished, ok := cfgMap[defaultTTLSecondsAfterFinishedKey]; ok {
+		ttl, err := strconv.ParseInt(defaultTTLSecondsAfterFinished, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultTTLSecondsAfterFinishedKey)
+		}
+		if ttl < 0 && ttl != NoTTLSecondsAfterFinished {
+			return nil, fmt.Errorf("invalid value for defaults config %q: %d, must be >= 0 or %d", defaultTTLSecondsAfterFinishedKey, ttl, NoTTLSecondsAfterFinished)
+		}
+		tc.DefaultTTLSecondsAfterFinished = int(ttl)
+	}
+
+	if defaultMaxCompletedPipelineRuns, ok := cfgMap[defaultMaxCompletedPipelineRunsKey]; ok {
+		count, err := strconv.ParseInt(defaultMaxCompletedPipelineRuns, 10, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing defaults config %q", defaultMaxCompletedPipelineRunsKey)
+		}
+		tc.DefaultMaxCompletedPipelineRunsPerPipeline = int(count)
+	}
+
 	return &tc, nil
 }
 
@@ -24,6 +24,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestNewDefaultsFromConfigMap(t *testing.T) {
@@ -40,6 +42,9 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultServiceAccount:             "tekton",
 				DefaultManagedByLabelValue:        "something-else",
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCacheWorkspaceTTLMinutes:   config.DefaultCacheWorkspaceTTLMinutes,
+				DefaultMaxCacheWorkspacesCount:    config.DefaultMaxCacheWorkspacesCount,
+				DefaultTTLSecondsAfterFinished:    config.NoTTLSecondsAfterFinished,
 			},
 			fileName: config.GetDefaultsConfigName(),
 		},
@@ -59,6 +64,9 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 					},
 				},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCacheWorkspaceTTLMinutes:   config.DefaultCacheWorkspaceTTLMinutes,
+				DefaultMaxCacheWorkspacesCount:    config.DefaultMaxCacheWorkspacesCount,
+				DefaultTTLSecondsAfterFinished:    config.NoTTLSecondsAfterFinished,
 			},
 			fileName: "config-defaults-with-pod-template",
 		},
@@ -78,6 +86,9 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
 				DefaultPodTemplate:                &pod.Template{},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCacheWorkspaceTTLMinutes:   config.DefaultCacheWorkspaceTTLMinutes,
+				DefaultMaxCacheWorkspacesCount:    config.DefaultMaxCacheWorkspacesCount,
+				DefaultTTLSecondsAfterFinished:    config.NoTTLSecondsAfterFinished,
 			},
 		},
 		{
@@ -89,17 +100,94 @@ func TestNewDefaultsFromConfigMap(t *testing.T) {
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
 				DefaultAAPodTemplate:              &pod.AffinityAssistantTemplate{},
 				DefaultMaxMatrixCombinationsCount: 256,
+				DefaultCacheWorkspaceTTLMinutes:   config.DefaultCacheWorkspaceTTLMinutes,
+				DefaultMaxCacheWorkspacesCount:    config.DefaultMaxCacheWorkspacesCount,
+				DefaultTTLSecondsAfterFinished:    config.NoTTLSecondsAfterFinished,
 			},
 		},
 		{
 			expectedError: true,
 			fileName:      "config-defaults-matrix-err",
 		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-cache-workspace",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+				DefaultCacheWorkspaceTTLMinutes:   60,
+				DefaultMaxCacheWorkspacesCount:    3,
+				DefaultTTLSecondsAfterFinished:    config.NoTTLSecondsAfterFinished,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-cache-workspace-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-run-retention",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:                      60,
+				DefaultServiceAccount:                      "default",
+				DefaultManagedByLabelValue:                 config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount:          config.DefaultMaxMatrixCombinationsCount,
+				DefaultCacheWorkspaceTTLMinutes:            config.DefaultCacheWorkspaceTTLMinutes,
+				DefaultMaxCacheWorkspacesCount:             config.DefaultMaxCacheWorkspacesCount,
+				DefaultTTLSecondsAfterFinished:             3600,
+				DefaultMaxCompletedPipelineRunsPerPipeline: 5,
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-run-retention-err",
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-ttl-seconds-after-finished-err",
+		},
+		{
+			expectedError: false,
+			fileName:      "config-defaults-volume-claim-template-policy",
+			expectedConfig: &config.Defaults{
+				DefaultTimeoutMinutes:             60,
+				DefaultServiceAccount:             "default",
+				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
+				DefaultMaxMatrixCombinationsCount: config.DefaultMaxMatrixCombinationsCount,
+				DefaultCacheWorkspaceTTLMinutes:   config.DefaultCacheWorkspaceTTLMinutes,
+				DefaultMaxCacheWorkspacesCount:    config.DefaultMaxCacheWorkspacesCount,
+				DefaultTTLSecondsAfterFinished:    config.NoTTLSecondsAfterFinished,
+				DefaultVolumeClaimTemplatePolicies: &config.VolumeClaimTemplatePolicies{
+					VolumeClaimTemplatePolicy: config.VolumeClaimTemplatePolicy{
+						StorageClassName:         "standard",
+						Storage:                  quantity("1Gi"),
+						AccessModes:              []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						MaxStorage:               quantity("10Gi"),
+						AllowedStorageClassNames: []string{"standard", "fast"},
+					},
+					Namespaces: map[string]config.VolumeClaimTemplatePolicy{
+						"builds": {
+							StorageClassName: "fast",
+							MaxStorage:       quantity("100Gi"),
+						},
+					},
+				},
+			},
+		},
+		{
+			expectedError: true,
+			fileName:      "config-defaults-volume-claim-template-policy-err",
+		},
 		{
 			expectedError: false,
 			fileName:      "config-defaults-matrix",
 			expectedConfig: &config.Defaults{
 				DefaultMaxMatrixCombinationsCount: 1024,
+				DefaultCacheWorkspaceTTLMinutes:   config.DefaultCacheWorkspaceTTLMinutes,
+				DefaultMaxCacheWorkspacesCount:    config.DefaultMaxCacheWorkspacesCount,
+				DefaultTTLSecondsAfterFinished:    config.NoTTLSecondsAfterFinished,
 				DefaultTimeoutMinutes:             60,
 				DefaultServiceAccount:             "default",
 				DefaultManagedByLabelValue:        config.DefaultManagedByLabelValue,
@@ -125,6 +213,9 @@ func TestNewDefaultsFromEmptyConfigMap(t *testing.T) {
 		DefaultManagedByLabelValue:        "tekton-pipelines",
 		DefaultServiceAccount:             "default",
 		DefaultMaxMatrixCombinationsCount: 256,
+		DefaultCacheWorkspaceTTLMinutes:   config.DefaultCacheWorkspaceTTLMinutes,
+		DefaultMaxCacheWorkspacesCount:    config.DefaultMaxCacheWorkspacesCount,
+		DefaultTTLSecondsAfterFinished:    config.NoTTLSecondsAfterFinished,
 	}
 	verifyConfigFileWithExpectedConfig(t, DefaultsConfigEmptyName, expectedConfig)
 }
@@ -284,3 +375,8 @@ func verifyConfigFileWithExpectedError(t *testing.T, fileName string) {
 		t.Errorf("NewDefaultsFromConfigMap(actual) was expected to return an error")
 	}
 }
+
+func quantity(s string) *resource.Quantity {
+	q := resource.MustParse(s)
+	return &q
+}
@@ -40,6 +40,22 @@ const (
 	// MinimalEmbeddedStatus is the value used for "embedded-status" when only ChildReferences should be used in
 	// PipelineRunStatusFields.
 	MinimalEmbeddedStatus = "minimal"
+	// CoscheduleWorkspaces is the value used for "coschedule" when the TaskRun pods sharing a PersistentVolumeClaim
+	// workspace should be scheduled to the same Node, using one Affinity Assistant per workspace.
+	CoscheduleWorkspaces = "workspaces"
+	// CoschedulePipelineRuns is the value used for "coschedule" when all the TaskRun pods of a PipelineRun should be
+	// scheduled to the same Node, using one Affinity Assistant per PipelineRun.
+	CoschedulePipelineRuns = "pipelineruns"
+	// NeverWorkspacePVCCleanup is the value used for "workspace-pvc-cleanup" when the PersistentVolumeClaims
+	// created from the volumeClaimTemplates of a PipelineRun should be kept until the PipelineRun is deleted.
+	NeverWorkspacePVCCleanup = "never"
+	// AlwaysWorkspacePVCCleanup is the value used for "workspace-pvc-cleanup" when the PersistentVolumeClaims
+	// created from the volumeClaimTemplates of a PipelineRun should be deleted as soon as it completes.
+	AlwaysWorkspacePVCCleanup = "always"
+	// OnSuccessWorkspacePVCCleanup is the value used for "workspace-pvc-cleanup" when the PersistentVolumeClaims
+	// created from the volumeClaimTemplates of a PipelineRun should be deleted as soon as it succeeds, and kept
+	// for debugging when it fails.
+	OnSuccessWorkspacePVCCleanup = "on-success"
 	// DefaultDisableAffinityAssistant is the default value for "disable-affinity-assistant".
 	DefaultDisableAffinityAssistant = false
 	// DefaultDisableCredsInit is the default value for "disable-creds-init".
@@ -60,6 +76,10 @@ const (
 	DefaultSendCloudEventsForRuns = false
 	// DefaultEmbeddedStatus is the default value for "embedded-status".
 	DefaultEmbeddedStatus = FullEmbeddedStatus
+	// DefaultCoschedule is the default value for "coschedule".
+	DefaultCoschedule = CoscheduleWorkspaces
+	// DefaultWorkspacePVCCleanup is the default value for "workspace-pvc-cleanup".
+	DefaultWorkspacePVCCleanup = NeverWorkspacePVCCleanup
 
 	disableAffinityAssistantKey         = "disable-affinity-assistant"
 	disableCredsInitKey                 = "disable-creds-init"
@@ -71,6 +91,8 @@ const (
 	enableAPIFields                     = "enable-api-fields"
 	sendCloudEventsForRuns              = "send-cloudevents-for-runs"
 	embeddedStatus                      = "embedded-status"
+	coscheduleKey                       = "coschedule"
+	workspacePVCCleanupKey              = "workspace-pvc-cleanup"
 )
 
 // FeatureFlags holds the features configurations
@@ -87,6 +109,8 @@ type FeatureFlags struct {
 	SendCloudEventsForRuns           bool
 	AwaitSidecarReadiness            bool
 	EmbeddedStatus                   string
+	Coschedule                       string
+	WorkspacePVCCleanup              string
 }
 
 // GetFeatureFlagsConfigName returns the name of the configmap containing all
@@ -138,6 +162,12 @@ func NewFeatureFlagsFromMap(cfgMap map[string]string) (*FeatureFlags, error) {
 	if err := setEmbeddedStatus(cfgMap, DefaultEmbeddedStatus, &tc.EmbeddedStatus); err != nil {
 		return nil, err
 	}
+	if err := setCoschedule(cfgMap, DefaultCoschedule, &tc.Coschedule); err != nil {
+		return nil, err
+	}
+	if err := setWorkspacePVCCleanup(cfgMap, DefaultWorkspacePVCCleanup, &tc.WorkspacePVCCleanup); err != nil {
+		return nil, err
+	}
 
 	// Given that they are alpha features, Tekton Bundles and Custom Tasks should be switched on if
 	// enable-api-fields is "alpha". If enable-api-fields is not "alpha" then fall back to the value of
@@ -191,6 +221,45 @@ func setEmbeddedStatus(cfgMap map[string]string, defaultValue string, feature *s
 	return nil
 }
 
+// setCoschedule sets the "coschedule" flag based on the content of a given map.
+// If the feature gate is invalid or missing then an error is returned.
+func setCoschedule(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[coscheduleKey]; ok {
+		value = strings.ToLower(cfg)
+	}
+	switch value {
+	case CoscheduleWorkspaces, CoschedulePipelineRuns:
+		*feature = value
+	default:
+		return fmt.Errorf("invalid value for feature flag %q: %q", coscheduleKey, value)
+	}
+	return nil
+}
+
+// setWorkspacePVCCleanup sets the "workspace-pvc-cleanup" flag based on the content of a given map.
+// If the feature gate is invalid or missing then an error is returned.
+func setWorkspacePVCCleanup(cfgMap map[string]string, defaultValue string, feature *string) error {
+	value := defaultValue
+	if cfg, ok := cfgMap[workspacePVCCleanupKey]; ok {
+		value = strings.ToLower(cfg)
+	}
+	if !IsValidWorkspacePVCCleanup(value) {
+		return fmt.Errorf("invalid value for feature flag %q: %q", workspacePVCCleanupKey, value)
+	}
+	*feature = value
+	return nil
+}
+
+// IsValidWorkspacePVCCleanup returns true if the value is a valid "workspace-pvc-cleanup" policy.
+func IsValidWorkspacePVCCleanup(value string) bool {
+	switch value {
+	case NeverWorkspacePVCCleanup, AlwaysWorkspacePVCCleanup, OnSuccessWorkspacePVCCleanup:
+		return true
+	}
+	return false
+}
+
 // NewFeatureFlagsFromConfigMap returns a Config for the given configmap
 func NewFeatureFlagsFromConfigMap(config *corev1.ConfigMap) (*FeatureFlags, error) {
 	return NewFeatureFlagsFromMap(config.Data)
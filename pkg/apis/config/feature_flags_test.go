@@ -46,6 +46,8 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableAPIFields:        config.DefaultEnableAPIFields,
 				SendCloudEventsForRuns: config.DefaultSendCloudEventsForRuns,
 				EmbeddedStatus:         config.DefaultEmbeddedStatus,
+				Coschedule:             config.DefaultCoschedule,
+				WorkspacePVCCleanup:    config.DefaultWorkspacePVCCleanup,
 			},
 			fileName: config.GetFeatureFlagsConfigName(),
 		},
@@ -60,6 +62,8 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				EnableAPIFields:                  "alpha",
 				SendCloudEventsForRuns:           true,
 				EmbeddedStatus:                   "both",
+				Coschedule:                       "pipelineruns",
+				WorkspacePVCCleanup:              "on-success",
 			},
 			fileName: "feature-flags-all-flags-set",
 		},
@@ -78,6 +82,8 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				RequireGitSSHSecretKnownHosts:    config.DefaultRequireGitSSHSecretKnownHosts,
 				SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
 				EmbeddedStatus:                   config.DefaultEmbeddedStatus,
+				Coschedule:                       config.DefaultCoschedule,
+				WorkspacePVCCleanup:              config.DefaultWorkspacePVCCleanup,
 			},
 			fileName: "feature-flags-enable-api-fields-overrides-bundles-and-custom-tasks",
 		},
@@ -94,6 +100,8 @@ func TestNewFeatureFlagsFromConfigMap(t *testing.T) {
 				RequireGitSSHSecretKnownHosts:    config.DefaultRequireGitSSHSecretKnownHosts,
 				SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
 				EmbeddedStatus:                   config.DefaultEmbeddedStatus,
+				Coschedule:                       config.DefaultCoschedule,
+				WorkspacePVCCleanup:              config.DefaultWorkspacePVCCleanup,
 			},
 			fileName: "feature-flags-bundles-and-custom-tasks",
 		},
@@ -121,6 +129,8 @@ func TestNewFeatureFlagsFromEmptyConfigMap(t *testing.T) {
 		EnableAPIFields:                  config.DefaultEnableAPIFields,
 		SendCloudEventsForRuns:           config.DefaultSendCloudEventsForRuns,
 		EmbeddedStatus:                   config.DefaultEmbeddedStatus,
+		Coschedule:                       config.DefaultCoschedule,
+		WorkspacePVCCleanup:              config.DefaultWorkspacePVCCleanup,
 	}
 	verifyConfigFileWithExpectedFeatureFlagsConfig(t, FeatureFlagsConfigEmptyName, expectedConfig)
 }
@@ -165,6 +175,10 @@ func TestNewFeatureFlagsConfigMapErrors(t *testing.T) {
 		fileName: "feature-flags-invalid-enable-api-fields",
 	}, {
 		fileName: "feature-flags-invalid-embedded-status",
+	}, {
+		fileName: "feature-flags-invalid-coschedule",
+	}, {
+		fileName: "feature-flags-invalid-workspace-pvc-cleanup",
 	}} {
 		t.Run(tc.fileName, func(t *testing.T) {
 			cm := test.ConfigMapFromTestFile(t, tc.fileName)
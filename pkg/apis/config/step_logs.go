@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// stepLogsLocationKey is the configmap key for the URL the logs of the steps are uploaded to
+	stepLogsLocationKey = "location"
+	// stepLogsEndpointKey is the configmap key for the endpoint of the S3-compatible storage
+	stepLogsEndpointKey = "endpoint"
+	// stepLogsRegionKey is the configmap key for the region of the S3-compatible storage
+	stepLogsRegionKey = "region"
+	// stepLogsSecretNameKey is the configmap key for the name of the secret, in the namespace
+	// of the TaskRun, holding the credentials used to upload the logs
+	stepLogsSecretNameKey = "secret-name"
+
+	// DefaultStepLogsLocation is the default value for "location", the logs are not uploaded
+	DefaultStepLogsLocation = ""
+	// DefaultStepLogsRegion is the default value for "region"
+	DefaultStepLogsRegion = "us-east-1"
+)
+
+// StepLogs holds the configurations for uploading the logs of the steps
+// +k8s:deepcopy-gen=true
+type StepLogs struct {
+	Location   string
+	Endpoint   string
+	Region     string
+	SecretName string
+}
+
+// GetStepLogsConfigName returns the name of the configmap containing all
+// customizations for uploading the logs of the steps.
+func GetStepLogsConfigName() string {
+	if e := os.Getenv("CONFIG_STEP_LOGS_NAME"); e != "" {
+		return e
+	}
+	return "config-step-logs"
+}
+
+// Enabled returns true if the logs of the steps are uploaded.
+func (cfg *StepLogs) Enabled() bool {
+	return cfg != nil && cfg.Location != ""
+}
+
+// Equals returns true if two Configs are identical
+func (cfg *StepLogs) Equals(other *StepLogs) bool {
+	if cfg == nil && other == nil {
+		return true
+	}
+
+	if cfg == nil || other == nil {
+		return false
+	}
+
+	return other.Location == cfg.Location &&
+		other.Endpoint == cfg.Endpoint &&
+		other.Region == cfg.Region &&
+		other.SecretName == cfg.SecretName
+}
+
+// NewStepLogsFromMap returns a Config given a map corresponding to a ConfigMap
+func NewStepLogsFromMap(cfgMap map[string]string) (*StepLogs, error) {
+	s := StepLogs{
+		Location: DefaultStepLogsLocation,
+		Region:   DefaultStepLogsRegion,
+	}
+
+	if location, ok := cfgMap[stepLogsLocationKey]; ok && location != "" {
+		u, err := url.Parse(location)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing step logs config %q: %w", stepLogsLocationKey, err)
+		}
+		switch u.Scheme {
+		case "s3", "http", "https":
+		default:
+			return nil, fmt.Errorf("step logs config %q must be an s3, http or https URL, got %q", stepLogsLocationKey, location)
+		}
+		s.Location = location
+	}
+
+	if endpoint, ok := cfgMap[stepLogsEndpointKey]; ok && endpoint != "" {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing step logs config %q: %w", stepLogsEndpointKey, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("step logs config %q must be an http or https URL, got %q", stepLogsEndpointKey, endpoint)
+		}
+		s.Endpoint = endpoint
+	}
+
+	if region, ok := cfgMap[stepLogsRegionKey]; ok && region != "" {
+		s.Region = region
+	}
+
+	if secretName, ok := cfgMap[stepLogsSecretNameKey]; ok {
+		s.SecretName = secretName
+	}
+
+	return &s, nil
+}
+
+// NewStepLogsFromConfigMap returns a Config given a ConfigMap
+func NewStepLogsFromConfigMap(config *corev1.ConfigMap) (*StepLogs, error) {
+	return NewStepLogsFromMap(config.Data)
+}
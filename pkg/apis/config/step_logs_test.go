@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewStepLogsFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		fileName       string
+		expectedConfig *config.StepLogs
+	}{{
+		fileName: config.GetStepLogsConfigName(),
+		expectedConfig: &config.StepLogs{
+			Location:   "s3://tekton-logs/steps",
+			Endpoint:   "http://minio.minio.svc.cluster.local:9000",
+			Region:     "eu-west-1",
+			SecretName: "step-logs-credentials",
+		},
+	}, {
+		fileName: "config-step-logs-empty",
+		expectedConfig: &config.StepLogs{
+			Location: config.DefaultStepLogsLocation,
+			Region:   config.DefaultStepLogsRegion,
+		},
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			got, err := config.NewStepLogsFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewStepLogsFromConfigMap() = %v", err)
+			}
+			if d := cmp.Diff(tc.expectedConfig, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+			if got.Enabled() != (tc.expectedConfig.Location != "") {
+				t.Errorf("Enabled() = %t with location %q", got.Enabled(), got.Location)
+			}
+		})
+	}
+}
+
+func TestNewStepLogsFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-step-logs-invalid-location",
+		"config-step-logs-invalid-endpoint",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, fileName)
+			if _, err := config.NewStepLogsFromConfigMap(cm); err == nil {
+				t.Error("expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestStepLogsEquals(t *testing.T) {
+	s3 := &config.StepLogs{Location: "s3://tekton-logs", Region: config.DefaultStepLogsRegion}
+	for _, tc := range []struct {
+		name  string
+		left  *config.StepLogs
+		right *config.StepLogs
+		want  bool
+	}{{
+		name: "both nil",
+		want: true,
+	}, {
+		name:  "one nil",
+		left:  s3,
+		right: nil,
+		want:  false,
+	}, {
+		name:  "same",
+		left:  s3,
+		right: &config.StepLogs{Location: "s3://tekton-logs", Region: config.DefaultStepLogsRegion},
+		want:  true,
+	}, {
+		name:  "different location",
+		left:  s3,
+		right: &config.StepLogs{Location: "https://logs.example.com/upload", Region: config.DefaultStepLogsRegion},
+		want:  false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.left.Equals(tc.right); got != tc.want {
+				t.Errorf("Equals() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
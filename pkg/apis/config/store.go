@@ -32,6 +32,9 @@ type Config struct {
 	ArtifactBucket *ArtifactBucket
 	ArtifactPVC    *ArtifactPVC
 	Metrics        *Metrics
+	Tracing        *Tracing
+	Archive        *Archive
+	StepLogs       *StepLogs
 }
 
 // FromContext extracts a Config from the provided context.
@@ -54,12 +57,18 @@ func FromContextOrDefaults(ctx context.Context) *Config {
 	artifactBucket, _ := NewArtifactBucketFromMap(map[string]string{})
 	artifactPVC, _ := NewArtifactPVCFromMap(map[string]string{})
 	metrics, _ := newMetricsFromMap(map[string]string{})
+	tracing, _ := NewTracingFromMap(map[string]string{})
+	archive, _ := NewArchiveFromMap(map[string]string{})
+	stepLogs, _ := NewStepLogsFromMap(map[string]string{})
 	return &Config{
 		Defaults:       defaults,
 		FeatureFlags:   featureFlags,
 		ArtifactBucket: artifactBucket,
 		ArtifactPVC:    artifactPVC,
 		Metrics:        metrics,
+		Tracing:        tracing,
+		Archive:        archive,
+		StepLogs:       stepLogs,
 	}
 }
 
@@ -87,6 +96,9 @@ func NewStore(logger configmap.Logger, onAfterStore ...func(name string, value i
 				GetArtifactBucketConfigName(): NewArtifactBucketFromConfigMap,
 				GetArtifactPVCConfigName():    NewArtifactPVCFromConfigMap,
 				GetMetricsConfigName():        NewMetricsFromConfigMap,
+				GetTracingConfigName():        NewTracingFromConfigMap,
+				GetArchiveConfigName():        NewArchiveFromConfigMap,
+				GetStepLogsConfigName():       NewStepLogsFromConfigMap,
 			},
 			onAfterStore...,
 		),
@@ -123,11 +135,26 @@ func (s *Store) Load() *Config {
 	if metrics == nil {
 		metrics, _ = newMetricsFromMap(map[string]string{})
 	}
+	tracing := s.UntypedLoad(GetTracingConfigName())
+	if tracing == nil {
+		tracing, _ = NewTracingFromMap(map[string]string{})
+	}
+	archive := s.UntypedLoad(GetArchiveConfigName())
+	if archive == nil {
+		archive, _ = NewArchiveFromMap(map[string]string{})
+	}
+	stepLogs := s.UntypedLoad(GetStepLogsConfigName())
+	if stepLogs == nil {
+		stepLogs, _ = NewStepLogsFromMap(map[string]string{})
+	}
 	return &Config{
 		Defaults:       defaults.(*Defaults).DeepCopy(),
 		FeatureFlags:   featureFlags.(*FeatureFlags).DeepCopy(),
 		ArtifactBucket: artifactBucket.(*ArtifactBucket).DeepCopy(),
 		ArtifactPVC:    artifactPVC.(*ArtifactPVC).DeepCopy(),
 		Metrics:        metrics.(*Metrics).DeepCopy(),
+		Tracing:        tracing.(*Tracing).DeepCopy(),
+		Archive:        archive.(*Archive).DeepCopy(),
+		StepLogs:       stepLogs.(*StepLogs).DeepCopy(),
 	}
 }
@@ -33,12 +33,18 @@ func TestStoreLoadWithContext(t *testing.T) {
 	artifactBucketConfig := test.ConfigMapFromTestFile(t, "config-artifact-bucket")
 	artifactPVCConfig := test.ConfigMapFromTestFile(t, "config-artifact-pvc")
 	metricsConfig := test.ConfigMapFromTestFile(t, "config-observability")
+	tracingConfig := test.ConfigMapFromTestFile(t, "config-tracing")
+	archiveConfig := test.ConfigMapFromTestFile(t, "config-archive")
+	stepLogsConfig := test.ConfigMapFromTestFile(t, "config-step-logs")
 
 	expectedDefaults, _ := config.NewDefaultsFromConfigMap(defaultConfig)
 	expectedFeatures, _ := config.NewFeatureFlagsFromConfigMap(featuresConfig)
 	expectedArtifactBucket, _ := config.NewArtifactBucketFromConfigMap(artifactBucketConfig)
 	expectedArtifactPVC, _ := config.NewArtifactPVCFromConfigMap(artifactPVCConfig)
 	metrics, _ := config.NewMetricsFromConfigMap(metricsConfig)
+	tracing, _ := config.NewTracingFromConfigMap(tracingConfig)
+	archive, _ := config.NewArchiveFromConfigMap(archiveConfig)
+	stepLogs, _ := config.NewStepLogsFromConfigMap(stepLogsConfig)
 
 	expected := &config.Config{
 		Defaults:       expectedDefaults,
@@ -46,6 +52,9 @@ func TestStoreLoadWithContext(t *testing.T) {
 		ArtifactBucket: expectedArtifactBucket,
 		ArtifactPVC:    expectedArtifactPVC,
 		Metrics:        metrics,
+		Tracing:        tracing,
+		Archive:        archive,
+		StepLogs:       stepLogs,
 	}
 
 	store := config.NewStore(logtesting.TestLogger(t))
@@ -54,6 +63,9 @@ func TestStoreLoadWithContext(t *testing.T) {
 	store.OnConfigChanged(artifactBucketConfig)
 	store.OnConfigChanged(artifactPVCConfig)
 	store.OnConfigChanged(metricsConfig)
+	store.OnConfigChanged(tracingConfig)
+	store.OnConfigChanged(archiveConfig)
+	store.OnConfigChanged(stepLogsConfig)
 
 	cfg := config.FromContext(store.ToContext(context.Background()))
 
@@ -0,0 +1,102 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// tracingEnabledKey is the configmap key which determines whether tracing is enabled
+	tracingEnabledKey = "enabled"
+	// tracingEndpointKey is the configmap key for the OTLP/HTTP collector endpoint
+	tracingEndpointKey = "endpoint"
+
+	// DefaultTracingEnabled is the default value for "enabled"
+	DefaultTracingEnabled = false
+	// DefaultTracingEndpoint is the default OTLP/HTTP endpoint spans are exported to
+	DefaultTracingEndpoint = "http://otel-collector.observability.svc.cluster.local:4318/v1/traces"
+)
+
+// Tracing holds the configurations for tracing
+// +k8s:deepcopy-gen=true
+type Tracing struct {
+	Enabled  bool
+	Endpoint string
+}
+
+// GetTracingConfigName returns the name of the configmap containing all
+// customizations for tracing.
+func GetTracingConfigName() string {
+	if e := os.Getenv("CONFIG_TRACING_NAME"); e != "" {
+		return e
+	}
+	return "config-tracing"
+}
+
+// Equals returns true if two Configs are identical
+func (cfg *Tracing) Equals(other *Tracing) bool {
+	if cfg == nil && other == nil {
+		return true
+	}
+
+	if cfg == nil || other == nil {
+		return false
+	}
+
+	return other.Enabled == cfg.Enabled &&
+		other.Endpoint == cfg.Endpoint
+}
+
+// NewTracingFromMap returns a Config given a map corresponding to a ConfigMap
+func NewTracingFromMap(cfgMap map[string]string) (*Tracing, error) {
+	t := Tracing{
+		Enabled:  DefaultTracingEnabled,
+		Endpoint: DefaultTracingEndpoint,
+	}
+
+	if endpoint, ok := cfgMap[tracingEndpointKey]; ok {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tracing config %q: %w", tracingEndpointKey, err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return nil, fmt.Errorf("tracing config %q must be an http or https URL, got %q", tracingEndpointKey, endpoint)
+		}
+		t.Endpoint = endpoint
+	}
+
+	if enabled, ok := cfgMap[tracingEnabledKey]; ok {
+		e, err := strconv.ParseBool(enabled)
+		if err != nil {
+			return nil, fmt.Errorf("failed parsing tracing config %q: %w", tracingEnabledKey, err)
+		}
+		t.Enabled = e
+	}
+
+	return &t, nil
+}
+
+// NewTracingFromConfigMap returns a Config given a ConfigMap
+func NewTracingFromConfigMap(config *corev1.ConfigMap) (*Tracing, error) {
+	return NewTracingFromMap(config.Data)
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	test "github.com/tektoncd/pipeline/pkg/reconciler/testing"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewTracingFromConfigMap(t *testing.T) {
+	for _, tc := range []struct {
+		fileName       string
+		expectedConfig *config.Tracing
+	}{{
+		fileName: config.GetTracingConfigName(),
+		expectedConfig: &config.Tracing{
+			Enabled:  true,
+			Endpoint: "http://jaeger-collector.jaeger.svc.cluster.local:4318/v1/traces",
+		},
+	}, {
+		fileName: "config-tracing-empty",
+		expectedConfig: &config.Tracing{
+			Enabled:  false,
+			Endpoint: config.DefaultTracingEndpoint,
+		},
+	}} {
+		t.Run(tc.fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, tc.fileName)
+			got, err := config.NewTracingFromConfigMap(cm)
+			if err != nil {
+				t.Fatalf("NewTracingFromConfigMap() = %v", err)
+			}
+			if d := cmp.Diff(tc.expectedConfig, got); d != "" {
+				t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestNewTracingFromConfigMapWithError(t *testing.T) {
+	for _, fileName := range []string{
+		"config-tracing-invalid-endpoint",
+		"config-tracing-invalid-enabled",
+	} {
+		t.Run(fileName, func(t *testing.T) {
+			cm := test.ConfigMapFromTestFile(t, fileName)
+			if _, err := config.NewTracingFromConfigMap(cm); err == nil {
+				t.Error("expected error but got nil")
+			}
+		})
+	}
+}
+
+func TestTracingEquals(t *testing.T) {
+	enabled := &config.Tracing{Enabled: true, Endpoint: config.DefaultTracingEndpoint}
+	for _, tc := range []struct {
+		name  string
+		left  *config.Tracing
+		right *config.Tracing
+		want  bool
+	}{{
+		name: "both nil",
+		want: true,
+	}, {
+		name:  "one nil",
+		left:  enabled,
+		right: nil,
+		want:  false,
+	}, {
+		name:  "same",
+		left:  enabled,
+		right: &config.Tracing{Enabled: true, Endpoint: config.DefaultTracingEndpoint},
+		want:  true,
+	}, {
+		name:  "different endpoint",
+		left:  enabled,
+		right: &config.Tracing{Enabled: true, Endpoint: "http://localhost:4318"},
+		want:  false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.left.Equals(tc.right); got != tc.want {
+				t.Errorf("Equals() = %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
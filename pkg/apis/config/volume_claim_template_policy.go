@@ -0,0 +1,145 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// VolumeClaimTemplatePolicy holds the defaults and the limits applied to the
+// volumeClaimTemplates of workspaces.
+// +k8s:deepcopy-gen=true
+type VolumeClaimTemplatePolicy struct {
+	// StorageClassName is the storage class of the claims not specifying one.
+	StorageClassName string `json:"storageClassName,omitempty"`
+	// Storage is the storage requested by the claims not requesting any.
+	Storage *resource.Quantity `json:"storage,omitempty"`
+	// AccessModes are the access modes of the claims not specifying any.
+	AccessModes []corev1.PersistentVolumeAccessMode `json:"accessModes,omitempty"`
+	// MaxStorage is the maximum storage a claim can request.
+	MaxStorage *resource.Quantity `json:"maxStorage,omitempty"`
+	// AllowedStorageClassNames are the storage classes the claims can use, any if empty.
+	AllowedStorageClassNames []string `json:"allowedStorageClassNames,omitempty"`
+	// AllowedAccessModes are the access modes the claims can use, any if empty.
+	AllowedAccessModes []corev1.PersistentVolumeAccessMode `json:"allowedAccessModes,omitempty"`
+}
+
+// VolumeClaimTemplatePolicies holds the VolumeClaimTemplatePolicy of the cluster,
+// and the policies of the namespaces overriding it.
+// +k8s:deepcopy-gen=true
+type VolumeClaimTemplatePolicies struct {
+	VolumeClaimTemplatePolicy `json:",inline"`
+	// Namespaces are the policies of namespaces, their fields override the
+	// fields of the cluster policy when set.
+	Namespaces map[string]VolumeClaimTemplatePolicy `json:"namespaces,omitempty"`
+}
+
+// ForNamespace returns the VolumeClaimTemplatePolicy applied in the given namespace.
+func (p *VolumeClaimTemplatePolicies) ForNamespace(namespace string) VolumeClaimTemplatePolicy {
+	if p == nil {
+		return VolumeClaimTemplatePolicy{}
+	}
+	policy := *p.VolumeClaimTemplatePolicy.DeepCopy()
+	override, ok := p.Namespaces[namespace]
+	if !ok {
+		return policy
+	}
+	if override.StorageClassName != "" {
+		policy.StorageClassName = override.StorageClassName
+	}
+	if override.Storage != nil {
+		policy.Storage = override.Storage
+	}
+	if len(override.AccessModes) != 0 {
+		policy.AccessModes = override.AccessModes
+	}
+	if override.MaxStorage != nil {
+		policy.MaxStorage = override.MaxStorage
+	}
+	if len(override.AllowedStorageClassNames) != 0 {
+		policy.AllowedStorageClassNames = override.AllowedStorageClassNames
+	}
+	if len(override.AllowedAccessModes) != 0 {
+		policy.AllowedAccessModes = override.AllowedAccessModes
+	}
+	return policy
+}
+
+// SetDefaults sets the storage class, the requested storage and the access modes
+// of the claim to the defaults of the policy when they are not specified.
+func (p VolumeClaimTemplatePolicy) SetDefaults(claim *corev1.PersistentVolumeClaim) {
+	if claim.Spec.StorageClassName == nil && p.StorageClassName != "" {
+		storageClassName := p.StorageClassName
+		claim.Spec.StorageClassName = &storageClassName
+	}
+	if len(claim.Spec.AccessModes) == 0 && len(p.AccessModes) != 0 {
+		claim.Spec.AccessModes = append([]corev1.PersistentVolumeAccessMode{}, p.AccessModes...)
+	}
+	if _, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]; !ok && p.Storage != nil {
+		if claim.Spec.Resources.Requests == nil {
+			claim.Spec.Resources.Requests = corev1.ResourceList{}
+		}
+		claim.Spec.Resources.Requests[corev1.ResourceStorage] = p.Storage.DeepCopy()
+	}
+}
+
+// Validate returns an error if the claim doesn't comply with the limits of the policy.
+func (p VolumeClaimTemplatePolicy) Validate(claim *corev1.PersistentVolumeClaim) error {
+	if len(p.AllowedStorageClassNames) != 0 {
+		storageClassName := ""
+		if claim.Spec.StorageClassName != nil {
+			storageClassName = *claim.Spec.StorageClassName
+		}
+		if !containsString(p.AllowedStorageClassNames, storageClassName) {
+			return fmt.Errorf("storage class %q is not allowed, must be one of %v", storageClassName, p.AllowedStorageClassNames)
+		}
+	}
+	if len(p.AllowedAccessModes) != 0 {
+		for _, accessMode := range claim.Spec.AccessModes {
+			if !containsAccessMode(p.AllowedAccessModes, accessMode) {
+				return fmt.Errorf("access mode %q is not allowed, must be one of %v", accessMode, p.AllowedAccessModes)
+			}
+		}
+	}
+	if p.MaxStorage != nil {
+		if storage, ok := claim.Spec.Resources.Requests[corev1.ResourceStorage]; ok && storage.Cmp(*p.MaxStorage) > 0 {
+			return fmt.Errorf("requested storage %s exceeds the maximum of %s", storage.String(), p.MaxStorage.String())
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func containsAccessMode(accessModes []corev1.PersistentVolumeAccessMode, accessMode corev1.PersistentVolumeAccessMode) bool {
+	for _, a := range accessModes {
+		if a == accessMode {
+			return true
+		}
+	}
+	return false
+}
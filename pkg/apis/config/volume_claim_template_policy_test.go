@@ -0,0 +1,174 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestVolumeClaimTemplatePoliciesForNamespace(t *testing.T) {
+	policies := &config.VolumeClaimTemplatePolicies{
+		VolumeClaimTemplatePolicy: config.VolumeClaimTemplatePolicy{
+			StorageClassName:         "standard",
+			Storage:                  quantity("1Gi"),
+			MaxStorage:               quantity("10Gi"),
+			AllowedStorageClassNames: []string{"standard"},
+		},
+		Namespaces: map[string]config.VolumeClaimTemplatePolicy{
+			"builds": {
+				MaxStorage:               quantity("100Gi"),
+				AllowedStorageClassNames: []string{"standard", "fast"},
+			},
+		},
+	}
+	for _, tc := range []struct {
+		namespace string
+		want      config.VolumeClaimTemplatePolicy
+	}{{
+		namespace: "default",
+		want:      policies.VolumeClaimTemplatePolicy,
+	}, {
+		namespace: "builds",
+		want: config.VolumeClaimTemplatePolicy{
+			StorageClassName:         "standard",
+			Storage:                  quantity("1Gi"),
+			MaxStorage:               quantity("100Gi"),
+			AllowedStorageClassNames: []string{"standard", "fast"},
+		},
+	}} {
+		t.Run(tc.namespace, func(t *testing.T) {
+			if d := cmp.Diff(tc.want, policies.ForNamespace(tc.namespace)); d != "" {
+				t.Errorf("ForNamespace() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestVolumeClaimTemplatePolicySetDefaults(t *testing.T) {
+	fast := "fast"
+	policy := config.VolumeClaimTemplatePolicy{
+		StorageClassName: "standard",
+		Storage:          quantity("1Gi"),
+		AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+	}
+	for _, tc := range []struct {
+		name  string
+		claim *corev1.PersistentVolumeClaim
+		want  *corev1.PersistentVolumeClaim
+	}{{
+		name:  "unspecified claim",
+		claim: &corev1.PersistentVolumeClaim{},
+		want: &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &policy.StorageClassName,
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: *quantity("1Gi")},
+				},
+			},
+		},
+	}, {
+		name: "specified claim",
+		claim: &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &fast,
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: *quantity("5Gi")},
+				},
+			},
+		},
+		want: &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &fast,
+				AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: *quantity("5Gi")},
+				},
+			},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			policy.SetDefaults(tc.claim)
+			if d := cmp.Diff(tc.want, tc.claim); d != "" {
+				t.Errorf("SetDefaults() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestVolumeClaimTemplatePolicyValidate(t *testing.T) {
+	standard := "standard"
+	fast := "fast"
+	policy := config.VolumeClaimTemplatePolicy{
+		MaxStorage:               quantity("10Gi"),
+		AllowedStorageClassNames: []string{"standard"},
+		AllowedAccessModes:       []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+	}
+	claim := func(storageClassName *string, accessMode corev1.PersistentVolumeAccessMode, storage string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: storageClassName,
+				AccessModes:      []corev1.PersistentVolumeAccessMode{accessMode},
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: *quantity(storage)},
+				},
+			},
+		}
+	}
+	for _, tc := range []struct {
+		name    string
+		claim   *corev1.PersistentVolumeClaim
+		wantErr string
+	}{{
+		name:  "allowed claim",
+		claim: claim(&standard, corev1.ReadWriteOnce, "10Gi"),
+	}, {
+		name:    "storage class not allowed",
+		claim:   claim(&fast, corev1.ReadWriteOnce, "1Gi"),
+		wantErr: `storage class "fast" is not allowed, must be one of [standard]`,
+	}, {
+		name:    "cluster default storage class not allowed",
+		claim:   claim(nil, corev1.ReadWriteOnce, "1Gi"),
+		wantErr: `storage class "" is not allowed, must be one of [standard]`,
+	}, {
+		name:    "access mode not allowed",
+		claim:   claim(&standard, corev1.ReadWriteMany, "1Gi"),
+		wantErr: `access mode "ReadWriteMany" is not allowed, must be one of [ReadWriteOnce]`,
+	}, {
+		name:    "storage above maximum",
+		claim:   claim(&standard, corev1.ReadWriteOnce, "20Gi"),
+		wantErr: `requested storage 20Gi exceeds the maximum of 10Gi`,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := policy.Validate(tc.claim)
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Errorf("Validate() returned unexpected error: %v", err)
+			case tc.wantErr != "" && err == nil:
+				t.Errorf("Validate() didn't return error %q", tc.wantErr)
+			case tc.wantErr != "" && err.Error() != tc.wantErr:
+				t.Errorf("Validate() returned error %q, want %q", err, tc.wantErr)
+			}
+		})
+	}
+}
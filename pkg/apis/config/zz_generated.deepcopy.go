@@ -23,8 +23,25 @@ package config
 
 import (
 	pod "github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "k8s.io/api/core/v1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Archive) DeepCopyInto(out *Archive) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Archive.
+func (in *Archive) DeepCopy() *Archive {
+	if in == nil {
+		return nil
+	}
+	out := new(Archive)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ArtifactBucket) DeepCopyInto(out *ArtifactBucket) {
 	*out = *in
@@ -70,6 +87,11 @@ func (in *Defaults) DeepCopyInto(out *Defaults) {
 		*out = new(pod.AffinityAssistantTemplate)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.DefaultVolumeClaimTemplatePolicies != nil {
+		in, out := &in.DefaultVolumeClaimTemplatePolicies, &out.DefaultVolumeClaimTemplatePolicies
+		*out = new(VolumeClaimTemplatePolicies)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -114,3 +136,100 @@ func (in *Metrics) DeepCopy() *Metrics {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *StepLogs) DeepCopyInto(out *StepLogs) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StepLogs.
+func (in *StepLogs) DeepCopy() *StepLogs {
+	if in == nil {
+		return nil
+	}
+	out := new(StepLogs)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Tracing) DeepCopyInto(out *Tracing) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Tracing.
+func (in *Tracing) DeepCopy() *Tracing {
+	if in == nil {
+		return nil
+	}
+	out := new(Tracing)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeClaimTemplatePolicies) DeepCopyInto(out *VolumeClaimTemplatePolicies) {
+	*out = *in
+	in.VolumeClaimTemplatePolicy.DeepCopyInto(&out.VolumeClaimTemplatePolicy)
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make(map[string]VolumeClaimTemplatePolicy, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimTemplatePolicies.
+func (in *VolumeClaimTemplatePolicies) DeepCopy() *VolumeClaimTemplatePolicies {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeClaimTemplatePolicies)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VolumeClaimTemplatePolicy) DeepCopyInto(out *VolumeClaimTemplatePolicy) {
+	*out = *in
+	if in.Storage != nil {
+		in, out := &in.Storage, &out.Storage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxStorage != nil {
+		in, out := &in.MaxStorage, &out.MaxStorage
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.AllowedStorageClassNames != nil {
+		in, out := &in.AllowedStorageClassNames, &out.AllowedStorageClassNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedAccessModes != nil {
+		in, out := &in.AllowedAccessModes, &out.AllowedAccessModes
+		*out = make([]v1.PersistentVolumeAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VolumeClaimTemplatePolicy.
+func (in *VolumeClaimTemplatePolicy) DeepCopy() *VolumeClaimTemplatePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(VolumeClaimTemplatePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
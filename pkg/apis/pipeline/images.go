@@ -44,6 +44,8 @@ type Images struct {
 	ImageDigestExporterImage string
 	// WorkingDirInitImage is the container image containing our working dir init binary.
 	WorkingDirInitImage string
+	// S3CopyImage is the container image containing our binary copying files to and from S3-compatible storages.
+	S3CopyImage string
 
 	// NOTE: Make sure to add any new images to Validate below!
 }
@@ -64,6 +66,7 @@ func (i Images) Validate() error {
 		{i.PRImage, "pr-image"},
 		{i.ImageDigestExporterImage, "imagedigest-exporter-image"},
 		{i.WorkingDirInitImage, "workingdirinit-image"},
+		{i.S3CopyImage, "s3-copy-image"},
 	} {
 		if f.v == "" {
 			unset = append(unset, f.name)
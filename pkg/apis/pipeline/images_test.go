@@ -18,6 +18,7 @@ func TestValidate(t *testing.T) {
 		PRImage:                  "set",
 		ImageDigestExporterImage: "set",
 		WorkingDirInitImage:      "set",
+		S3CopyImage:              "set",
 	}
 	if err := valid.Validate(); err != nil {
 		t.Errorf("valid Images returned error: %v", err)
@@ -33,6 +34,7 @@ func TestValidate(t *testing.T) {
 		GsutilImage:              "set",
 		PRImage:                  "", // unset!
 		ImageDigestExporterImage: "set",
+		S3CopyImage:              "set",
 	}
 	wantErr := "found unset image flags: [git-image pr-image shell-image workingdirinit-image]"
 	if err := invalid.Validate(); err == nil {
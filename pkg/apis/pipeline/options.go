@@ -16,8 +16,26 @@ limitations under the License.
 
 package pipeline
 
+import "time"
+
 // Options holds options passed to the Tekton Pipeline controllers
 // typically via command-line flags.
 type Options struct {
-	Images Images
+	Images          Images
+	EntrypointCache EntrypointCacheOptions
+}
+
+// EntrypointCacheOptions holds the options of the cache of image metadata
+// looked up by the controller to resolve the entrypoint of steps.
+type EntrypointCacheOptions struct {
+	// ConfigMapName is the name of the ConfigMap, in the controller's namespace,
+	// used to share the cache across controller replicas and restarts.
+	// The cache is only kept in memory if empty.
+	ConfigMapName string
+	// TTL is how long an entry of the shared cache is used before the image
+	// metadata is looked up again.
+	TTL time.Duration
+	// MaxEntries is the maximum number of entries in the shared cache. The
+	// oldest entries are evicted first.
+	MaxEntries int
 }
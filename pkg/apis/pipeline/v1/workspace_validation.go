@@ -19,6 +19,8 @@ package v1
 import (
 	"context"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"knative.dev/pkg/apis"
 )
@@ -36,7 +38,7 @@ var allVolumeSourceFields = []string{
 // Validate looks at the Volume provided in wb and makes sure that it is valid.
 // This means that only one VolumeSource can be specified, and also that the
 // supported VolumeSource is itself valid.
-func (b *WorkspaceBinding) Validate(context.Context) *apis.FieldError {
+func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError) {
 	if equality.Semantic.DeepEqual(b, &WorkspaceBinding{}) || b == nil {
 		return apis.ErrMissingField(apis.CurrentField)
 	}
@@ -66,6 +68,47 @@ func (b *WorkspaceBinding) Validate(context.Context) *apis.FieldError {
 		return apis.ErrMissingField("secret.secretName")
 	}
 
+	// Mounting a workspace read-only is only supported when the alpha feature gate is enabled.
+	if b.ReadOnly {
+		if err := version.ValidateEnabledAPIFields(ctx, "readOnly workspace binding", config.AlphaAPIFields).ViaField("workspace"); err != nil {
+			return err
+		}
+	}
+
+	// The projected workspace is only supported when the alpha feature gate is enabled.
+	// For a Projected volume to work, you must provide at least one source.
+	if b.Projected != nil {
+		if err := version.ValidateEnabledAPIFields(ctx, "projected workspace type", config.AlphaAPIFields).ViaField("workspace"); err != nil {
+			return err
+		}
+		if len(b.Projected.Sources) == 0 {
+			return apis.ErrMissingField("projected.sources")
+		}
+	}
+
+	// The csi workspace is only supported when the alpha feature gate is enabled.
+	// For a CSI to work, you must provide and have installed the driver to use.
+	if b.CSI != nil {
+		errs := version.ValidateEnabledAPIFields(ctx, "csi workspace type", config.AlphaAPIFields).ViaField("workspaces")
+		if errs != nil {
+			return errs
+		}
+		if b.CSI.Driver == "" {
+			return apis.ErrMissingField("csi.driver")
+		}
+	}
+
+	// The cache workspace is only supported when the alpha feature gate is enabled.
+	// For a cache to work, you must provide the key of the cache.
+	if b.Cache != nil {
+		if err := version.ValidateEnabledAPIFields(ctx, "cache workspace type", config.AlphaAPIFields).ViaField("workspace"); err != nil {
+			return err
+		}
+		if b.Cache.Key == "" {
+			return apis.ErrMissingField("cache.key")
+		}
+	}
+
 	return nil
 }
 
@@ -88,5 +131,14 @@ func (b *WorkspaceBinding) numSources() int {
 	if b.Secret != nil {
 		n++
 	}
+	if b.Projected != nil {
+		n++
+	}
+	if b.CSI != nil {
+		n++
+	}
+	if b.Cache != nil {
+		n++
+	}
 	return n
 }
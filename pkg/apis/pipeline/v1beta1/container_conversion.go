@@ -2,10 +2,37 @@ package v1beta1
 
 import (
 	"context"
+	"reflect"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	corev1 "k8s.io/api/core/v1"
 )
 
+// stepDeprecated holds the fields of a Step or StepTemplate which have
+// been removed in v1, so that they can be restored when converting back.
+// +k8s:openapi-gen=false
+type stepDeprecated struct {
+	Name                     string                          `json:"name,omitempty"`
+	Ports                    []corev1.ContainerPort          `json:"ports,omitempty"`
+	LivenessProbe            *corev1.Probe                   `json:"livenessProbe,omitempty"`
+	ReadinessProbe           *corev1.Probe                   `json:"readinessProbe,omitempty"`
+	StartupProbe             *corev1.Probe                   `json:"startupProbe,omitempty"`
+	Lifecycle                *corev1.Lifecycle               `json:"lifecycle,omitempty"`
+	TerminationMessagePath   string                          `json:"terminationMessagePath,omitempty"`
+	TerminationMessagePolicy corev1.TerminationMessagePolicy `json:"terminationMessagePolicy,omitempty"`
+	Stdin                    bool                            `json:"stdin,omitempty"`
+	StdinOnce                bool                            `json:"stdinOnce,omitempty"`
+	TTY                      bool                            `json:"tty,omitempty"`
+}
+
+// orNil returns nil if none of the deprecated fields are set.
+func (d *stepDeprecated) orNil() *stepDeprecated {
+	if reflect.DeepEqual(d, &stepDeprecated{}) {
+		return nil
+	}
+	return d
+}
+
 func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 	sink.Name = s.Name
 	sink.Image = s.Image
@@ -32,9 +59,39 @@ func (s Step) convertTo(ctx context.Context, sink *v1.Step) {
 	sink.StdoutConfig = (*v1.StepOutputConfig)(s.StdoutConfig)
 	sink.StderrConfig = (*v1.StepOutputConfig)(s.StderrConfig)
 
-	// TODO(#4546): Handle deprecated fields
-	// Ports, LivenessProbe, ReadinessProbe, StartupProbe, Lifecycle, TerminationMessagePath
-	// TerminationMessagePolicy, Stdin, StdinOnce, TTY
+}
+
+// deprecated returns the fields of the Step which cannot be represented in v1,
+// or nil if there are none.
+func (s Step) deprecated() *stepDeprecated {
+	return (&stepDeprecated{
+		Ports:                    s.DeprecatedPorts,
+		LivenessProbe:            s.DeprecatedLivenessProbe,
+		ReadinessProbe:           s.DeprecatedReadinessProbe,
+		StartupProbe:             s.DeprecatedStartupProbe,
+		Lifecycle:                s.DeprecatedLifecycle,
+		TerminationMessagePath:   s.DeprecatedTerminationMessagePath,
+		TerminationMessagePolicy: s.DeprecatedTerminationMessagePolicy,
+		Stdin:                    s.DeprecatedStdin,
+		StdinOnce:                s.DeprecatedStdinOnce,
+		TTY:                      s.DeprecatedTTY,
+	}).orNil()
+}
+
+func (s *Step) restoreDeprecated(d *stepDeprecated) {
+	if d == nil {
+		return
+	}
+	s.DeprecatedPorts = d.Ports
+	s.DeprecatedLivenessProbe = d.LivenessProbe
+	s.DeprecatedReadinessProbe = d.ReadinessProbe
+	s.DeprecatedStartupProbe = d.StartupProbe
+	s.DeprecatedLifecycle = d.Lifecycle
+	s.DeprecatedTerminationMessagePath = d.TerminationMessagePath
+	s.DeprecatedTerminationMessagePolicy = d.TerminationMessagePolicy
+	s.DeprecatedStdin = d.Stdin
+	s.DeprecatedStdinOnce = d.StdinOnce
+	s.DeprecatedTTY = d.TTY
 }
 
 func (s *Step) convertFrom(ctx context.Context, source v1.Step) {
@@ -76,9 +133,41 @@ func (s StepTemplate) convertTo(ctx context.Context, sink *v1.StepTemplate) {
 	sink.VolumeDevices = s.VolumeDevices
 	sink.ImagePullPolicy = s.ImagePullPolicy
 	sink.SecurityContext = s.SecurityContext
-	// TODO(#4546): Handle deprecated fields
-	// Name, Ports, LivenessProbe, ReadinessProbe, StartupProbe, Lifecycle, TerminationMessagePath
-	// TerminationMessagePolicy, Stdin, StdinOnce, TTY
+}
+
+// deprecated returns the fields of the StepTemplate which cannot be represented
+// in v1, or nil if there are none.
+func (s StepTemplate) deprecated() *stepDeprecated {
+	return (&stepDeprecated{
+		Name:                     s.DeprecatedName,
+		Ports:                    s.DeprecatedPorts,
+		LivenessProbe:            s.DeprecatedLivenessProbe,
+		ReadinessProbe:           s.DeprecatedReadinessProbe,
+		StartupProbe:             s.DeprecatedStartupProbe,
+		Lifecycle:                s.DeprecatedLifecycle,
+		TerminationMessagePath:   s.DeprecatedTerminationMessagePath,
+		TerminationMessagePolicy: s.DeprecatedTerminationMessagePolicy,
+		Stdin:                    s.DeprecatedStdin,
+		StdinOnce:                s.DeprecatedStdinOnce,
+		TTY:                      s.DeprecatedTTY,
+	}).orNil()
+}
+
+func (s *StepTemplate) restoreDeprecated(d *stepDeprecated) {
+	if d == nil {
+		return
+	}
+	s.DeprecatedName = d.Name
+	s.DeprecatedPorts = d.Ports
+	s.DeprecatedLivenessProbe = d.LivenessProbe
+	s.DeprecatedReadinessProbe = d.ReadinessProbe
+	s.DeprecatedStartupProbe = d.StartupProbe
+	s.DeprecatedLifecycle = d.Lifecycle
+	s.DeprecatedTerminationMessagePath = d.TerminationMessagePath
+	s.DeprecatedTerminationMessagePolicy = d.TerminationMessagePolicy
+	s.DeprecatedStdin = d.Stdin
+	s.DeprecatedStdinOnce = d.StdinOnce
+	s.DeprecatedTTY = d.TTY
 }
 
 func (s *StepTemplate) convertFrom(ctx context.Context, source *v1.StepTemplate) {
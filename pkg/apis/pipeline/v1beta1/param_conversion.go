@@ -45,3 +45,31 @@ func (p *ParamSpec) convertFrom(ctx context.Context, source v1.ParamSpec) {
 		}
 	}
 }
+
+func (p Param) convertTo(ctx context.Context, sink *v1.Param) {
+	sink.Name = p.Name
+	newValue := v1.ArrayOrString{}
+	p.Value.convertTo(ctx, &newValue)
+	sink.Value = newValue
+}
+
+func (p *Param) convertFrom(ctx context.Context, source v1.Param) {
+	p.Name = source.Name
+	newValue := ArrayOrString{}
+	newValue.convertFrom(ctx, source.Value)
+	p.Value = newValue
+}
+
+func (v ArrayOrString) convertTo(ctx context.Context, sink *v1.ArrayOrString) {
+	sink.Type = v1.ParamType(v.Type)
+	sink.StringVal = v.StringVal
+	sink.ArrayVal = v.ArrayVal
+	sink.ObjectVal = v.ObjectVal
+}
+
+func (v *ArrayOrString) convertFrom(ctx context.Context, source v1.ArrayOrString) {
+	v.Type = ParamType(source.Type)
+	v.StringVal = source.StringVal
+	v.ArrayVal = source.ArrayVal
+	v.ObjectVal = source.ObjectVal
+}
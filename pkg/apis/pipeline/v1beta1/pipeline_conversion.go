@@ -20,23 +20,250 @@ import (
 	"context"
 	"fmt"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"knative.dev/pkg/apis"
 )
 
+// pipelineSpecDeprecated holds the fields of a PipelineSpec which have been
+// removed in v1. Tasks is keyed by the name of the PipelineTask, covering both
+// tasks and finally tasks.
+type pipelineSpecDeprecated struct {
+	Resources []PipelineDeclaredResource         `json:"resources,omitempty"`
+	Tasks     map[string]*pipelineTaskDeprecated `json:"tasks,omitempty"`
+}
+
+// pipelineTaskDeprecated holds the fields of a PipelineTask which have been
+// removed in v1.
+type pipelineTaskDeprecated struct {
+	Resources *PipelineTaskResources `json:"resources,omitempty"`
+	TaskSpec  *taskSpecDeprecated    `json:"taskSpec,omitempty"`
+}
+
 var _ apis.Convertible = (*Pipeline)(nil)
 
-// ConvertTo implements api.Convertible
-func (p *Pipeline) ConvertTo(ctx context.Context, sink apis.Convertible) error {
+// ConvertTo implements apis.Convertible
+func (p *Pipeline) ConvertTo(ctx context.Context, to apis.Convertible) error {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
-	return fmt.Errorf("v1beta1 is the highest known version, got: %T", sink)
+	switch sink := to.(type) {
+	case *v1.Pipeline:
+		sink.ObjectMeta = p.ObjectMeta
+		if d := p.Spec.convertTo(ctx, &sink.Spec); d != nil {
+			return version.SerializeToMetadata(&sink.ObjectMeta, d, deprecatedFieldsAnnotationKey)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// convertTo converts the PipelineSpec to v1 and returns the fields which could
+// not be represented in v1, or nil if there are none.
+func (ps *PipelineSpec) convertTo(ctx context.Context, sink *v1.PipelineSpec) *pipelineSpecDeprecated {
+	deprecated := &pipelineSpecDeprecated{Resources: ps.Resources}
+	sink.Description = ps.Description
+	sink.Tasks = nil
+	for _, t := range ps.Tasks {
+		new := v1.PipelineTask{}
+		deprecated.addTask(t.Name, t.convertTo(ctx, &new))
+		sink.Tasks = append(sink.Tasks, new)
+	}
+	sink.Params = nil
+	for _, p := range ps.Params {
+		new := v1.ParamSpec{}
+		p.convertTo(ctx, &new)
+		sink.Params = append(sink.Params, new)
+	}
+	sink.Workspaces = nil
+	for _, w := range ps.Workspaces {
+		new := v1.PipelineWorkspaceDeclaration{}
+		w.convertTo(ctx, &new)
+		sink.Workspaces = append(sink.Workspaces, new)
+	}
+	sink.Results = nil
+	for _, r := range ps.Results {
+		new := v1.PipelineResult{}
+		r.convertTo(ctx, &new)
+		sink.Results = append(sink.Results, new)
+	}
+	sink.Finally = nil
+	for _, f := range ps.Finally {
+		new := v1.PipelineTask{}
+		deprecated.addTask(f.Name, f.convertTo(ctx, &new))
+		sink.Finally = append(sink.Finally, new)
+	}
+	if deprecated.Resources == nil && deprecated.Tasks == nil {
+		return nil
+	}
+	return deprecated
+}
+
+func (d *pipelineSpecDeprecated) addTask(name string, task *pipelineTaskDeprecated) {
+	if task == nil {
+		return
+	}
+	if d.Tasks == nil {
+		d.Tasks = map[string]*pipelineTaskDeprecated{}
+	}
+	d.Tasks[name] = task
+}
+
+// convertTo converts the PipelineTask to v1 and returns the fields which could
+// not be represented in v1, or nil if there are none.
+func (pt PipelineTask) convertTo(ctx context.Context, sink *v1.PipelineTask) *pipelineTaskDeprecated {
+	deprecated := &pipelineTaskDeprecated{Resources: pt.Resources}
+	sink.Name = pt.Name
+	sink.TaskRef = nil
+	if pt.TaskRef != nil {
+		sink.TaskRef = &v1.TaskRef{}
+		pt.TaskRef.convertTo(ctx, sink.TaskRef)
+	}
+	sink.TaskSpec = nil
+	if pt.TaskSpec != nil {
+		sink.TaskSpec = &v1.EmbeddedTask{}
+		deprecated.TaskSpec = pt.TaskSpec.convertTo(ctx, sink.TaskSpec)
+	}
+	pt.WhenExpressions.convertTo(ctx, &sink.When)
+	sink.Retries = pt.Retries
+	sink.RunAfter = pt.RunAfter
+	sink.Params = nil
+	for _, p := range pt.Params {
+		new := v1.Param{}
+		p.convertTo(ctx, &new)
+		sink.Params = append(sink.Params, new)
+	}
+	sink.Matrix = nil
+	for _, p := range pt.Matrix {
+		new := v1.Param{}
+		p.convertTo(ctx, &new)
+		sink.Matrix = append(sink.Matrix, new)
+	}
+	sink.Workspaces = nil
+	for _, w := range pt.Workspaces {
+		new := v1.WorkspacePipelineTaskBinding{}
+		w.convertTo(ctx, &new)
+		sink.Workspaces = append(sink.Workspaces, new)
+	}
+	sink.Timeout = pt.Timeout
+	if deprecated.Resources == nil && deprecated.TaskSpec == nil {
+		return nil
+	}
+	return deprecated
 }
 
-// ConvertFrom implements api.Convertible
-func (p *Pipeline) ConvertFrom(ctx context.Context, source apis.Convertible) error {
+// convertTo converts the EmbeddedTask to v1 and returns the fields of its
+// TaskSpec which could not be represented in v1, or nil if there are none.
+func (et *EmbeddedTask) convertTo(ctx context.Context, sink *v1.EmbeddedTask) *taskSpecDeprecated {
+	sink.TypeMeta = et.TypeMeta
+	sink.Spec = et.Spec
+	sink.Metadata = v1.PipelineTaskMetadata(et.Metadata)
+	return et.TaskSpec.convertTo(ctx, &sink.TaskSpec)
+}
+
+// ConvertFrom implements apis.Convertible
+func (p *Pipeline) ConvertFrom(ctx context.Context, from apis.Convertible) error {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
-	return fmt.Errorf("v1beta1 is the highest know version, got: %T", source)
+	switch source := from.(type) {
+	case *v1.Pipeline:
+		p.ObjectMeta = source.ObjectMeta
+		deprecated := &pipelineSpecDeprecated{}
+		if err := version.DeserializeFromMetadata(&p.ObjectMeta, deprecated, deprecatedFieldsAnnotationKey); err != nil {
+			return err
+		}
+		p.Spec.convertFrom(ctx, &source.Spec, deprecated)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+// convertFrom converts the PipelineSpec from v1, restoring the deprecated fields
+// if any were preserved during the conversion to v1.
+func (ps *PipelineSpec) convertFrom(ctx context.Context, source *v1.PipelineSpec, deprecated *pipelineSpecDeprecated) {
+	if deprecated == nil {
+		deprecated = &pipelineSpecDeprecated{}
+	}
+	ps.Description = source.Description
+	ps.Resources = deprecated.Resources
+	ps.Tasks = nil
+	for _, t := range source.Tasks {
+		new := PipelineTask{}
+		new.convertFrom(ctx, t, deprecated.Tasks[t.Name])
+		ps.Tasks = append(ps.Tasks, new)
+	}
+	ps.Params = nil
+	for _, p := range source.Params {
+		new := ParamSpec{}
+		new.convertFrom(ctx, p)
+		ps.Params = append(ps.Params, new)
+	}
+	ps.Workspaces = nil
+	for _, w := range source.Workspaces {
+		new := PipelineWorkspaceDeclaration{}
+		new.convertFrom(ctx, w)
+		ps.Workspaces = append(ps.Workspaces, new)
+	}
+	ps.Results = nil
+	for _, r := range source.Results {
+		new := PipelineResult{}
+		new.convertFrom(ctx, r)
+		ps.Results = append(ps.Results, new)
+	}
+	ps.Finally = nil
+	for _, f := range source.Finally {
+		new := PipelineTask{}
+		new.convertFrom(ctx, f, deprecated.Tasks[f.Name])
+		ps.Finally = append(ps.Finally, new)
+	}
+}
+
+func (pt *PipelineTask) convertFrom(ctx context.Context, source v1.PipelineTask, deprecated *pipelineTaskDeprecated) {
+	if deprecated == nil {
+		deprecated = &pipelineTaskDeprecated{}
+	}
+	pt.Name = source.Name
+	pt.TaskRef = nil
+	if source.TaskRef != nil {
+		pt.TaskRef = &TaskRef{}
+		pt.TaskRef.convertFrom(ctx, *source.TaskRef)
+	}
+	pt.TaskSpec = nil
+	if source.TaskSpec != nil {
+		pt.TaskSpec = &EmbeddedTask{}
+		pt.TaskSpec.convertFrom(ctx, source.TaskSpec, deprecated.TaskSpec)
+	}
+	pt.WhenExpressions.convertFrom(ctx, source.When)
+	pt.Retries = source.Retries
+	pt.RunAfter = source.RunAfter
+	pt.Resources = deprecated.Resources
+	pt.Params = nil
+	for _, p := range source.Params {
+		new := Param{}
+		new.convertFrom(ctx, p)
+		pt.Params = append(pt.Params, new)
+	}
+	pt.Matrix = nil
+	for _, p := range source.Matrix {
+		new := Param{}
+		new.convertFrom(ctx, p)
+		pt.Matrix = append(pt.Matrix, new)
+	}
+	pt.Workspaces = nil
+	for _, w := range source.Workspaces {
+		new := WorkspacePipelineTaskBinding{}
+		new.convertFrom(ctx, w)
+		pt.Workspaces = append(pt.Workspaces, new)
+	}
+	pt.Timeout = source.Timeout
+}
+
+func (et *EmbeddedTask) convertFrom(ctx context.Context, source *v1.EmbeddedTask, deprecated *taskSpecDeprecated) {
+	et.TypeMeta = source.TypeMeta
+	et.Spec = source.Spec
+	et.Metadata = PipelineTaskMetadata(source.Metadata)
+	et.TaskSpec.convertFrom(ctx, &source.TaskSpec, deprecated)
 }
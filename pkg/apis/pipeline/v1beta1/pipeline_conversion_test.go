@@ -19,6 +19,15 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
 )
 
 func TestPipelineConversionBadType(t *testing.T) {
@@ -32,3 +41,184 @@ func TestPipelineConversionBadType(t *testing.T) {
 		t.Errorf("ConvertFrom() = %#v, wanted error", good)
 	}
 }
+
+func TestPipelineConversion(t *testing.T) {
+	versions := []apis.Convertible{&v1.Pipeline{}}
+
+	tests := []struct {
+		name string
+		in   *Pipeline
+	}{{
+		name: "simple pipeline",
+		in: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "foo",
+				Namespace:  "bar",
+				Generation: 1,
+			},
+			Spec: PipelineSpec{
+				Tasks: []PipelineTask{{
+					Name:    "task-1",
+					TaskRef: &TaskRef{Name: "task"},
+				}},
+			},
+		},
+	}, {
+		name: "pipeline conversion all non deprecated fields",
+		in: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "foo",
+				Namespace:  "bar",
+				Generation: 1,
+			},
+			Spec: PipelineSpec{
+				Description: "test",
+				Tasks: []PipelineTask{{
+					Name: "task-1",
+					TaskRef: &TaskRef{
+						Name:       "task",
+						Kind:       ClusterTaskKind,
+						APIVersion: "v1beta1",
+						Bundle:     "bundle",
+					},
+					WhenExpressions: WhenExpressions{{
+						Input:    "foo",
+						Operator: selection.In,
+						Values:   []string{"foo", "bar"},
+					}},
+					Retries:  2,
+					RunAfter: []string{"task-0"},
+					Params: []Param{{
+						Name:  "param-1",
+						Value: *NewArrayOrString("value"),
+					}},
+					Matrix: []Param{{
+						Name:  "param-2",
+						Value: *NewArrayOrString("a", "b"),
+					}},
+					Workspaces: []WorkspacePipelineTaskBinding{{
+						Name:      "workspace",
+						Workspace: "pipeline-workspace",
+						SubPath:   "dir",
+						From:      []string{"task-0"},
+						Access:    WorkspaceAccessReadWrite,
+					}},
+					Timeout: &metav1.Duration{Duration: time.Hour},
+				}, {
+					Name: "task-2",
+					TaskRef: &TaskRef{
+						ResolverRef: ResolverRef{
+							Resolver: "git",
+							Resource: []ResolverParam{{Name: "url", Value: "https://github.com/tektoncd/catalog"}},
+						},
+					},
+				}, {
+					Name: "task-3",
+					TaskSpec: &EmbeddedTask{
+						Metadata: PipelineTaskMetadata{
+							Labels:      map[string]string{"foo": "bar"},
+							Annotations: map[string]string{"bar": "foo"},
+						},
+						TaskSpec: TaskSpec{
+							Steps: []Step{{
+								Name:  "step",
+								Image: "foo",
+							}},
+						},
+					},
+				}},
+				Params: []ParamSpec{{
+					Name:        "param-1",
+					Type:        ParamTypeString,
+					Description: "My first param",
+				}},
+				Workspaces: []PipelineWorkspaceDeclaration{{
+					Name:        "pipeline-workspace",
+					Description: "description",
+					Optional:    true,
+				}},
+				Results: []PipelineResult{{
+					Name:        "result",
+					Type:        ResultsTypeString,
+					Description: "description",
+					Value:       *NewArrayOrString("$(tasks.task-1.results.foo)"),
+				}},
+				Finally: []PipelineTask{{
+					Name:    "final-task",
+					TaskRef: &TaskRef{Name: "task"},
+				}},
+			},
+		},
+	}, {
+		name: "pipeline resources",
+		in: &Pipeline{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   "bar",
+				Generation:  1,
+				Annotations: map[string]string{"foo": "bar"},
+			},
+			Spec: PipelineSpec{
+				Resources: []PipelineDeclaredResource{{
+					Name: "git-repo",
+					Type: PipelineResourceTypeGit,
+				}},
+				Tasks: []PipelineTask{{
+					Name:    "task-1",
+					TaskRef: &TaskRef{Name: "task"},
+					Resources: &PipelineTaskResources{
+						Inputs: []PipelineTaskInputResource{{
+							Name:     "source",
+							Resource: "git-repo",
+						}},
+					},
+				}},
+				Finally: []PipelineTask{{
+					Name: "final-task",
+					TaskSpec: &EmbeddedTask{
+						TaskSpec: TaskSpec{
+							Resources: &TaskResources{
+								Inputs: []TaskResource{{ResourceDeclaration: ResourceDeclaration{
+									Name: "source",
+									Type: PipelineResourceTypeGit,
+								}}},
+							},
+							Steps: []Step{{
+								Name:            "step",
+								Image:           "foo",
+								DeprecatedPorts: []corev1.ContainerPort{{ContainerPort: 8080}},
+							}},
+						},
+					},
+					Resources: &PipelineTaskResources{
+						Inputs: []PipelineTaskInputResource{{
+							Name:     "source",
+							Resource: "git-repo",
+						}},
+					},
+				}},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, version := range versions {
+			t.Run(test.name, func(t *testing.T) {
+				ver := version
+				if err := test.in.ConvertTo(context.Background(), ver); err != nil {
+					t.Errorf("ConvertTo() = %v", err)
+					return
+				}
+				t.Logf("ConvertTo() = %#v", ver)
+				got := &Pipeline{}
+				if err := got.ConvertFrom(context.Background(), ver); err != nil {
+					t.Errorf("ConvertFrom() = %v", err)
+				}
+				t.Logf("ConvertFrom() = %#v", got)
+				if d := cmp.Diff(test.in, got); d != "" {
+					t.Errorf("roundtrip %s", diff.PrintWantGot(d))
+				}
+			})
+		}
+	}
+}
@@ -510,11 +510,12 @@ func (pt PipelineTask) Validate(ctx context.Context) (errs *apis.FieldError) {
 	return
 }
 
-// Deps returns all other PipelineTask dependencies of this PipelineTask, based on resource usage or ordering
+// Deps returns all other PipelineTask dependencies of this PipelineTask, based on resource usage, workspace usage or ordering
 func (pt PipelineTask) Deps() []string {
 	deps := []string{}
 
 	deps = append(deps, pt.resourceDeps()...)
+	deps = append(deps, pt.workspaceDeps()...)
 	deps = append(deps, pt.orderingDeps()...)
 
 	uniqueDeps := sets.NewString()
@@ -544,6 +545,14 @@ func (pt PipelineTask) resourceDeps() []string {
 	return resourceDeps
 }
 
+func (pt PipelineTask) workspaceDeps() []string {
+	workspaceDeps := []string{}
+	for _, ws := range pt.Workspaces {
+		workspaceDeps = append(workspaceDeps, ws.From...)
+	}
+	return workspaceDeps
+}
+
 func (pt PipelineTask) orderingDeps() []string {
 	orderingDeps := []string{}
 	for _, runAfter := range pt.RunAfter {
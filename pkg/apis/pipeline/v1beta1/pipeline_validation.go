@@ -21,7 +21,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/validate"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"github.com/tektoncd/pipeline/pkg/list"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipeline/dag"
 	"github.com/tektoncd/pipeline/pkg/substitution"
@@ -57,6 +59,9 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validateFrom(ps.Tasks))
 	// Validate the pipeline task graph
 	errs = errs.Also(validateGraph(ps.Tasks))
+	// The workspace from values should make sense and not lead to parallel writes
+	errs = errs.Also(validateWorkspaceFrom(ctx, ps.Tasks))
+	errs = errs.Also(validateWorkspaceWriters(ps.Tasks))
 	errs = errs.Also(validateParamResults(ps.Tasks))
 	// The parameter variables should be valid
 	errs = errs.Also(validatePipelineParameterVariables(ctx, ps.Tasks, ps.Params).ViaField("tasks"))
@@ -68,6 +73,8 @@ func (ps *PipelineSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 	errs = errs.Also(validatePipelineWorkspacesDeclarations(ps.Workspaces))
 	errs = errs.Also(validatePipelineWorkspacesUsage(ps.Workspaces, ps.Tasks).ViaField("tasks"))
 	errs = errs.Also(validatePipelineWorkspacesUsage(ps.Workspaces, ps.Finally).ViaField("finally"))
+	errs = errs.Also(validatePipelineWorkspacesAccess(ctx, ps.Tasks).ViaField("tasks"))
+	errs = errs.Also(validatePipelineWorkspacesAccess(ctx, ps.Finally).ViaField("finally"))
 	// Validate the pipeline's results
 	errs = errs.Also(validatePipelineResults(ps.Results, ps.Tasks))
 	errs = errs.Also(validateTasksAndFinallySection(ps))
@@ -122,6 +129,56 @@ func validatePipelineWorkspacesUsage(wss []PipelineWorkspaceDeclaration, pts []P
 	return errs
 }
 
+// validatePipelineWorkspacesAccess validates the access of the workspaces bound by pipeline tasks: that it is
+// known, that it doesn't make writable a workspace which an embedded Task declares read-only, and that it
+// agrees with the `from` values of the bindings.
+func validatePipelineWorkspacesAccess(ctx context.Context, pts []PipelineTask) (errs *apis.FieldError) {
+	readOnlyWorkspaces := map[string]sets.String{}
+	for _, pt := range pts {
+		readOnlyWorkspaces[pt.Name] = sets.NewString()
+		for _, ws := range pt.Workspaces {
+			if ws.Access == WorkspaceAccessReadOnly {
+				readOnlyWorkspaces[pt.Name].Insert(ws.pipelineWorkspace())
+			}
+		}
+	}
+	for i, pt := range pts {
+		for j, ws := range pt.Workspaces {
+			if ws.Access != "" {
+				errs = errs.Also(validateWorkspaceAccess(ctx, pt, ws).ViaFieldIndex("workspaces", j).ViaIndex(i))
+			}
+			for _, from := range ws.From {
+				if readOnlyWorkspaces[from].Has(ws.pipelineWorkspace()) {
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected workspace %s to be from task %s, but task %s only reads it", ws.pipelineWorkspace(), from, from),
+						"from").ViaFieldIndex("workspaces", j).ViaIndex(i))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func validateWorkspaceAccess(ctx context.Context, pt PipelineTask, ws WorkspacePipelineTaskBinding) (errs *apis.FieldError) {
+	errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "workspace access", config.AlphaAPIFields).ViaField("access"))
+	known := false
+	for _, access := range AllWorkspaceAccesses {
+		if ws.Access == access {
+			known = true
+		}
+	}
+	if !known {
+		return errs.Also(apis.ErrInvalidValue(fmt.Sprintf("unknown workspace access %q, must be one of %v", ws.Access, AllWorkspaceAccesses), "access"))
+	}
+	if ws.Access != WorkspaceAccessReadOnly && pt.TaskSpec != nil {
+		for _, declared := range pt.TaskSpec.Workspaces {
+			if declared.Name == ws.Name && declared.ReadOnly {
+				errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("workspace %s is declared read-only by the task of pipeline task %s, it can't have %s access", ws.Name, pt.Name, ws.Access), "access"))
+			}
+		}
+	}
+	return errs
+}
+
 // validatePipelineParameterVariables validates parameters with those specified by each pipeline task,
 // (1) it validates the type of parameter is either string or array (2) parameter default value matches
 // with the type of that param (3) ensures that the referenced param variable is defined is part of the param declarations
@@ -324,6 +381,12 @@ func validateFinalTasks(tasks []PipelineTask, finalTasks []PipelineTask) (errs *
 		if len(f.RunAfter) != 0 {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("no runAfter allowed under spec.finally, final task %s has runAfter specified", f.Name), "").ViaFieldIndex("finally", idx))
 		}
+		for i, ws := range f.Workspaces {
+			if len(ws.From) != 0 {
+				errs = errs.Also(apis.ErrGeneric(fmt.Sprintf("no from allowed under workspaces,"+
+					" final task %s has from specified", f.Name), "from").ViaFieldIndex("workspaces", i).ViaFieldIndex("finally", idx))
+			}
+		}
 	}
 
 	ts := PipelineTaskList(tasks).Names()
@@ -494,6 +557,98 @@ func validateGraph(tasks []PipelineTask) *apis.FieldError {
 	return nil
 }
 
+// validateWorkspaceFrom ensures that the workspace `from` values make sense: that they rely on
+// Tasks of the Pipeline which are bound to the same Pipeline workspace.
+func validateWorkspaceFrom(ctx context.Context, tasks []PipelineTask) (errs *apis.FieldError) {
+	taskWorkspaces := map[string]sets.String{}
+	for _, pt := range tasks {
+		workspaces := sets.NewString()
+		for _, ws := range pt.Workspaces {
+			workspaces.Insert(ws.pipelineWorkspace())
+		}
+		taskWorkspaces[pt.Name] = workspaces
+	}
+	for i, pt := range tasks {
+		for j, ws := range pt.Workspaces {
+			if len(ws.From) != 0 {
+				errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "workspace from", config.AlphaAPIFields).ViaField("from").ViaFieldIndex("workspaces", j).ViaFieldIndex("tasks", i))
+			}
+			for _, from := range ws.From {
+				workspaces, found := taskWorkspaces[from]
+				if !found {
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected workspace %s to be from task %s, but task %s doesn't exist", ws.pipelineWorkspace(), from, from),
+						"from").ViaFieldIndex("workspaces", j).ViaFieldIndex("tasks", i))
+				} else if !workspaces.Has(ws.pipelineWorkspace()) {
+					errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("expected workspace %s to be from task %s, but task %s doesn't use it", ws.pipelineWorkspace(), from, from),
+						"from").ViaFieldIndex("workspaces", j).ViaFieldIndex("tasks", i))
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// validateWorkspaceWriters ensures that a Task writing to a workspace, i.e. a Task named in the
+// `from` of another Task's workspace binding or binding it with readWrite access,
+// doesn't run in parallel with any other Task using the same location of that workspace.
+func validateWorkspaceWriters(tasks []PipelineTask) (errs *apis.FieldError) {
+	g, err := dag.Build(PipelineTaskList(tasks), PipelineTaskList(tasks).Deps())
+	if err != nil {
+		// The graph errors are reported by validateGraph
+		return nil
+	}
+	writers := map[string]sets.String{}
+	for _, pt := range tasks {
+		for _, ws := range pt.Workspaces {
+			names := append([]string{}, ws.From...)
+			if ws.Access == WorkspaceAccessReadWrite {
+				names = append(names, pt.Name)
+			}
+			if len(names) == 0 {
+				continue
+			}
+			if _, ok := writers[ws.pipelineWorkspace()]; !ok {
+				writers[ws.pipelineWorkspace()] = sets.NewString()
+			}
+			writers[ws.pipelineWorkspace()].Insert(names...)
+		}
+	}
+	if len(writers) == 0 {
+		return nil
+	}
+	for i, pt := range tasks {
+		for j, ws := range pt.Workspaces {
+			for k := 0; k < i; k++ {
+				other := tasks[k]
+				writer := pt.Name
+				if !writers[ws.pipelineWorkspace()].Has(writer) {
+					writer = other.Name
+				}
+				if !writers[ws.pipelineWorkspace()].Has(writer) {
+					continue
+				}
+				if dag.DependsOn(g, pt.Name, other.Name) || dag.DependsOn(g, other.Name, pt.Name) {
+					continue
+				}
+				for _, otherWs := range other.Workspaces {
+					if otherWs.pipelineWorkspace() == ws.pipelineWorkspace() && overlappingSubPaths(otherWs.SubPath, ws.SubPath) {
+						errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("task %s writes to workspace %s but tasks %s and %s can run in parallel, use from or runAfter to order them", writer, ws.pipelineWorkspace(), other.Name, pt.Name),
+							"").ViaFieldIndex("workspaces", j).ViaFieldIndex("tasks", i))
+						break
+					}
+				}
+			}
+		}
+	}
+	return errs
+}
+
+// overlappingSubPaths returns true if the two subPaths of a workspace can share files, that is
+// if one of them is the whole volume or if they are the same directory.
+func overlappingSubPaths(a, b string) bool {
+	return a == "" || b == "" || a == b
+}
+
 func validateMatrix(ctx context.Context, tasks []PipelineTask) (errs *apis.FieldError) {
 	for idx, task := range tasks {
 		errs = errs.Also(task.validateMatrix(ctx).ViaIndex(idx))
@@ -1092,6 +1092,298 @@ func TestValidateGraph_Failure(t *testing.T) {
 	}
 }
 
+func TestValidateWorkspaceFrom_Success(t *testing.T) {
+	desc := "valid pipeline task - workspace from referring to pipeline task using the same workspace"
+	tasks := []PipelineTask{{
+		Name:       "fetch",
+		TaskRef:    &TaskRef{Name: "fetch-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "output", Workspace: "source"}},
+	}, {
+		Name:       "build",
+		TaskRef:    &TaskRef{Name: "build-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"fetch"}}},
+	}}
+	if err := validateWorkspaceFrom(config.EnableAlphaAPIFields(context.Background()), tasks); err != nil {
+		t.Errorf("Pipeline.validateWorkspaceFrom() returned error for: %s: %v", desc, err)
+	}
+}
+
+func TestValidateWorkspaceFrom_Failure(t *testing.T) {
+	tests := []struct {
+		name          string
+		tasks         []PipelineTask
+		wc            func(context.Context) context.Context
+		expectedError apis.FieldError
+	}{{
+		name: "workspace from requires alpha",
+		tasks: []PipelineTask{{
+			Name:       "fetch",
+			TaskRef:    &TaskRef{Name: "fetch-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+		}, {
+			Name:       "build",
+			TaskRef:    &TaskRef{Name: "build-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"fetch"}}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `workspace from requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
+	}, {
+		name: "invalid pipeline task - workspace from referencing pipeline task which does not exist",
+		tasks: []PipelineTask{{
+			Name:       "build",
+			TaskRef:    &TaskRef{Name: "build-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"fetch"}}},
+		}},
+		wc: config.EnableAlphaAPIFields,
+		expectedError: apis.FieldError{
+			Message: `invalid value: expected workspace source to be from task fetch, but task fetch doesn't exist`,
+			Paths:   []string{"tasks[0].workspaces[0].from"},
+		},
+	}, {
+		name: "invalid pipeline task - workspace from referencing pipeline task which does not use the workspace",
+		tasks: []PipelineTask{{
+			Name:       "fetch",
+			TaskRef:    &TaskRef{Name: "fetch-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "output", Workspace: "other"}},
+		}, {
+			Name:       "build",
+			TaskRef:    &TaskRef{Name: "build-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "input", Workspace: "source", From: []string{"fetch"}}},
+		}},
+		wc: config.EnableAlphaAPIFields,
+		expectedError: apis.FieldError{
+			Message: `invalid value: expected workspace source to be from task fetch, but task fetch doesn't use it`,
+			Paths:   []string{"tasks[1].workspaces[0].from"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			err := validateWorkspaceFrom(ctx, tt.tasks)
+			if err == nil {
+				t.Fatal("Pipeline.validateWorkspaceFrom() did not return error for invalid pipeline task workspaces")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateWorkspaceWriters_Success(t *testing.T) {
+	tests := []struct {
+		name  string
+		tasks []PipelineTask
+	}{{
+		name: "parallel pipeline tasks reading a workspace written by a previous pipeline task",
+		tasks: []PipelineTask{{
+			Name:       "fetch",
+			TaskRef:    &TaskRef{Name: "fetch-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+		}, {
+			Name:       "build",
+			TaskRef:    &TaskRef{Name: "build-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"fetch"}}},
+		}, {
+			Name:       "test",
+			TaskRef:    &TaskRef{Name: "test-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"fetch"}}},
+		}},
+	}, {
+		name: "pipeline task ordered after a writer with runAfter",
+		tasks: []PipelineTask{{
+			Name:       "fetch",
+			TaskRef:    &TaskRef{Name: "fetch-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+		}, {
+			Name:       "build",
+			TaskRef:    &TaskRef{Name: "build-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"fetch"}}},
+		}, {
+			Name:       "lint",
+			TaskRef:    &TaskRef{Name: "lint-task"},
+			RunAfter:   []string{"fetch"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+		}},
+	}, {
+		name: "parallel pipeline tasks using different sub paths of a written workspace",
+		tasks: []PipelineTask{{
+			Name:       "fetch",
+			TaskRef:    &TaskRef{Name: "fetch-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", SubPath: "src"}},
+		}, {
+			Name:       "build",
+			TaskRef:    &TaskRef{Name: "build-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", SubPath: "src", From: []string{"fetch"}}},
+		}, {
+			Name:       "docs",
+			TaskRef:    &TaskRef{Name: "docs-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", SubPath: "docs"}},
+		}},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := validateWorkspaceWriters(tt.tasks); err != nil {
+				t.Errorf("Pipeline.validateWorkspaceWriters() returned error for valid pipeline: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateWorkspaceWriters_Failure(t *testing.T) {
+	tasks := []PipelineTask{{
+		Name:       "fetch",
+		TaskRef:    &TaskRef{Name: "fetch-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+	}, {
+		Name:       "lint",
+		TaskRef:    &TaskRef{Name: "lint-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source"}},
+	}, {
+		Name:       "build",
+		TaskRef:    &TaskRef{Name: "build-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"fetch"}}},
+	}}
+	expectedError := apis.FieldError{
+		Message: `invalid value: task fetch writes to workspace source but tasks fetch and lint can run in parallel, use from or runAfter to order them`,
+		Paths:   []string{"tasks[1].workspaces[0]"},
+	}
+	err := validateWorkspaceWriters(tasks)
+	if err == nil {
+		t.Fatal("Pipeline.validateWorkspaceWriters() did not return error for parallel writes to a workspace")
+	}
+	if d := cmp.Diff(expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+		t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestValidatePipelineWorkspacesAccess_Success(t *testing.T) {
+	tasks := []PipelineTask{{
+		Name:       "fetch",
+		TaskRef:    &TaskRef{Name: "fetch-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: WorkspaceAccessReadWrite}},
+	}, {
+		Name:       "lint",
+		TaskRef:    &TaskRef{Name: "lint-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: WorkspaceAccessReadOnly, From: []string{"fetch"}}},
+	}, {
+		Name: "build",
+		TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+			Steps:      []Step{{Name: "build", Image: "busybox"}},
+			Workspaces: []WorkspaceDeclaration{{Name: "source"}},
+		}},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: WorkspaceAccessReadWrite, From: []string{"fetch"}}},
+	}}
+	ctx := config.EnableAlphaAPIFields(context.Background())
+	if err := validatePipelineWorkspacesAccess(ctx, tasks); err != nil {
+		t.Errorf("Pipeline.validatePipelineWorkspacesAccess() returned error for valid pipeline: %v", err)
+	}
+}
+
+func TestValidatePipelineWorkspacesAccess_Failure(t *testing.T) {
+	tests := []struct {
+		name          string
+		tasks         []PipelineTask
+		wc            func(context.Context) context.Context
+		expectedError apis.FieldError
+	}{{
+		name: "access requires alpha",
+		tasks: []PipelineTask{{
+			Name:       "lint",
+			TaskRef:    &TaskRef{Name: "lint-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: WorkspaceAccessReadOnly}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `workspace access requires "enable-api-fields" feature gate to be "alpha" but it is "stable"`,
+		},
+	}, {
+		name: "unknown access",
+		tasks: []PipelineTask{{
+			Name:       "lint",
+			TaskRef:    &TaskRef{Name: "lint-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: "writeOnly"}},
+		}},
+		wc: config.EnableAlphaAPIFields,
+		expectedError: apis.FieldError{
+			Message: `invalid value: unknown workspace access "writeOnly", must be one of [readOnly readWrite]`,
+			Paths:   []string{"[0].workspaces[0].access"},
+		},
+	}, {
+		name: "writable access to a workspace the task declares read-only",
+		tasks: []PipelineTask{{
+			Name: "build",
+			TaskSpec: &EmbeddedTask{TaskSpec: TaskSpec{
+				Steps:      []Step{{Name: "build", Image: "busybox"}},
+				Workspaces: []WorkspaceDeclaration{{Name: "source", ReadOnly: true}},
+			}},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: WorkspaceAccessReadWrite}},
+		}},
+		wc: config.EnableAlphaAPIFields,
+		expectedError: apis.FieldError{
+			Message: `invalid value: workspace source is declared read-only by the task of pipeline task build, it can't have readWrite access`,
+			Paths:   []string{"[0].workspaces[0].access"},
+		},
+	}, {
+		name: "from a task which only reads the workspace",
+		tasks: []PipelineTask{{
+			Name:       "lint",
+			TaskRef:    &TaskRef{Name: "lint-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: WorkspaceAccessReadOnly}},
+		}, {
+			Name:       "build",
+			TaskRef:    &TaskRef{Name: "build-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"lint"}}},
+		}},
+		wc: config.EnableAlphaAPIFields,
+		expectedError: apis.FieldError{
+			Message: `invalid value: expected workspace source to be from task lint, but task lint only reads it`,
+			Paths:   []string{"[1].workspaces[0].from"},
+		},
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.wc != nil {
+				ctx = tt.wc(ctx)
+			}
+			err := validatePipelineWorkspacesAccess(ctx, tt.tasks)
+			if err == nil {
+				t.Fatal("Pipeline.validatePipelineWorkspacesAccess() did not return error for invalid workspace access")
+			}
+			if d := cmp.Diff(tt.expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+				t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestValidateWorkspaceWriters_ReadWriteAccess(t *testing.T) {
+	tasks := []PipelineTask{{
+		Name:       "lint",
+		TaskRef:    &TaskRef{Name: "lint-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: WorkspaceAccessReadOnly}},
+	}, {
+		Name:       "format",
+		TaskRef:    &TaskRef{Name: "format-task"},
+		Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", Access: WorkspaceAccessReadWrite}},
+	}}
+	expectedError := apis.FieldError{
+		Message: `invalid value: task format writes to workspace source but tasks lint and format can run in parallel, use from or runAfter to order them`,
+		Paths:   []string{"tasks[1].workspaces[0]"},
+	}
+	err := validateWorkspaceWriters(tasks)
+	if err == nil {
+		t.Fatal("Pipeline.validateWorkspaceWriters() did not return error for parallel writes to a workspace")
+	}
+	if d := cmp.Diff(expectedError.Error(), err.Error(), cmpopts.IgnoreUnexported(apis.FieldError{})); d != "" {
+		t.Errorf("PipelineSpec.Validate() errors diff %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestValidateParamResults_Success(t *testing.T) {
 	desc := "valid pipeline task referencing task result along with parameter variable"
 	tasks := []PipelineTask{{
@@ -2630,6 +2922,17 @@ func TestValidateFinalTasks_Failure(t *testing.T) {
 			Message: `invalid value: no runAfter allowed under spec.finally, final task final-task has runAfter specified`,
 			Paths:   []string{"finally[0]"},
 		},
+	}, {
+		name: "invalid pipeline with final task workspace from referring to other task",
+		finalTasks: []PipelineTask{{
+			Name:       "final-task",
+			TaskRef:    &TaskRef{Name: "final-task"},
+			Workspaces: []WorkspacePipelineTaskBinding{{Name: "source", From: []string{"task"}}},
+		}},
+		expectedError: apis.FieldError{
+			Message: `no from allowed under workspaces, final task final-task has from specified`,
+			Paths:   []string{"finally[0].workspaces[0].from"},
+		},
 	}, {
 		name: "invalid pipeline with final task output resources referring to other task input",
 		finalTasks: []PipelineTask{{
@@ -20,23 +20,315 @@ import (
 	"context"
 	"fmt"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"knative.dev/pkg/apis"
 )
 
+// pipelineRunDeprecated holds the fields of a PipelineRun which have been
+// removed in v1.
+// +k8s:openapi-gen=false
+type pipelineRunDeprecated struct {
+	Resources    []PipelineResourceBinding    `json:"resources,omitempty"`
+	PipelineSpec *pipelineSpecDeprecated      `json:"pipelineSpec,omitempty"`
+	Status       *pipelineRunStatusDeprecated `json:"status,omitempty"`
+}
+
+// pipelineRunStatusDeprecated holds the fields of a PipelineRunStatus which have
+// been removed in v1. TaskRuns is keyed by the name of the TaskRun.
+// +k8s:openapi-gen=false
+type pipelineRunStatusDeprecated struct {
+	PipelineSpec *pipelineSpecDeprecated             `json:"pipelineSpec,omitempty"`
+	TaskRuns     map[string]*taskRunStatusDeprecated `json:"taskRuns,omitempty"`
+}
+
 var _ apis.Convertible = (*PipelineRun)(nil)
 
-// ConvertTo implements api.Convertible
-func (pr *PipelineRun) ConvertTo(ctx context.Context, sink apis.Convertible) error {
+// ConvertTo implements apis.Convertible
+func (pr *PipelineRun) ConvertTo(ctx context.Context, to apis.Convertible) error {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
-	return fmt.Errorf("v1beta1 is the highest known version, got: %T", sink)
+	switch sink := to.(type) {
+	case *v1.PipelineRun:
+		sink.ObjectMeta = pr.ObjectMeta
+		deprecated := &pipelineRunDeprecated{
+			Resources:    pr.Spec.Resources,
+			PipelineSpec: pr.Spec.convertTo(ctx, &sink.Spec),
+			Status:       pr.Status.convertTo(ctx, &sink.Status),
+		}
+		if deprecated.Resources != nil || deprecated.PipelineSpec != nil || deprecated.Status != nil {
+			return version.SerializeToMetadata(&sink.ObjectMeta, deprecated, deprecatedFieldsAnnotationKey)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// convertTo converts the PipelineRunSpec to v1 and returns the fields of its
+// PipelineSpec which could not be represented in v1, or nil if there are none.
+// Resources are not converted.
+func (prs *PipelineRunSpec) convertTo(ctx context.Context, sink *v1.PipelineRunSpec) *pipelineSpecDeprecated {
+	var deprecated *pipelineSpecDeprecated
+	sink.PipelineRef = nil
+	if prs.PipelineRef != nil {
+		sink.PipelineRef = &v1.PipelineRef{}
+		prs.PipelineRef.convertTo(ctx, sink.PipelineRef)
+	}
+	sink.PipelineSpec = nil
+	if prs.PipelineSpec != nil {
+		sink.PipelineSpec = &v1.PipelineSpec{}
+		deprecated = prs.PipelineSpec.convertTo(ctx, sink.PipelineSpec)
+	}
+	sink.Params = nil
+	for _, p := range prs.Params {
+		new := v1.Param{}
+		p.convertTo(ctx, &new)
+		sink.Params = append(sink.Params, new)
+	}
+	sink.ServiceAccountName = prs.ServiceAccountName
+	sink.Status = v1.PipelineRunSpecStatus(prs.Status)
+	sink.Timeouts = (*v1.TimeoutFields)(prs.Timeouts)
+	sink.Timeout = prs.Timeout
+	sink.PodTemplate = prs.PodTemplate
+	sink.Workspaces = nil
+	for _, w := range prs.Workspaces {
+		new := v1.WorkspaceBinding{}
+		w.convertTo(ctx, &new)
+		sink.Workspaces = append(sink.Workspaces, new)
+	}
+	sink.TaskRunSpecs = nil
+	for _, ts := range prs.TaskRunSpecs {
+		new := v1.PipelineTaskRunSpec{}
+		ts.convertTo(ctx, &new)
+		sink.TaskRunSpecs = append(sink.TaskRunSpecs, new)
+	}
+	sink.TTLSecondsAfterFinished = prs.TTLSecondsAfterFinished
+	return deprecated
 }
 
-// ConvertFrom implements api.Convertible
-func (pr *PipelineRun) ConvertFrom(ctx context.Context, source apis.Convertible) error {
+func (ptrs PipelineTaskRunSpec) convertTo(ctx context.Context, sink *v1.PipelineTaskRunSpec) {
+	sink.PipelineTaskName = ptrs.PipelineTaskName
+	sink.TaskServiceAccountName = ptrs.TaskServiceAccountName
+	sink.TaskPodTemplate = ptrs.TaskPodTemplate
+	sink.StepOverrides = nil
+	for _, o := range ptrs.StepOverrides {
+		sink.StepOverrides = append(sink.StepOverrides, v1.TaskRunStepOverride(o))
+	}
+	sink.SidecarOverrides = nil
+	for _, o := range ptrs.SidecarOverrides {
+		sink.SidecarOverrides = append(sink.SidecarOverrides, v1.TaskRunSidecarOverride(o))
+	}
+	sink.Metadata = (*v1.PipelineTaskMetadata)(ptrs.Metadata)
+	sink.ComputeResources = ptrs.ComputeResources
+}
+
+// convertTo converts the PipelineRunStatus to v1 and returns the fields which
+// could not be represented in v1, or nil if there are none.
+func (prs *PipelineRunStatus) convertTo(ctx context.Context, sink *v1.PipelineRunStatus) *pipelineRunStatusDeprecated {
+	deprecated := &pipelineRunStatusDeprecated{}
+	sink.Status = prs.Status
+	sink.StartTime = prs.StartTime
+	sink.CompletionTime = prs.CompletionTime
+	sink.TaskRuns = nil
+	if prs.TaskRuns != nil {
+		sink.TaskRuns = make(map[string]*v1.PipelineRunTaskRunStatus, len(prs.TaskRuns))
+	}
+	for name, trs := range prs.TaskRuns {
+		if trs == nil {
+			sink.TaskRuns[name] = nil
+			continue
+		}
+		new := &v1.PipelineRunTaskRunStatus{PipelineTaskName: trs.PipelineTaskName}
+		if trs.Status != nil {
+			new.Status = &v1.TaskRunStatus{}
+			if d := trs.Status.convertTo(ctx, new.Status); d != nil {
+				if deprecated.TaskRuns == nil {
+					deprecated.TaskRuns = map[string]*taskRunStatusDeprecated{}
+				}
+				deprecated.TaskRuns[name] = d
+			}
+		}
+		WhenExpressions(trs.WhenExpressions).convertTo(ctx, (*v1.WhenExpressions)(&new.WhenExpressions))
+		sink.TaskRuns[name] = new
+	}
+	sink.Runs = nil
+	if prs.Runs != nil {
+		sink.Runs = make(map[string]*v1.PipelineRunRunStatus, len(prs.Runs))
+	}
+	for name, rs := range prs.Runs {
+		if rs == nil {
+			sink.Runs[name] = nil
+			continue
+		}
+		new := &v1.PipelineRunRunStatus{PipelineTaskName: rs.PipelineTaskName, Status: rs.Status}
+		WhenExpressions(rs.WhenExpressions).convertTo(ctx, (*v1.WhenExpressions)(&new.WhenExpressions))
+		sink.Runs[name] = new
+	}
+	sink.PipelineResults = nil
+	for _, r := range prs.PipelineResults {
+		new := v1.PipelineRunResult{}
+		r.convertTo(ctx, &new)
+		sink.PipelineResults = append(sink.PipelineResults, new)
+	}
+	sink.PipelineSpec = nil
+	if prs.PipelineSpec != nil {
+		sink.PipelineSpec = &v1.PipelineSpec{}
+		deprecated.PipelineSpec = prs.PipelineSpec.convertTo(ctx, sink.PipelineSpec)
+	}
+	sink.SkippedTasks = nil
+	for _, st := range prs.SkippedTasks {
+		new := v1.SkippedTask{Name: st.Name, Reason: v1.SkippingReason(st.Reason)}
+		WhenExpressions(st.WhenExpressions).convertTo(ctx, (*v1.WhenExpressions)(&new.WhenExpressions))
+		sink.SkippedTasks = append(sink.SkippedTasks, new)
+	}
+	sink.ChildReferences = nil
+	for _, cr := range prs.ChildReferences {
+		new := v1.ChildStatusReference{TypeMeta: cr.TypeMeta, Name: cr.Name, PipelineTaskName: cr.PipelineTaskName}
+		WhenExpressions(cr.WhenExpressions).convertTo(ctx, (*v1.WhenExpressions)(&new.WhenExpressions))
+		sink.ChildReferences = append(sink.ChildReferences, new)
+	}
+	sink.ResourceUsage = (*v1.ResourceUsage)(prs.ResourceUsage)
+	if deprecated.PipelineSpec == nil && deprecated.TaskRuns == nil {
+		return nil
+	}
+	return deprecated
+}
+
+// ConvertFrom implements apis.Convertible
+func (pr *PipelineRun) ConvertFrom(ctx context.Context, from apis.Convertible) error {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
-	return fmt.Errorf("v1beta1 is the highest know version, got: %T", source)
+	switch source := from.(type) {
+	case *v1.PipelineRun:
+		pr.ObjectMeta = source.ObjectMeta
+		deprecated := &pipelineRunDeprecated{}
+		if err := version.DeserializeFromMetadata(&pr.ObjectMeta, deprecated, deprecatedFieldsAnnotationKey); err != nil {
+			return err
+		}
+		pr.Spec.convertFrom(ctx, &source.Spec, deprecated.PipelineSpec)
+		pr.Spec.Resources = deprecated.Resources
+		pr.Status.convertFrom(ctx, &source.Status, deprecated.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+func (prs *PipelineRunSpec) convertFrom(ctx context.Context, source *v1.PipelineRunSpec, deprecated *pipelineSpecDeprecated) {
+	prs.PipelineRef = nil
+	if source.PipelineRef != nil {
+		prs.PipelineRef = &PipelineRef{}
+		prs.PipelineRef.convertFrom(ctx, *source.PipelineRef)
+	}
+	prs.PipelineSpec = nil
+	if source.PipelineSpec != nil {
+		prs.PipelineSpec = &PipelineSpec{}
+		prs.PipelineSpec.convertFrom(ctx, source.PipelineSpec, deprecated)
+	}
+	prs.Params = nil
+	for _, p := range source.Params {
+		new := Param{}
+		new.convertFrom(ctx, p)
+		prs.Params = append(prs.Params, new)
+	}
+	prs.ServiceAccountName = source.ServiceAccountName
+	prs.Status = PipelineRunSpecStatus(source.Status)
+	prs.Timeouts = (*TimeoutFields)(source.Timeouts)
+	prs.Timeout = source.Timeout
+	prs.PodTemplate = source.PodTemplate
+	prs.Workspaces = nil
+	for _, w := range source.Workspaces {
+		new := WorkspaceBinding{}
+		new.convertFrom(ctx, w)
+		prs.Workspaces = append(prs.Workspaces, new)
+	}
+	prs.TaskRunSpecs = nil
+	for _, ts := range source.TaskRunSpecs {
+		new := PipelineTaskRunSpec{}
+		new.convertFrom(ctx, ts)
+		prs.TaskRunSpecs = append(prs.TaskRunSpecs, new)
+	}
+	prs.TTLSecondsAfterFinished = source.TTLSecondsAfterFinished
+}
+
+func (ptrs *PipelineTaskRunSpec) convertFrom(ctx context.Context, source v1.PipelineTaskRunSpec) {
+	ptrs.PipelineTaskName = source.PipelineTaskName
+	ptrs.TaskServiceAccountName = source.TaskServiceAccountName
+	ptrs.TaskPodTemplate = source.TaskPodTemplate
+	ptrs.StepOverrides = nil
+	for _, o := range source.StepOverrides {
+		ptrs.StepOverrides = append(ptrs.StepOverrides, TaskRunStepOverride(o))
+	}
+	ptrs.SidecarOverrides = nil
+	for _, o := range source.SidecarOverrides {
+		ptrs.SidecarOverrides = append(ptrs.SidecarOverrides, TaskRunSidecarOverride(o))
+	}
+	ptrs.Metadata = (*PipelineTaskMetadata)(source.Metadata)
+	ptrs.ComputeResources = source.ComputeResources
+}
+
+func (prs *PipelineRunStatus) convertFrom(ctx context.Context, source *v1.PipelineRunStatus, deprecated *pipelineRunStatusDeprecated) {
+	if deprecated == nil {
+		deprecated = &pipelineRunStatusDeprecated{}
+	}
+	prs.Status = source.Status
+	prs.StartTime = source.StartTime
+	prs.CompletionTime = source.CompletionTime
+	prs.TaskRuns = nil
+	if source.TaskRuns != nil {
+		prs.TaskRuns = make(map[string]*PipelineRunTaskRunStatus, len(source.TaskRuns))
+	}
+	for name, trs := range source.TaskRuns {
+		if trs == nil {
+			prs.TaskRuns[name] = nil
+			continue
+		}
+		new := &PipelineRunTaskRunStatus{PipelineTaskName: trs.PipelineTaskName}
+		if trs.Status != nil {
+			new.Status = &TaskRunStatus{}
+			new.Status.convertFrom(ctx, trs.Status, deprecated.TaskRuns[name])
+		}
+		(*WhenExpressions)(&new.WhenExpressions).convertFrom(ctx, trs.WhenExpressions)
+		prs.TaskRuns[name] = new
+	}
+	prs.Runs = nil
+	if source.Runs != nil {
+		prs.Runs = make(map[string]*PipelineRunRunStatus, len(source.Runs))
+	}
+	for name, rs := range source.Runs {
+		if rs == nil {
+			prs.Runs[name] = nil
+			continue
+		}
+		new := &PipelineRunRunStatus{PipelineTaskName: rs.PipelineTaskName, Status: rs.Status}
+		(*WhenExpressions)(&new.WhenExpressions).convertFrom(ctx, rs.WhenExpressions)
+		prs.Runs[name] = new
+	}
+	prs.PipelineResults = nil
+	for _, r := range source.PipelineResults {
+		new := PipelineRunResult{}
+		new.convertFrom(ctx, r)
+		prs.PipelineResults = append(prs.PipelineResults, new)
+	}
+	prs.PipelineSpec = nil
+	if source.PipelineSpec != nil {
+		prs.PipelineSpec = &PipelineSpec{}
+		prs.PipelineSpec.convertFrom(ctx, source.PipelineSpec, deprecated.PipelineSpec)
+	}
+	prs.SkippedTasks = nil
+	for _, st := range source.SkippedTasks {
+		new := SkippedTask{Name: st.Name, Reason: SkippingReason(st.Reason)}
+		(*WhenExpressions)(&new.WhenExpressions).convertFrom(ctx, st.WhenExpressions)
+		prs.SkippedTasks = append(prs.SkippedTasks, new)
+	}
+	prs.ChildReferences = nil
+	for _, cr := range source.ChildReferences {
+		new := ChildStatusReference{TypeMeta: cr.TypeMeta, Name: cr.Name, PipelineTaskName: cr.PipelineTaskName}
+		(*WhenExpressions)(&new.WhenExpressions).convertFrom(ctx, cr.WhenExpressions)
+		prs.ChildReferences = append(prs.ChildReferences, new)
+	}
+	prs.ResourceUsage = (*ResourceUsage)(source.ResourceUsage)
 }
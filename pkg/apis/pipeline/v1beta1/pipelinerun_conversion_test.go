@@ -19,6 +19,19 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	runv1alpha1 "github.com/tektoncd/pipeline/pkg/apis/run/v1alpha1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/selection"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 func TestPipelineRunConversionBadType(t *testing.T) {
@@ -32,3 +45,202 @@ func TestPipelineRunConversionBadType(t *testing.T) {
 		t.Errorf("ConvertFrom() = %#v, wanted error", good)
 	}
 }
+
+func TestPipelineRunConversion(t *testing.T) {
+	versions := []apis.Convertible{&v1.PipelineRun{}}
+	ttl := int32(3600)
+
+	when := []WhenExpression{{
+		Input:    "foo",
+		Operator: selection.NotIn,
+		Values:   []string{"bar"},
+	}}
+	tests := []struct {
+		name string
+		in   *PipelineRun
+	}{{
+		name: "simple pipelinerun",
+		in: &PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "foo",
+				Namespace:  "bar",
+				Generation: 1,
+			},
+			Spec: PipelineRunSpec{
+				PipelineRef: &PipelineRef{Name: "pipeline"},
+			},
+		},
+	}, {
+		name: "pipelinerun conversion all non deprecated fields",
+		in: &PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "foo",
+				Namespace:  "bar",
+				Generation: 1,
+			},
+			Spec: PipelineRunSpec{
+				PipelineSpec: &PipelineSpec{
+					Tasks: []PipelineTask{{
+						Name:    "task-1",
+						TaskRef: &TaskRef{Name: "task"},
+					}},
+				},
+				Params: []Param{{
+					Name:  "param-1",
+					Value: *NewArrayOrString("a", "b"),
+				}},
+				ServiceAccountName: "sa",
+				Status:             PipelineRunSpecStatusCancelled,
+				Timeouts: &TimeoutFields{
+					Pipeline: &metav1.Duration{Duration: time.Hour},
+					Tasks:    &metav1.Duration{Duration: 30 * time.Minute},
+					Finally:  &metav1.Duration{Duration: 30 * time.Minute},
+				},
+				PodTemplate:             &pod.Template{NodeSelector: map[string]string{"foo": "bar"}},
+				TTLSecondsAfterFinished: &ttl,
+				Workspaces: []WorkspaceBinding{{
+					Name:     "workspace",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}},
+				TaskRunSpecs: []PipelineTaskRunSpec{{
+					PipelineTaskName:       "task-1",
+					TaskServiceAccountName: "task-sa",
+					StepOverrides:          []TaskRunStepOverride{{Name: "step"}},
+					SidecarOverrides:       []TaskRunSidecarOverride{{Name: "sidecar"}},
+					Metadata: &PipelineTaskMetadata{
+						Labels: map[string]string{"foo": "bar"},
+					},
+				}},
+			},
+			Status: PipelineRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					}},
+				},
+				PipelineRunStatusFields: PipelineRunStatusFields{
+					TaskRuns: map[string]*PipelineRunTaskRunStatus{
+						"foo-task-1": {
+							PipelineTaskName: "task-1",
+							Status: &TaskRunStatus{
+								TaskRunStatusFields: TaskRunStatusFields{PodName: "pod"},
+							},
+							WhenExpressions: when,
+						},
+					},
+					Runs: map[string]*PipelineRunRunStatus{
+						"foo-run-1": {
+							PipelineTaskName: "run-1",
+							Status:           &runv1alpha1.RunStatus{},
+						},
+					},
+					PipelineResults: []PipelineRunResult{{
+						Name:  "result",
+						Value: *NewArrayOrString("value"),
+					}},
+					PipelineSpec: &PipelineSpec{
+						Tasks: []PipelineTask{{
+							Name:    "task-1",
+							TaskRef: &TaskRef{Name: "task"},
+						}},
+					},
+					SkippedTasks: []SkippedTask{{
+						Name:            "task-2",
+						Reason:          WhenExpressionsSkip,
+						WhenExpressions: when,
+					}},
+					ChildReferences: []ChildStatusReference{{
+						TypeMeta:         runtime.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "TaskRun"},
+						Name:             "foo-task-1",
+						PipelineTaskName: "task-1",
+						WhenExpressions:  when,
+					}},
+					ResourceUsage: &ResourceUsage{
+						Duration: &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+		},
+	}, {
+		name: "pipelinerun with deprecated fields",
+		in: &PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   "bar",
+				Generation:  1,
+				Annotations: map[string]string{"foo": "bar"},
+			},
+			Spec: PipelineRunSpec{
+				Resources: []PipelineResourceBinding{{
+					Name:        "git-repo",
+					ResourceRef: &PipelineResourceRef{Name: "git-resource"},
+				}},
+				PipelineSpec: &PipelineSpec{
+					Resources: []PipelineDeclaredResource{{
+						Name: "git-repo",
+						Type: PipelineResourceTypeGit,
+					}},
+					Tasks: []PipelineTask{{
+						Name:    "task-1",
+						TaskRef: &TaskRef{Name: "task"},
+						Resources: &PipelineTaskResources{
+							Inputs: []PipelineTaskInputResource{{
+								Name:     "source",
+								Resource: "git-repo",
+							}},
+						},
+					}},
+				},
+			},
+			Status: PipelineRunStatus{
+				PipelineRunStatusFields: PipelineRunStatusFields{
+					TaskRuns: map[string]*PipelineRunTaskRunStatus{
+						"foo-task-1": {
+							PipelineTaskName: "task-1",
+							Status: &TaskRunStatus{
+								TaskRunStatusFields: TaskRunStatusFields{
+									PodName: "pod",
+									ResourcesResult: []PipelineResourceResult{{
+										Key:   "commit",
+										Value: "abc",
+									}},
+								},
+							},
+						},
+						"foo-task-2": {
+							PipelineTaskName: "task-2",
+						},
+					},
+					PipelineSpec: &PipelineSpec{
+						Resources: []PipelineDeclaredResource{{
+							Name: "git-repo",
+							Type: PipelineResourceTypeGit,
+						}},
+					},
+				},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, version := range versions {
+			t.Run(test.name, func(t *testing.T) {
+				ver := version
+				if err := test.in.ConvertTo(context.Background(), ver); err != nil {
+					t.Errorf("ConvertTo() = %v", err)
+					return
+				}
+				t.Logf("ConvertTo() = %#v", ver)
+				got := &PipelineRun{}
+				if err := got.ConvertFrom(context.Background(), ver); err != nil {
+					t.Errorf("ConvertFrom() = %v", err)
+				}
+				t.Logf("ConvertFrom() = %#v", got)
+				if d := cmp.Diff(test.in, got); d != "" {
+					t.Errorf("roundtrip %s", diff.PrintWantGot(d))
+				}
+			})
+		}
+	}
+}
@@ -29,6 +29,7 @@ var _ apis.Defaultable = (*PipelineRun)(nil)
 
 // SetDefaults implements apis.Defaultable
 func (pr *PipelineRun) SetDefaults(ctx context.Context) {
+	ctx = apis.WithinParent(ctx, pr.ObjectMeta)
 	pr.Spec.SetDefaults(ctx)
 }
 
@@ -51,6 +52,15 @@ func (prs *PipelineRunSpec) SetDefaults(ctx context.Context) {
 	defaultPodTemplate := cfg.Defaults.DefaultPodTemplate
 	prs.PodTemplate = MergePodTemplateWithDefault(prs.PodTemplate, defaultPodTemplate)
 
+	// ttlSecondsAfterFinished is only supported when the alpha feature gate is enabled.
+	if prs.TTLSecondsAfterFinished == nil && cfg.Defaults.DefaultTTLSecondsAfterFinished != config.NoTTLSecondsAfterFinished &&
+		cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields {
+		ttl := int32(cfg.Defaults.DefaultTTLSecondsAfterFinished)
+		prs.TTLSecondsAfterFinished = &ttl
+	}
+
+	setVolumeClaimTemplateDefaults(ctx, prs.Workspaces)
+
 	if prs.PipelineSpec != nil {
 		prs.PipelineSpec.SetDefaults(ctx)
 	}
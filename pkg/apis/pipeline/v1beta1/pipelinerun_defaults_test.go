@@ -28,6 +28,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	logtesting "knative.dev/pkg/logging/testing"
 )
@@ -106,6 +107,8 @@ func TestPipelineRunSpec_SetDefaults(t *testing.T) {
 }
 
 func TestPipelineRunDefaulting(t *testing.T) {
+	ttl := int32(3600)
+	fastStorageClassName := "fast"
 	tests := []struct {
 		name string
 		in   *v1beta1.PipelineRun
@@ -196,6 +199,69 @@ func TestPipelineRunDefaulting(t *testing.T) {
 			})
 			return s.ToContext(ctx)
 		},
+	}, {
+		name: "PipelineRef default config context with ttlSecondsAfterFinished",
+		in: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			},
+		},
+		want: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef:             &v1beta1.PipelineRef{Name: "foo"},
+				ServiceAccountName:      config.DefaultServiceAccountValue,
+				Timeout:                 &metav1.Duration{Duration: 5 * time.Minute},
+				TTLSecondsAfterFinished: &ttl,
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			s := config.NewStore(logtesting.TestLogger(t))
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.GetDefaultsConfigName(),
+				},
+				Data: map[string]string{
+					"default-timeout-minutes":            "5",
+					"default-ttl-seconds-after-finished": "3600",
+				},
+			})
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.GetFeatureFlagsConfigName(),
+				},
+				Data: map[string]string{
+					"enable-api-fields": "alpha",
+				},
+			})
+			return s.ToContext(ctx)
+		},
+	}, {
+		name: "PipelineRef default config context with ttlSecondsAfterFinished without alpha feature gate",
+		in: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+			},
+		},
+		want: &v1beta1.PipelineRun{
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef:        &v1beta1.PipelineRef{Name: "foo"},
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Timeout:            &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			s := config.NewStore(logtesting.TestLogger(t))
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.GetDefaultsConfigName(),
+				},
+				Data: map[string]string{
+					"default-timeout-minutes":            "5",
+					"default-ttl-seconds-after-finished": "3600",
+				},
+			})
+			return s.ToContext(ctx)
+		},
 	}, {
 		name: "PipelineRef pod template is coming from default config pod template",
 		in: &v1beta1.PipelineRun{
@@ -313,6 +379,97 @@ func TestPipelineRunDefaulting(t *testing.T) {
 			})
 			return s.ToContext(ctx)
 		},
+	}, {
+		name: "PipelineRun volumeClaimTemplate defaults from namespace policy",
+		in: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "builds"},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:                "source",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{},
+				}},
+			},
+		},
+		want: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "builds"},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef:        &v1beta1.PipelineRef{Name: "foo"},
+				Timeout:            &metav1.Duration{Duration: time.Duration(config.DefaultTimeoutMinutes) * time.Minute},
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name: "source",
+					VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+						Spec: corev1.PersistentVolumeClaimSpec{
+							StorageClassName: &fastStorageClassName,
+							AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+							},
+						},
+					},
+				}},
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			s := config.NewStore(logtesting.TestLogger(t))
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.GetDefaultsConfigName(),
+				},
+				Data: map[string]string{
+					"default-volume-claim-template-policy": "storageClassName: standard\nstorage: 1Gi\naccessModes: [ReadWriteOnce]\nnamespaces:\n  builds:\n    storageClassName: fast",
+				},
+			})
+			return s.ToContext(ctx)
+		},
+	}, {
+		name: "PipelineRun cache volumeClaimTemplate defaults from namespace policy",
+		in: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "builds"},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef: &v1beta1.PipelineRef{Name: "foo"},
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name:  "gocache",
+					Cache: &v1beta1.CacheWorkspaceBinding{Key: "go-mod-1234"},
+				}},
+			},
+		},
+		want: &v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "builds"},
+			Spec: v1beta1.PipelineRunSpec{
+				PipelineRef:        &v1beta1.PipelineRef{Name: "foo"},
+				Timeout:            &metav1.Duration{Duration: time.Duration(config.DefaultTimeoutMinutes) * time.Minute},
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				Workspaces: []v1beta1.WorkspaceBinding{{
+					Name: "gocache",
+					Cache: &v1beta1.CacheWorkspaceBinding{
+						Key: "go-mod-1234",
+						VolumeClaimTemplate: corev1.PersistentVolumeClaim{
+							Spec: corev1.PersistentVolumeClaimSpec{
+								StorageClassName: &fastStorageClassName,
+								AccessModes:      []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+								Resources: corev1.ResourceRequirements{
+									Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+								},
+							},
+						},
+					},
+				}},
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			s := config.NewStore(logtesting.TestLogger(t))
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.GetDefaultsConfigName(),
+				},
+				Data: map[string]string{
+					"default-volume-claim-template-policy": "storageClassName: standard\nstorage: 1Gi\naccessModes: [ReadWriteOnce]\nnamespaces:\n  builds:\n    storageClassName: fast",
+				},
+			})
+			return s.ToContext(ctx)
+		},
 	}}
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
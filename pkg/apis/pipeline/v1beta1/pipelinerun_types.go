@@ -174,10 +174,11 @@ func (pr *PipelineRun) HasTimedOut(ctx context.Context, c clock.PassiveClock) bo
 }
 
 // HasVolumeClaimTemplate returns true if PipelineRun contains volumeClaimTemplates that is
-// used for creating PersistentVolumeClaims with an OwnerReference for each run
+// used for creating PersistentVolumeClaims with an OwnerReference for each run, or
+// cache workspaces whose PersistentVolumeClaims are created from a template
 func (pr *PipelineRun) HasVolumeClaimTemplate() bool {
 	for _, ws := range pr.Spec.Workspaces {
-		if ws.VolumeClaimTemplate != nil {
+		if ws.VolumeClaimTemplate != nil || ws.Cache != nil {
 			return true
 		}
 	}
@@ -227,6 +228,11 @@ type PipelineRunSpec struct {
 	// +optional
 	// +listType=atomic
 	TaskRunSpecs []PipelineTaskRunSpec `json:"taskRunSpecs,omitempty"`
+	// TTLSecondsAfterFinished limits the lifetime of a PipelineRun that has finished
+	// execution. The PipelineRun is deleted by the controller once this many seconds
+	// have elapsed since its completion. Defaults to never.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
 // TimeoutFields allows granular specification of pipeline, task, and finally timeouts
@@ -417,6 +423,11 @@ type PipelineRunStatusFields struct {
 	// +optional
 	// +listType=atomic
 	ChildReferences []ChildStatusReference `json:"childReferences,omitempty"`
+
+	// ResourceUsage is the sum of the compute resources reserved by the completed
+	// TaskRuns of this PipelineRun, including retried attempts.
+	// +optional
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"`
 }
 
 // SkippedTask is used to describe the Tasks that were skipped due to their When Expressions
@@ -542,6 +553,7 @@ func (pr *PipelineRun) GetTaskRunSpec(pipelineTaskName string) PipelineTaskRunSp
 			s.StepOverrides = task.StepOverrides
 			s.SidecarOverrides = task.SidecarOverrides
 			s.Metadata = task.Metadata
+			s.ComputeResources = task.ComputeResources
 		}
 	}
 	return s
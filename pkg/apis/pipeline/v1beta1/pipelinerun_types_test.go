@@ -26,6 +26,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"knative.dev/pkg/apis"
@@ -472,3 +473,25 @@ func TestPipelineRunGetPodSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestPipelineRunGetTaskRunSpecComputeResources(t *testing.T) {
+	computeResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")},
+	}
+	pr := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr"},
+		Spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{Name: "prs"},
+			TaskRunSpecs: []v1beta1.PipelineTaskRunSpec{{
+				PipelineTaskName: "taskNameOne",
+				ComputeResources: computeResources,
+			}},
+		},
+	}
+	if d := cmp.Diff(computeResources, pr.GetTaskRunSpec("taskNameOne").ComputeResources); d != "" {
+		t.Errorf("wrong compute resources %s", diff.PrintWantGot(d))
+	}
+	if got := pr.GetTaskRunSpec("unknown").ComputeResources; got != nil {
+		t.Errorf("expected no compute resources, got %v", got)
+	}
+}
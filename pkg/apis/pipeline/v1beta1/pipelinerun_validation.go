@@ -95,6 +95,13 @@ func (ps *PipelineRunSpec) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
+	if ps.TTLSecondsAfterFinished != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "ttlSecondsAfterFinished", config.AlphaAPIFields).ViaField("ttlSecondsAfterFinished"))
+		if *ps.TTLSecondsAfterFinished < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ps.TTLSecondsAfterFinished), "ttlSecondsAfterFinished"))
+		}
+	}
+
 	errs = errs.Also(validateSpecStatus(ps.Status))
 
 	if ps.Workspaces != nil {
@@ -306,6 +306,8 @@ func TestPipelineRun_Validate(t *testing.T) {
 }
 
 func TestPipelineRunSpec_Invalidate(t *testing.T) {
+	negativeTTL := int32(-1)
+	ttl := int32(3600)
 	tests := []struct {
 		name        string
 		spec        v1beta1.PipelineRunSpec
@@ -326,6 +328,25 @@ func TestPipelineRunSpec_Invalidate(t *testing.T) {
 				}}},
 		},
 		wantErr: apis.ErrMultipleOneOf("pipelineRef", "pipelineSpec"),
+	}, {
+		name: "negative ttlSecondsAfterFinished",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "pipelinerefname",
+			},
+			TTLSecondsAfterFinished: &negativeTTL,
+		},
+		wantErr:     apis.ErrInvalidValue("-1 should be >= 0", "ttlSecondsAfterFinished"),
+		withContext: config.EnableAlphaAPIFields,
+	}, {
+		name: "ttlSecondsAfterFinished without alpha feature gate",
+		spec: v1beta1.PipelineRunSpec{
+			PipelineRef: &v1beta1.PipelineRef{
+				Name: "pipelinerefname",
+			},
+			TTLSecondsAfterFinished: &ttl,
+		},
+		wantErr: apis.ErrGeneric("ttlSecondsAfterFinished requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaField("ttlSecondsAfterFinished"),
 	}, {
 		name: "workspaces may only appear once",
 		spec: v1beta1.PipelineRunSpec{
@@ -27,3 +27,51 @@ func (r *TaskResult) convertFrom(ctx context.Context, source v1.TaskResult) {
 	}
 	r.Properties = properties
 }
+
+func (r TaskRunResult) convertTo(ctx context.Context, sink *v1.TaskRunResult) {
+	sink.Name = r.Name
+	sink.Type = v1.ResultsType(r.Type)
+	newValue := v1.ArrayOrString{}
+	r.Value.convertTo(ctx, &newValue)
+	sink.Value = newValue
+}
+
+func (r *TaskRunResult) convertFrom(ctx context.Context, source v1.TaskRunResult) {
+	r.Name = source.Name
+	r.Type = ResultsType(source.Type)
+	newValue := ArrayOrString{}
+	newValue.convertFrom(ctx, source.Value)
+	r.Value = newValue
+}
+
+func (r PipelineResult) convertTo(ctx context.Context, sink *v1.PipelineResult) {
+	sink.Name = r.Name
+	sink.Type = v1.ResultsType(r.Type)
+	sink.Description = r.Description
+	newValue := v1.ArrayOrString{}
+	r.Value.convertTo(ctx, &newValue)
+	sink.Value = newValue
+}
+
+func (r *PipelineResult) convertFrom(ctx context.Context, source v1.PipelineResult) {
+	r.Name = source.Name
+	r.Type = ResultsType(source.Type)
+	r.Description = source.Description
+	newValue := ArrayOrString{}
+	newValue.convertFrom(ctx, source.Value)
+	r.Value = newValue
+}
+
+func (r PipelineRunResult) convertTo(ctx context.Context, sink *v1.PipelineRunResult) {
+	sink.Name = r.Name
+	newValue := v1.ArrayOrString{}
+	r.Value.convertTo(ctx, &newValue)
+	sink.Value = newValue
+}
+
+func (r *PipelineRunResult) convertFrom(ctx context.Context, source v1.PipelineRunResult) {
+	r.Name = source.Name
+	newValue := ArrayOrString{}
+	newValue.convertFrom(ctx, source.Value)
+	r.Value = newValue
+}
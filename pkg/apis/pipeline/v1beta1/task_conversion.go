@@ -21,9 +21,24 @@ import (
 	"fmt"
 
 	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"knative.dev/pkg/apis"
 )
 
+// deprecatedFieldsAnnotationKey is the annotation under which fields that have
+// been removed in v1 are serialized when converting from v1beta1, so that they
+// can be restored when converting back.
+const deprecatedFieldsAnnotationKey = "tekton.dev/v1beta1DeprecatedFields"
+
+// taskSpecDeprecated holds the fields of a TaskSpec which have been removed in v1.
+// Steps is index-aligned with the TaskSpec's Steps.
+// +k8s:openapi-gen=false
+type taskSpecDeprecated struct {
+	Resources    *TaskResources    `json:"resources,omitempty"`
+	Steps        []*stepDeprecated `json:"steps,omitempty"`
+	StepTemplate *stepDeprecated   `json:"stepTemplate,omitempty"`
+}
+
 var _ apis.Convertible = (*Task)(nil)
 
 // ConvertTo implements apis.Convertible
@@ -34,25 +49,47 @@ func (t *Task) ConvertTo(ctx context.Context, to apis.Convertible) error {
 	switch sink := to.(type) {
 	case *v1.Task:
 		sink.ObjectMeta = t.ObjectMeta
-		return t.Spec.ConvertTo(ctx, &sink.Spec)
+		if d := t.Spec.convertTo(ctx, &sink.Spec); d != nil {
+			return version.SerializeToMetadata(&sink.ObjectMeta, d, deprecatedFieldsAnnotationKey)
+		}
+		return nil
 	default:
 		return fmt.Errorf("unknown version, got: %T", sink)
 	}
 }
 
-// ConvertTo implements apis.Convertible
+// ConvertTo implements apis.Convertible. Fields which have been removed in v1
+// are dropped; use the Task, Pipeline, TaskRun or PipelineRun conversions to
+// preserve them.
 func (ts *TaskSpec) ConvertTo(ctx context.Context, sink *v1.TaskSpec) error {
+	ts.convertTo(ctx, sink)
+	return nil
+}
+
+// convertTo converts the TaskSpec to v1 and returns the fields which could not
+// be represented in v1, or nil if there are none.
+func (ts *TaskSpec) convertTo(ctx context.Context, sink *v1.TaskSpec) *taskSpecDeprecated {
+	deprecated := &taskSpecDeprecated{Resources: ts.Resources}
 	sink.Steps = nil
+	hasDeprecatedSteps := false
 	for _, s := range ts.Steps {
 		new := v1.Step{}
 		s.convertTo(ctx, &new)
 		sink.Steps = append(sink.Steps, new)
+		d := s.deprecated()
+		hasDeprecatedSteps = hasDeprecatedSteps || d != nil
+		deprecated.Steps = append(deprecated.Steps, d)
+	}
+	if !hasDeprecatedSteps {
+		deprecated.Steps = nil
 	}
 	sink.Volumes = ts.Volumes
+	sink.StepTemplate = nil
 	if ts.StepTemplate != nil {
 		new := v1.StepTemplate{}
 		ts.StepTemplate.convertTo(ctx, &new)
 		sink.StepTemplate = &new
+		deprecated.StepTemplate = ts.StepTemplate.deprecated()
 	}
 	sink.Sidecars = nil
 	for _, s := range ts.Sidecars {
@@ -79,8 +116,10 @@ func (ts *TaskSpec) ConvertTo(ctx context.Context, sink *v1.TaskSpec) error {
 		sink.Params = append(sink.Params, new)
 	}
 	sink.Description = ts.Description
-	// TODO(#4546): Handle Resources
-	return nil
+	if deprecated.Resources == nil && deprecated.Steps == nil && deprecated.StepTemplate == nil {
+		return nil
+	}
+	return deprecated
 }
 
 // ConvertFrom implements apis.Convertible
@@ -91,7 +130,12 @@ func (t *Task) ConvertFrom(ctx context.Context, from apis.Convertible) error {
 	switch source := from.(type) {
 	case *v1.Task:
 		t.ObjectMeta = source.ObjectMeta
-		return t.Spec.ConvertFrom(ctx, &source.Spec)
+		deprecated := &taskSpecDeprecated{}
+		if err := version.DeserializeFromMetadata(&t.ObjectMeta, deprecated, deprecatedFieldsAnnotationKey); err != nil {
+			return err
+		}
+		t.Spec.convertFrom(ctx, &source.Spec, deprecated)
+		return nil
 	default:
 		return fmt.Errorf("unknown version, got: %T", t)
 	}
@@ -99,16 +143,32 @@ func (t *Task) ConvertFrom(ctx context.Context, from apis.Convertible) error {
 
 // ConvertFrom implements apis.Convertible
 func (ts *TaskSpec) ConvertFrom(ctx context.Context, source *v1.TaskSpec) error {
+	ts.convertFrom(ctx, source, nil)
+	return nil
+}
+
+// convertFrom converts the TaskSpec from v1, restoring the deprecated fields
+// if any were preserved during the conversion to v1.
+func (ts *TaskSpec) convertFrom(ctx context.Context, source *v1.TaskSpec, deprecated *taskSpecDeprecated) {
+	if deprecated == nil {
+		deprecated = &taskSpecDeprecated{}
+	}
+	ts.Resources = deprecated.Resources
 	ts.Steps = nil
-	for _, s := range source.Steps {
+	for i, s := range source.Steps {
 		new := Step{}
 		new.convertFrom(ctx, s)
+		if i < len(deprecated.Steps) {
+			new.restoreDeprecated(deprecated.Steps[i])
+		}
 		ts.Steps = append(ts.Steps, new)
 	}
 	ts.Volumes = source.Volumes
+	ts.StepTemplate = nil
 	if source.StepTemplate != nil {
 		new := StepTemplate{}
 		new.convertFrom(ctx, source.StepTemplate)
+		new.restoreDeprecated(deprecated.StepTemplate)
 		ts.StepTemplate = &new
 	}
 	ts.Sidecars = nil
@@ -136,5 +196,4 @@ func (ts *TaskSpec) ConvertFrom(ctx context.Context, source *v1.TaskSpec) error
 		ts.Params = append(ts.Params, new)
 	}
 	ts.Description = source.Description
-	return nil
 }
@@ -187,14 +187,10 @@ func TestTaskConversion(t *testing.T) {
 }
 
 func TestTaskConversionFromDeprecated(t *testing.T) {
-	// TODO(#4546): We're just dropping Resources when converting from
-	// v1beta1 to v1. Before moving the stored version to v1, we should
-	// come up with a better strategy
 	versions := []apis.Convertible{&v1.Task{}}
 	tests := []struct {
 		name string
 		in   *v1beta1.Task
-		want *v1beta1.Task
 	}{{
 		name: "input resources",
 		in: &v1beta1.Task{
@@ -205,39 +201,64 @@ func TestTaskConversionFromDeprecated(t *testing.T) {
 			},
 			Spec: v1beta1.TaskSpec{
 				Resources: &v1beta1.TaskResources{
-					Inputs: []v1beta1.TaskResource{},
+					Inputs: []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{
+						Name: "git-repo",
+						Type: v1beta1.PipelineResourceTypeGit,
+					}}},
 				},
 			},
 		},
-		want: &v1beta1.Task{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:       "foo",
-				Namespace:  "bar",
-				Generation: 1,
-			},
-			Spec: v1beta1.TaskSpec{},
-		},
 	}, {
 		name: "output resources",
 		in: &v1beta1.Task{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:       "foo",
-				Namespace:  "bar",
-				Generation: 1,
+				Name:        "foo",
+				Namespace:   "bar",
+				Generation:  1,
+				Annotations: map[string]string{"foo": "bar"},
 			},
 			Spec: v1beta1.TaskSpec{
 				Resources: &v1beta1.TaskResources{
-					Outputs: []v1beta1.TaskResource{},
+					Outputs: []v1beta1.TaskResource{{ResourceDeclaration: v1beta1.ResourceDeclaration{
+						Name: "image",
+						Type: v1beta1.PipelineResourceTypeImage,
+					}}},
 				},
 			},
 		},
-		want: &v1beta1.Task{
+	}, {
+		name: "deprecated step and step template fields",
+		in: &v1beta1.Task{
 			ObjectMeta: metav1.ObjectMeta{
 				Name:       "foo",
 				Namespace:  "bar",
 				Generation: 1,
 			},
-			Spec: v1beta1.TaskSpec{},
+			Spec: v1beta1.TaskSpec{
+				Steps: []v1beta1.Step{{
+					Name:  "no-deprecated-fields",
+					Image: "foo",
+				}, {
+					Name:                               "deprecated-fields",
+					Image:                              "foo",
+					DeprecatedPorts:                    []corev1.ContainerPort{{ContainerPort: 8080}},
+					DeprecatedLivenessProbe:            &corev1.Probe{InitialDelaySeconds: 1},
+					DeprecatedReadinessProbe:           &corev1.Probe{InitialDelaySeconds: 2},
+					DeprecatedStartupProbe:             &corev1.Probe{InitialDelaySeconds: 3},
+					DeprecatedLifecycle:                &corev1.Lifecycle{PreStop: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"stop"}}}},
+					DeprecatedTerminationMessagePath:   "/path",
+					DeprecatedTerminationMessagePolicy: corev1.TerminationMessageReadFile,
+					DeprecatedStdin:                    true,
+					DeprecatedStdinOnce:                true,
+					DeprecatedTTY:                      true,
+				}},
+				StepTemplate: &v1beta1.StepTemplate{
+					DeprecatedName:  "template",
+					Image:           "foo",
+					DeprecatedPorts: []corev1.ContainerPort{{ContainerPort: 8080}},
+					DeprecatedTTY:   true,
+				},
+			},
 		},
 	}}
 	for _, test := range tests {
@@ -253,7 +274,7 @@ func TestTaskConversionFromDeprecated(t *testing.T) {
 					t.Errorf("ConvertFrom() = %v", err)
 				}
 				t.Logf("ConvertFrom() = %#v", got)
-				if d := cmp.Diff(test.want, got); d != "" {
+				if d := cmp.Diff(test.in, got); d != "" {
 					t.Errorf("roundtrip %s", diff.PrintWantGot(d))
 				}
 			})
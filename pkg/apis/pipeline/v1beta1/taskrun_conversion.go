@@ -20,23 +20,287 @@ import (
 	"context"
 	"fmt"
 
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/version"
 	"knative.dev/pkg/apis"
 )
 
+// taskRunDeprecated holds the fields of a TaskRun which have been removed in v1.
+// +k8s:openapi-gen=false
+type taskRunDeprecated struct {
+	Resources *TaskRunResources        `json:"resources,omitempty"`
+	TaskSpec  *taskSpecDeprecated      `json:"taskSpec,omitempty"`
+	Status    *taskRunStatusDeprecated `json:"status,omitempty"`
+}
+
+// taskRunStatusDeprecated holds the fields of a TaskRunStatus which have been
+// removed in v1. RetriesStatus is index-aligned with the status' RetriesStatus.
+// +k8s:openapi-gen=false
+type taskRunStatusDeprecated struct {
+	CloudEvents     []CloudEventDelivery       `json:"cloudEvents,omitempty"`
+	ResourcesResult []PipelineResourceResult   `json:"resourcesResult,omitempty"`
+	TaskSpec        *taskSpecDeprecated        `json:"taskSpec,omitempty"`
+	RetriesStatus   []*taskRunStatusDeprecated `json:"retriesStatus,omitempty"`
+}
+
 var _ apis.Convertible = (*TaskRun)(nil)
 
-// ConvertTo implements api.Convertible
-func (tr *TaskRun) ConvertTo(ctx context.Context, sink apis.Convertible) error {
+// ConvertTo implements apis.Convertible
+func (tr *TaskRun) ConvertTo(ctx context.Context, to apis.Convertible) error {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
-	return fmt.Errorf("v1beta1 is the highest known version, got: %T", sink)
+	switch sink := to.(type) {
+	case *v1.TaskRun:
+		sink.ObjectMeta = tr.ObjectMeta
+		deprecated := &taskRunDeprecated{
+			Resources: tr.Spec.Resources,
+			TaskSpec:  tr.Spec.convertTo(ctx, &sink.Spec),
+			Status:    tr.Status.convertTo(ctx, &sink.Status),
+		}
+		if deprecated.Resources != nil || deprecated.TaskSpec != nil || deprecated.Status != nil {
+			return version.SerializeToMetadata(&sink.ObjectMeta, deprecated, deprecatedFieldsAnnotationKey)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", sink)
+	}
+}
+
+// convertTo converts the TaskRunSpec to v1 and returns the fields of its
+// TaskSpec which could not be represented in v1, or nil if there are none.
+// Resources are not converted.
+func (trs *TaskRunSpec) convertTo(ctx context.Context, sink *v1.TaskRunSpec) *taskSpecDeprecated {
+	var deprecated *taskSpecDeprecated
+	sink.Debug = (*v1.TaskRunDebug)(trs.Debug)
+	sink.Params = nil
+	for _, p := range trs.Params {
+		new := v1.Param{}
+		p.convertTo(ctx, &new)
+		sink.Params = append(sink.Params, new)
+	}
+	sink.ServiceAccountName = trs.ServiceAccountName
+	sink.TaskRef = nil
+	if trs.TaskRef != nil {
+		sink.TaskRef = &v1.TaskRef{}
+		trs.TaskRef.convertTo(ctx, sink.TaskRef)
+	}
+	sink.TaskSpec = nil
+	if trs.TaskSpec != nil {
+		sink.TaskSpec = &v1.TaskSpec{}
+		deprecated = trs.TaskSpec.convertTo(ctx, sink.TaskSpec)
+	}
+	sink.Status = v1.TaskRunSpecStatus(trs.Status)
+	sink.Timeout = trs.Timeout
+	sink.PodTemplate = trs.PodTemplate
+	sink.Workspaces = nil
+	for _, w := range trs.Workspaces {
+		new := v1.WorkspaceBinding{}
+		w.convertTo(ctx, &new)
+		sink.Workspaces = append(sink.Workspaces, new)
+	}
+	sink.StepOverrides = nil
+	for _, o := range trs.StepOverrides {
+		sink.StepOverrides = append(sink.StepOverrides, v1.TaskRunStepOverride(o))
+	}
+	sink.SidecarOverrides = nil
+	for _, o := range trs.SidecarOverrides {
+		sink.SidecarOverrides = append(sink.SidecarOverrides, v1.TaskRunSidecarOverride(o))
+	}
+	sink.ComputeResources = trs.ComputeResources
+	sink.TTLSecondsAfterFinished = trs.TTLSecondsAfterFinished
+	return deprecated
+}
+
+// convertTo converts the TaskRunStatus to v1 and returns the fields which could
+// not be represented in v1, or nil if there are none.
+func (trs *TaskRunStatus) convertTo(ctx context.Context, sink *v1.TaskRunStatus) *taskRunStatusDeprecated {
+	deprecated := &taskRunStatusDeprecated{
+		CloudEvents:     trs.CloudEvents,
+		ResourcesResult: trs.ResourcesResult,
+	}
+	sink.Status = trs.Status
+	sink.PodName = trs.PodName
+	sink.StartTime = trs.StartTime
+	sink.CompletionTime = trs.CompletionTime
+	sink.Steps = nil
+	for _, s := range trs.Steps {
+		new := v1.StepState{}
+		s.convertTo(ctx, &new)
+		sink.Steps = append(sink.Steps, new)
+	}
+	sink.RetriesStatus = nil
+	hasDeprecatedRetries := false
+	for _, r := range trs.RetriesStatus {
+		new := v1.TaskRunStatus{}
+		d := r.convertTo(ctx, &new)
+		hasDeprecatedRetries = hasDeprecatedRetries || d != nil
+		deprecated.RetriesStatus = append(deprecated.RetriesStatus, d)
+		sink.RetriesStatus = append(sink.RetriesStatus, new)
+	}
+	if !hasDeprecatedRetries {
+		deprecated.RetriesStatus = nil
+	}
+	sink.Results = nil
+	for _, r := range trs.TaskRunResults {
+		new := v1.TaskRunResult{}
+		r.convertTo(ctx, &new)
+		sink.Results = append(sink.Results, new)
+	}
+	sink.Sidecars = nil
+	for _, s := range trs.Sidecars {
+		new := v1.SidecarState{}
+		s.convertTo(ctx, &new)
+		sink.Sidecars = append(sink.Sidecars, new)
+	}
+	sink.TaskSpec = nil
+	if trs.TaskSpec != nil {
+		sink.TaskSpec = &v1.TaskSpec{}
+		deprecated.TaskSpec = trs.TaskSpec.convertTo(ctx, sink.TaskSpec)
+	}
+	sink.ResourceUsage = (*v1.ResourceUsage)(trs.ResourceUsage)
+	if deprecated.CloudEvents == nil && deprecated.ResourcesResult == nil &&
+		deprecated.TaskSpec == nil && deprecated.RetriesStatus == nil {
+		return nil
+	}
+	return deprecated
 }
 
-// ConvertFrom implements api.Convertible
-func (tr *TaskRun) ConvertFrom(ctx context.Context, source apis.Convertible) error {
+func (ss StepState) convertTo(ctx context.Context, sink *v1.StepState) {
+	sink.ContainerState = ss.ContainerState
+	sink.Name = ss.Name
+	sink.Container = ss.ContainerName
+	sink.ImageID = ss.ImageID
+	sink.Resources = ss.Resources
+	sink.LogLocation = ss.LogLocation
+}
+
+func (ss SidecarState) convertTo(ctx context.Context, sink *v1.SidecarState) {
+	sink.ContainerState = ss.ContainerState
+	sink.Name = ss.Name
+	sink.Container = ss.ContainerName
+	sink.ImageID = ss.ImageID
+}
+
+// ConvertFrom implements apis.Convertible
+func (tr *TaskRun) ConvertFrom(ctx context.Context, from apis.Convertible) error {
 	if apis.IsInDelete(ctx) {
 		return nil
 	}
-	return fmt.Errorf("v1beta1 is the highest know version, got: %T", source)
+	switch source := from.(type) {
+	case *v1.TaskRun:
+		tr.ObjectMeta = source.ObjectMeta
+		deprecated := &taskRunDeprecated{}
+		if err := version.DeserializeFromMetadata(&tr.ObjectMeta, deprecated, deprecatedFieldsAnnotationKey); err != nil {
+			return err
+		}
+		tr.Spec.convertFrom(ctx, &source.Spec, deprecated.TaskSpec)
+		tr.Spec.Resources = deprecated.Resources
+		tr.Status.convertFrom(ctx, &source.Status, deprecated.Status)
+		return nil
+	default:
+		return fmt.Errorf("unknown version, got: %T", source)
+	}
+}
+
+func (trs *TaskRunSpec) convertFrom(ctx context.Context, source *v1.TaskRunSpec, deprecated *taskSpecDeprecated) {
+	trs.Debug = (*TaskRunDebug)(source.Debug)
+	trs.Params = nil
+	for _, p := range source.Params {
+		new := Param{}
+		new.convertFrom(ctx, p)
+		trs.Params = append(trs.Params, new)
+	}
+	trs.ServiceAccountName = source.ServiceAccountName
+	trs.TaskRef = nil
+	if source.TaskRef != nil {
+		trs.TaskRef = &TaskRef{}
+		trs.TaskRef.convertFrom(ctx, *source.TaskRef)
+	}
+	trs.TaskSpec = nil
+	if source.TaskSpec != nil {
+		trs.TaskSpec = &TaskSpec{}
+		trs.TaskSpec.convertFrom(ctx, source.TaskSpec, deprecated)
+	}
+	trs.Status = TaskRunSpecStatus(source.Status)
+	trs.Timeout = source.Timeout
+	trs.PodTemplate = source.PodTemplate
+	trs.Workspaces = nil
+	for _, w := range source.Workspaces {
+		new := WorkspaceBinding{}
+		new.convertFrom(ctx, w)
+		trs.Workspaces = append(trs.Workspaces, new)
+	}
+	trs.StepOverrides = nil
+	for _, o := range source.StepOverrides {
+		trs.StepOverrides = append(trs.StepOverrides, TaskRunStepOverride(o))
+	}
+	trs.SidecarOverrides = nil
+	for _, o := range source.SidecarOverrides {
+		trs.SidecarOverrides = append(trs.SidecarOverrides, TaskRunSidecarOverride(o))
+	}
+	trs.ComputeResources = source.ComputeResources
+	trs.TTLSecondsAfterFinished = source.TTLSecondsAfterFinished
+}
+
+func (trs *TaskRunStatus) convertFrom(ctx context.Context, source *v1.TaskRunStatus, deprecated *taskRunStatusDeprecated) {
+	if deprecated == nil {
+		deprecated = &taskRunStatusDeprecated{}
+	}
+	trs.Status = source.Status
+	trs.PodName = source.PodName
+	trs.StartTime = source.StartTime
+	trs.CompletionTime = source.CompletionTime
+	trs.Steps = nil
+	for _, s := range source.Steps {
+		new := StepState{}
+		new.convertFrom(ctx, s)
+		trs.Steps = append(trs.Steps, new)
+	}
+	trs.CloudEvents = deprecated.CloudEvents
+	trs.RetriesStatus = nil
+	for i, r := range source.RetriesStatus {
+		var d *taskRunStatusDeprecated
+		if i < len(deprecated.RetriesStatus) {
+			d = deprecated.RetriesStatus[i]
+		}
+		new := TaskRunStatus{}
+		new.convertFrom(ctx, &r, d)
+		trs.RetriesStatus = append(trs.RetriesStatus, new)
+	}
+	trs.ResourcesResult = deprecated.ResourcesResult
+	trs.TaskRunResults = nil
+	for _, r := range source.Results {
+		new := TaskRunResult{}
+		new.convertFrom(ctx, r)
+		trs.TaskRunResults = append(trs.TaskRunResults, new)
+	}
+	trs.Sidecars = nil
+	for _, s := range source.Sidecars {
+		new := SidecarState{}
+		new.convertFrom(ctx, s)
+		trs.Sidecars = append(trs.Sidecars, new)
+	}
+	trs.TaskSpec = nil
+	if source.TaskSpec != nil {
+		trs.TaskSpec = &TaskSpec{}
+		trs.TaskSpec.convertFrom(ctx, source.TaskSpec, deprecated.TaskSpec)
+	}
+	trs.ResourceUsage = (*ResourceUsage)(source.ResourceUsage)
+}
+
+func (ss *StepState) convertFrom(ctx context.Context, source v1.StepState) {
+	ss.ContainerState = source.ContainerState
+	ss.Name = source.Name
+	ss.ContainerName = source.Container
+	ss.ImageID = source.ImageID
+	ss.Resources = source.Resources
+	ss.LogLocation = source.LogLocation
+}
+
+func (ss *SidecarState) convertFrom(ctx context.Context, source v1.SidecarState) {
+	ss.ContainerState = source.ContainerState
+	ss.Name = source.Name
+	ss.ContainerName = source.Container
+	ss.ImageID = source.ImageID
 }
@@ -19,6 +19,17 @@ package v1beta1
 import (
 	"context"
 	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
+	v1 "github.com/tektoncd/pipeline/pkg/apis/pipeline/v1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 func TestTaskRunConversionBadType(t *testing.T) {
@@ -32,3 +43,202 @@ func TestTaskRunConversionBadType(t *testing.T) {
 		t.Errorf("ConvertFrom() = %#v, wanted error", good)
 	}
 }
+
+func TestTaskRunConversion(t *testing.T) {
+	versions := []apis.Convertible{&v1.TaskRun{}}
+	ttl := int32(3600)
+
+	tests := []struct {
+		name string
+		in   *TaskRun
+	}{{
+		name: "simple taskrun",
+		in: &TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "foo",
+				Namespace:  "bar",
+				Generation: 1,
+			},
+			Spec: TaskRunSpec{
+				TaskRef: &TaskRef{Name: "task"},
+			},
+		},
+	}, {
+		name: "taskrun conversion all non deprecated fields",
+		in: &TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "foo",
+				Namespace:  "bar",
+				Generation: 1,
+			},
+			Spec: TaskRunSpec{
+				Debug: &TaskRunDebug{Breakpoint: []string{"onFailure"}},
+				Params: []Param{{
+					Name:  "param-1",
+					Value: *NewArrayOrString("value"),
+				}},
+				ServiceAccountName: "sa",
+				TaskSpec: &TaskSpec{
+					Steps: []Step{{
+						Name:  "step",
+						Image: "foo",
+					}},
+				},
+				Status:                  TaskRunSpecStatusCancelled,
+				Timeout:                 &metav1.Duration{Duration: time.Hour},
+				TTLSecondsAfterFinished: &ttl,
+				PodTemplate:             &pod.Template{NodeSelector: map[string]string{"foo": "bar"}},
+				Workspaces: []WorkspaceBinding{{
+					Name:     "workspace",
+					SubPath:  "dir",
+					EmptyDir: &corev1.EmptyDirVolumeSource{},
+				}, {
+					Name: "cache",
+					Cache: &CacheWorkspaceBinding{
+						Key: "key",
+					},
+				}},
+				StepOverrides: []TaskRunStepOverride{{
+					Name: "step",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				}},
+				SidecarOverrides: []TaskRunSidecarOverride{{
+					Name: "sidecar",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+				}},
+			},
+			Status: TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					}},
+				},
+				TaskRunStatusFields: TaskRunStatusFields{
+					PodName: "pod",
+					Steps: []StepState{{
+						ContainerState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+						},
+						Name:          "step",
+						ContainerName: "step-step",
+						ImageID:       "image-id",
+						LogLocation:   "s3://tekton-logs/foo/taskrun-1234/step.log",
+					}},
+					RetriesStatus: []TaskRunStatus{{
+						TaskRunStatusFields: TaskRunStatusFields{PodName: "retry-pod"},
+					}},
+					TaskRunResults: []TaskRunResult{{
+						Name:  "result",
+						Type:  ResultsTypeString,
+						Value: *NewArrayOrString("value"),
+					}},
+					Sidecars: []SidecarState{{
+						Name:          "sidecar",
+						ContainerName: "sidecar-sidecar",
+						ImageID:       "image-id",
+					}},
+					TaskSpec: &TaskSpec{
+						Steps: []Step{{
+							Name:  "step",
+							Image: "foo",
+						}},
+					},
+					ResourceUsage: &ResourceUsage{
+						Duration: &metav1.Duration{Duration: time.Minute},
+					},
+				},
+			},
+		},
+	}, {
+		name: "taskrun with deprecated fields",
+		in: &TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "foo",
+				Namespace:   "bar",
+				Generation:  1,
+				Annotations: map[string]string{"foo": "bar"},
+			},
+			Spec: TaskRunSpec{
+				Resources: &TaskRunResources{
+					Inputs: []TaskResourceBinding{{
+						PipelineResourceBinding: PipelineResourceBinding{
+							Name:        "source",
+							ResourceRef: &PipelineResourceRef{Name: "git-repo"},
+						},
+					}},
+				},
+				TaskSpec: &TaskSpec{
+					Steps: []Step{{
+						Name:            "step",
+						Image:           "foo",
+						DeprecatedStdin: true,
+						DeprecatedTTY:   true,
+						DeprecatedPorts: []corev1.ContainerPort{{ContainerPort: 8080}},
+					}},
+				},
+			},
+			Status: TaskRunStatus{
+				TaskRunStatusFields: TaskRunStatusFields{
+					PodName: "pod",
+					CloudEvents: []CloudEventDelivery{{
+						Target: "http://sink",
+						Status: CloudEventDeliveryState{
+							Condition:  CloudEventConditionSent,
+							RetryCount: 1,
+						},
+					}},
+					RetriesStatus: []TaskRunStatus{{
+						TaskRunStatusFields: TaskRunStatusFields{PodName: "retry-pod"},
+					}, {
+						TaskRunStatusFields: TaskRunStatusFields{
+							PodName: "retry-pod-2",
+							ResourcesResult: []PipelineResourceResult{{
+								Key:   "digest",
+								Value: "sha256:abc",
+							}},
+						},
+					}},
+					ResourcesResult: []PipelineResourceResult{{
+						Key:          "commit",
+						Value:        "abc",
+						ResourceName: "source",
+					}},
+					TaskSpec: &TaskSpec{
+						Resources: &TaskResources{
+							Inputs: []TaskResource{{ResourceDeclaration: ResourceDeclaration{
+								Name: "source",
+								Type: PipelineResourceTypeGit,
+							}}},
+						},
+					},
+				},
+			},
+		},
+	}}
+
+	for _, test := range tests {
+		for _, version := range versions {
+			t.Run(test.name, func(t *testing.T) {
+				ver := version
+				if err := test.in.ConvertTo(context.Background(), ver); err != nil {
+					t.Errorf("ConvertTo() = %v", err)
+					return
+				}
+				t.Logf("ConvertTo() = %#v", ver)
+				got := &TaskRun{}
+				if err := got.ConvertFrom(context.Background(), ver); err != nil {
+					t.Errorf("ConvertFrom() = %v", err)
+				}
+				t.Logf("ConvertFrom() = %#v", got)
+				if d := cmp.Diff(test.in, got); d != "" {
+					t.Errorf("roundtrip %s", diff.PrintWantGot(d))
+				}
+			})
+		}
+	}
+}
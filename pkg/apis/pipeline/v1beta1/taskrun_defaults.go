@@ -65,6 +65,15 @@ func (trs *TaskRunSpec) SetDefaults(ctx context.Context) {
 	defaultPodTemplate := cfg.Defaults.DefaultPodTemplate
 	trs.PodTemplate = MergePodTemplateWithDefault(trs.PodTemplate, defaultPodTemplate)
 
+	// ttlSecondsAfterFinished is only supported when the alpha feature gate is enabled.
+	if trs.TTLSecondsAfterFinished == nil && cfg.Defaults.DefaultTTLSecondsAfterFinished != config.NoTTLSecondsAfterFinished &&
+		cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields {
+		ttl := int32(cfg.Defaults.DefaultTTLSecondsAfterFinished)
+		trs.TTLSecondsAfterFinished = &ttl
+	}
+
+	setVolumeClaimTemplateDefaults(ctx, trs.Workspaces)
+
 	// If this taskrun has an embedded task, apply the usual task defaults
 	if trs.TaskSpec != nil {
 		trs.TaskSpec.SetDefaults(ctx)
@@ -5,7 +5,7 @@ Licensed under the Apache License, Version 2.0 (the "License");
 you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-    http://www.apache.org/licenses/LICENSE-2.0
+	http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -131,6 +131,7 @@ func TestTaskRunSpec_SetDefaults(t *testing.T) {
 }
 
 func TestTaskRunDefaulting(t *testing.T) {
+	ttl := int32(3600)
 	tests := []struct {
 		name string
 		in   *v1beta1.TaskRun
@@ -224,6 +225,75 @@ func TestTaskRunDefaulting(t *testing.T) {
 			})
 			return s.ToContext(ctx)
 		},
+	}, {
+		name: "TaskRef default config context with ttlSecondsAfterFinished",
+		in: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "foo"},
+			},
+		},
+		want: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "tekton-pipelines"},
+			},
+			Spec: v1beta1.TaskRunSpec{
+				ServiceAccountName:      config.DefaultServiceAccountValue,
+				TaskRef:                 &v1beta1.TaskRef{Name: "foo", Kind: v1beta1.NamespacedTaskKind},
+				Timeout:                 &metav1.Duration{Duration: 5 * time.Minute},
+				TTLSecondsAfterFinished: &ttl,
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			s := config.NewStore(logtesting.TestLogger(t))
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.GetDefaultsConfigName(),
+				},
+				Data: map[string]string{
+					"default-timeout-minutes":            "5",
+					"default-ttl-seconds-after-finished": "3600",
+				},
+			})
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.GetFeatureFlagsConfigName(),
+				},
+				Data: map[string]string{
+					"enable-api-fields": "alpha",
+				},
+			})
+			return s.ToContext(ctx)
+		},
+	}, {
+		name: "TaskRef default config context with ttlSecondsAfterFinished without alpha feature gate",
+		in: &v1beta1.TaskRun{
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "foo"},
+			},
+		},
+		want: &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{
+				Labels: map[string]string{"app.kubernetes.io/managed-by": "tekton-pipelines"},
+			},
+			Spec: v1beta1.TaskRunSpec{
+				ServiceAccountName: config.DefaultServiceAccountValue,
+				TaskRef:            &v1beta1.TaskRef{Name: "foo", Kind: v1beta1.NamespacedTaskKind},
+				Timeout:            &metav1.Duration{Duration: 5 * time.Minute},
+			},
+		},
+		wc: func(ctx context.Context) context.Context {
+			s := config.NewStore(logtesting.TestLogger(t))
+			s.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: config.GetDefaultsConfigName(),
+				},
+				Data: map[string]string{
+					"default-timeout-minutes":            "5",
+					"default-ttl-seconds-after-finished": "3600",
+				},
+			})
+			return s.ToContext(ctx)
+		},
 	}, {
 		name: "TaskRun managed-by set in config",
 		in: &v1beta1.TaskRun{
@@ -79,6 +79,12 @@ type TaskRunSpec struct {
 	SidecarOverrides []TaskRunSidecarOverride `json:"sidecarOverrides,omitempty"`
 	// Compute resources to use for this TaskRun
 	ComputeResources *corev1.ResourceRequirements `json:"computeResources,omitempty"`
+	// TTLSecondsAfterFinished limits the lifetime of a TaskRun that has finished
+	// execution. The TaskRun is deleted by the controller once this many seconds
+	// have elapsed since its completion, unless it is controlled by another resource
+	// such as a PipelineRun. Defaults to never.
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 }
 
 // TaskRunSpecStatus defines the taskrun spec status the user can provide
@@ -240,6 +246,11 @@ type TaskRunStatusFields struct {
 
 	// TaskSpec contains the Spec from the dereferenced Task definition used to instantiate this TaskRun.
 	TaskSpec *TaskSpec `json:"taskSpec,omitempty"`
+
+	// ResourceUsage describes the compute resources reserved by the TaskRun Pod.
+	// It is set once the TaskRun completes.
+	// +optional
+	ResourceUsage *ResourceUsage `json:"resourceUsage,omitempty"`
 }
 
 // TaskRunStepOverride is used to override the values of a Step in the corresponding Task.
@@ -305,6 +316,14 @@ type StepState struct {
 	Name                  string `json:"name,omitempty"`
 	ContainerName         string `json:"container,omitempty"`
 	ImageID               string `json:"imageID,omitempty"`
+	// Resources are the effective compute resource requirements of the step
+	// container, after overrides and LimitRange defaults have been applied.
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// LogLocation is the URL the logs of the step were uploaded to, when the
+	// upload of step logs is configured in config-step-logs.
+	// +optional
+	LogLocation string `json:"logLocation,omitempty"`
 }
 
 // SidecarState reports the results of running a sidecar in a Task.
@@ -468,10 +487,11 @@ func (tr *TaskRun) IsPartOfPipeline() (bool, string, string) {
 }
 
 // HasVolumeClaimTemplate returns true if TaskRun contains volumeClaimTemplates that is
-// used for creating PersistentVolumeClaims with an OwnerReference for each run
+// used for creating PersistentVolumeClaims with an OwnerReference for each run, or
+// cache workspaces whose PersistentVolumeClaims are created from a template
 func (tr *TaskRun) HasVolumeClaimTemplate() bool {
 	for _, ws := range tr.Spec.Workspaces {
-		if ws.VolumeClaimTemplate != nil {
+		if ws.VolumeClaimTemplate != nil || ws.Cache != nil {
 			return true
 		}
 	}
@@ -89,6 +89,12 @@ func (ts *TaskRunSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%s should be >= 0", ts.Timeout.Duration.String()), "timeout"))
 		}
 	}
+	if ts.TTLSecondsAfterFinished != nil {
+		errs = errs.Also(version.ValidateEnabledAPIFields(ctx, "ttlSecondsAfterFinished", config.AlphaAPIFields).ViaField("ttlSecondsAfterFinished"))
+		if *ts.TTLSecondsAfterFinished < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(fmt.Sprintf("%d should be >= 0", *ts.TTLSecondsAfterFinished), "ttlSecondsAfterFinished"))
+		}
+	}
 
 	return errs
 }
@@ -148,6 +148,8 @@ func TestTaskRun_Workspaces_Invalid(t *testing.T) {
 }
 
 func TestTaskRunSpec_Invalidate(t *testing.T) {
+	negativeTTL := int32(-1)
+	ttl := int32(3600)
 	tests := []struct {
 		name    string
 		spec    v1beta1.TaskRunSpec
@@ -180,6 +182,25 @@ func TestTaskRunSpec_Invalidate(t *testing.T) {
 			Timeout: &metav1.Duration{Duration: -48 * time.Hour},
 		},
 		wantErr: apis.ErrInvalidValue("-48h0m0s should be >= 0", "timeout"),
+	}, {
+		name: "negative ttlSecondsAfterFinished",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "taskrefname",
+			},
+			TTLSecondsAfterFinished: &negativeTTL,
+		},
+		wantErr: apis.ErrInvalidValue("-1 should be >= 0", "ttlSecondsAfterFinished"),
+		wc:      config.EnableAlphaAPIFields,
+	}, {
+		name: "ttlSecondsAfterFinished without alpha feature gate",
+		spec: v1beta1.TaskRunSpec{
+			TaskRef: &v1beta1.TaskRef{
+				Name: "taskrefname",
+			},
+			TTLSecondsAfterFinished: &ttl,
+		},
+		wantErr: apis.ErrGeneric("ttlSecondsAfterFinished requires \"enable-api-fields\" feature gate to be \"alpha\" but it is \"stable\"").ViaField("ttlSecondsAfterFinished"),
 	}, {
 		name: "wrong taskrun cancel",
 		spec: v1beta1.TaskRunSpec{
@@ -0,0 +1,70 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ResourceUsage describes the compute resources reserved by a run, for accounting
+// purposes. Usage is computed from the effective resource requests of the TaskRun
+// Pods, after LimitRange defaults have been applied, and their wall-clock runtime.
+type ResourceUsage struct {
+	// Requests are the effective compute resource requests of the TaskRun Pod.
+	// Only set on TaskRuns.
+	// +optional
+	Requests corev1.ResourceList `json:"requests,omitempty"`
+
+	// Duration is the wall-clock time during which the TaskRun Pod reserved its
+	// requests, from the Pod start until the TaskRun completion.
+	// Only set on TaskRuns.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// CPUSeconds is the requested CPU multiplied by the runtime in seconds.
+	// +optional
+	CPUSeconds *resource.Quantity `json:"cpuSeconds,omitempty"`
+
+	// MemoryGBSeconds is the requested memory in gigabytes (10^9 bytes)
+	// multiplied by the runtime in seconds.
+	// +optional
+	MemoryGBSeconds *resource.Quantity `json:"memoryGBSeconds,omitempty"`
+}
+
+// Add adds the CPU-seconds and memory-GB-seconds of other to the ResourceUsage.
+func (u *ResourceUsage) Add(other *ResourceUsage) {
+	if other == nil {
+		return
+	}
+	u.CPUSeconds = addQuantity(u.CPUSeconds, other.CPUSeconds)
+	u.MemoryGBSeconds = addQuantity(u.MemoryGBSeconds, other.MemoryGBSeconds)
+}
+
+func addQuantity(a, b *resource.Quantity) *resource.Quantity {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		c := b.DeepCopy()
+		return &c
+	}
+	sum := a.DeepCopy()
+	sum.Add(*b)
+	return &sum
+}
@@ -31,3 +31,59 @@ func (w *WorkspaceUsage) convertFrom(ctx context.Context, source v1.WorkspaceUsa
 	w.Name = source.Name
 	w.MountPath = source.MountPath
 }
+
+func (w PipelineWorkspaceDeclaration) convertTo(ctx context.Context, sink *v1.PipelineWorkspaceDeclaration) {
+	sink.Name = w.Name
+	sink.Description = w.Description
+	sink.Optional = w.Optional
+}
+
+func (w *PipelineWorkspaceDeclaration) convertFrom(ctx context.Context, source v1.PipelineWorkspaceDeclaration) {
+	w.Name = source.Name
+	w.Description = source.Description
+	w.Optional = source.Optional
+}
+
+func (w WorkspacePipelineTaskBinding) convertTo(ctx context.Context, sink *v1.WorkspacePipelineTaskBinding) {
+	sink.Name = w.Name
+	sink.Workspace = w.Workspace
+	sink.SubPath = w.SubPath
+	sink.From = w.From
+	sink.Access = v1.WorkspaceAccess(w.Access)
+}
+
+func (w *WorkspacePipelineTaskBinding) convertFrom(ctx context.Context, source v1.WorkspacePipelineTaskBinding) {
+	w.Name = source.Name
+	w.Workspace = source.Workspace
+	w.SubPath = source.SubPath
+	w.From = source.From
+	w.Access = WorkspaceAccess(source.Access)
+}
+
+func (w WorkspaceBinding) convertTo(ctx context.Context, sink *v1.WorkspaceBinding) {
+	sink.Name = w.Name
+	sink.SubPath = w.SubPath
+	sink.ReadOnly = w.ReadOnly
+	sink.VolumeClaimTemplate = w.VolumeClaimTemplate
+	sink.PersistentVolumeClaim = w.PersistentVolumeClaim
+	sink.EmptyDir = w.EmptyDir
+	sink.ConfigMap = w.ConfigMap
+	sink.Secret = w.Secret
+	sink.Projected = w.Projected
+	sink.CSI = w.CSI
+	sink.Cache = (*v1.CacheWorkspaceBinding)(w.Cache)
+}
+
+func (w *WorkspaceBinding) convertFrom(ctx context.Context, source v1.WorkspaceBinding) {
+	w.Name = source.Name
+	w.SubPath = source.SubPath
+	w.ReadOnly = source.ReadOnly
+	w.VolumeClaimTemplate = source.VolumeClaimTemplate
+	w.PersistentVolumeClaim = source.PersistentVolumeClaim
+	w.EmptyDir = source.EmptyDir
+	w.ConfigMap = source.ConfigMap
+	w.Secret = source.Secret
+	w.Projected = source.Projected
+	w.CSI = source.CSI
+	w.Cache = (*CacheWorkspaceBinding)(source.Cache)
+}
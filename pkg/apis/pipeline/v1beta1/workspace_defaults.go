@@ -0,0 +1,43 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"knative.dev/pkg/apis"
+)
+
+// setVolumeClaimTemplateDefaults applies the defaults of the VolumeClaimTemplatePolicy configured
+// for the namespace of the run to the volumeClaimTemplates of its workspaces, including those of
+// its cache workspaces.
+func setVolumeClaimTemplateDefaults(ctx context.Context, wb []WorkspaceBinding) {
+	policies := config.FromContextOrDefaults(ctx).Defaults.DefaultVolumeClaimTemplatePolicies
+	if policies == nil {
+		return
+	}
+	policy := policies.ForNamespace(apis.ParentMeta(ctx).Namespace)
+	for _, w := range wb {
+		if w.VolumeClaimTemplate != nil {
+			policy.SetDefaults(w.VolumeClaimTemplate)
+		}
+		if w.Cache != nil {
+			policy.SetDefaults(&w.Cache.VolumeClaimTemplate)
+		}
+	}
+}
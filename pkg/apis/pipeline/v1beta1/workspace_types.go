@@ -58,6 +58,10 @@ type WorkspaceBinding struct {
 	// for this binding (i.e. the volume will be mounted at this sub directory).
 	// +optional
 	SubPath string `json:"subPath,omitempty"`
+	// ReadOnly mounts the workspace read-only, even if the Task declares it
+	// writable.
+	// +optional
+	ReadOnly bool `json:"readOnly,omitempty"`
 	// VolumeClaimTemplate is a template for a claim that will be created in the same namespace.
 	// The PipelineRun controller is responsible for creating a unique claim for each instance of PipelineRun.
 	// +optional
@@ -83,6 +87,21 @@ type WorkspaceBinding struct {
 	// CSI (Container Storage Interface) represents ephemeral storage that is handled by certain external CSI drivers.
 	// +optional
 	CSI *corev1.CSIVolumeSource `json:"csi,omitempty"`
+	// Cache represents a claim that is reused by the PipelineRuns and TaskRuns
+	// binding a workspace to the same cache key in the namespace.
+	// +optional
+	Cache *CacheWorkspaceBinding `json:"cache,omitempty"`
+}
+
+// CacheWorkspaceBinding binds a workspace to a PersistentVolumeClaim that outlives
+// the run and is shared by the runs using the same cache key in a namespace.
+type CacheWorkspaceBinding struct {
+	// Key identifies the cache, e.g. the hash of a lockfile. The claim is created
+	// from the VolumeClaimTemplate the first time the key is used, and reused
+	// afterwards until it expires or is evicted by the controller.
+	Key string `json:"key"`
+	// VolumeClaimTemplate is a template for the claim of the cache.
+	VolumeClaimTemplate corev1.PersistentVolumeClaim `json:"volumeClaimTemplate"`
 }
 
 // WorkspacePipelineDeclaration creates a named slot in a Pipeline that a PipelineRun
@@ -117,6 +136,38 @@ type WorkspacePipelineTaskBinding struct {
 	// for this binding (i.e. the volume will be mounted at this sub directory).
 	// +optional
 	SubPath string `json:"subPath,omitempty"`
+	// From is the list of PipelineTask names whose output on this workspace
+	// is consumed by this PipelineTask. (Implies an ordering in the execution graph.)
+	// +optional
+	// +listType=atomic
+	From []string `json:"from,omitempty"`
+	// Access declares how the PipelineTask uses the workspace: readOnly mounts
+	// it read-only, while readWrite marks the PipelineTask as writing to it.
+	// Defaults to the access declared by the Task.
+	// +optional
+	Access WorkspaceAccess `json:"access,omitempty"`
+}
+
+// WorkspaceAccess describes how a PipelineTask uses a workspace.
+type WorkspaceAccess string
+
+const (
+	// WorkspaceAccessReadOnly indicates that the PipelineTask only reads the workspace.
+	WorkspaceAccessReadOnly WorkspaceAccess = "readOnly"
+	// WorkspaceAccessReadWrite indicates that the PipelineTask reads and writes the workspace.
+	WorkspaceAccessReadWrite WorkspaceAccess = "readWrite"
+)
+
+// AllWorkspaceAccesses can be used for WorkspaceAccess validation.
+var AllWorkspaceAccesses = []WorkspaceAccess{WorkspaceAccessReadOnly, WorkspaceAccessReadWrite}
+
+// pipelineWorkspace returns the name of the Pipeline workspace bound by the PipelineTask,
+// which defaults to the name of the Task workspace.
+func (w WorkspacePipelineTaskBinding) pipelineWorkspace() string {
+	if w.Workspace == "" {
+		return w.Name
+	}
+	return w.Workspace
 }
 
 // WorkspaceUsage is used by a Step or Sidecar to declare that it wants isolated access
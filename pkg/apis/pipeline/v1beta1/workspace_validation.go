@@ -68,6 +68,13 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		return apis.ErrMissingField("secret.secretName")
 	}
 
+	// Mounting a workspace read-only is only supported when the alpha feature gate is enabled.
+	if b.ReadOnly {
+		if err := version.ValidateEnabledAPIFields(ctx, "readOnly workspace binding", config.AlphaAPIFields).ViaField("workspace"); err != nil {
+			return err
+		}
+	}
+
 	// The projected workspace is only supported when the alpha feature gate is enabled.
 	// For a Projected volume to work, you must provide at least one source.
 	if b.Projected != nil {
@@ -91,6 +98,17 @@ func (b *WorkspaceBinding) Validate(ctx context.Context) (errs *apis.FieldError)
 		}
 	}
 
+	// The cache workspace is only supported when the alpha feature gate is enabled.
+	// For a cache to work, you must provide the key of the cache.
+	if b.Cache != nil {
+		if err := version.ValidateEnabledAPIFields(ctx, "cache workspace type", config.AlphaAPIFields).ViaField("workspace"); err != nil {
+			return err
+		}
+		if b.Cache.Key == "" {
+			return apis.ErrMissingField("cache.key")
+		}
+	}
+
 	return nil
 }
 
@@ -119,5 +137,8 @@ func (b *WorkspaceBinding) numSources() int {
 	if b.CSI != nil {
 		n++
 	}
+	if b.Cache != nil {
+		n++
+	}
 	return n
 }
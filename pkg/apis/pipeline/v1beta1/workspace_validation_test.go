@@ -112,6 +112,23 @@ func TestWorkspaceBindingValidateValid(t *testing.T) {
 			},
 		},
 		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "Valid cache",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1beta1.CacheWorkspaceBinding{
+				Key: "go-mod-1234",
+			},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "Valid readOnly",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:     "beth",
+			ReadOnly: true,
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+		wc: config.EnableAlphaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -196,6 +213,40 @@ func TestWorkspaceBindingValidateInvalid(t *testing.T) {
 			},
 		},
 		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "cache workspace should be disallowed without alpha feature gate",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1beta1.CacheWorkspaceBinding{
+				Key: "go-mod-1234",
+			},
+		},
+	}, {
+		name: "readOnly should be disallowed without alpha feature gate",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:     "beth",
+			ReadOnly: true,
+			EmptyDir: &corev1.EmptyDirVolumeSource{},
+		},
+	}, {
+		name: "Provide cache without a key",
+		binding: &v1beta1.WorkspaceBinding{
+			Name:  "beth",
+			Cache: &v1beta1.CacheWorkspaceBinding{},
+		},
+		wc: config.EnableAlphaAPIFields,
+	}, {
+		name: "Provided both cache and pvc",
+		binding: &v1beta1.WorkspaceBinding{
+			Name: "beth",
+			Cache: &v1beta1.CacheWorkspaceBinding{
+				Key: "go-mod-1234",
+			},
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: "pool-party",
+			},
+		},
+		wc: config.EnableAlphaAPIFields,
 	}} {
 		t.Run(tc.name, func(t *testing.T) {
 			ctx := context.Background()
@@ -45,6 +45,16 @@ type Resource struct {
 	// refresh tokens for an OAuth2 flow.
 	// Token overrides userame and password
 	Token string `json:"token"`
+	// TokenFile is the path of a file holding the Bearer token, such as a projected
+	// ServiceAccount token, which is read again as the token is rotated.
+	// Token overrides TokenFile.
+	TokenFile string `json:"tokenFile,omitempty"`
+	// Exec runs a command to get the credentials of the user, as a client-go credential plugin.
+	// Exec overrides the token, the token file, and the username and password.
+	Exec *ExecConfig `json:"exec,omitempty"`
+	// Contexts are the contexts written in addition to the context named after the resource,
+	// for instance to target other namespaces of the cluster.
+	Contexts []Context `json:"contexts,omitempty"`
 	// Server should be accessed without verifying the TLS certificate. For testing only.
 	Insecure bool
 	// CAData holds PEM-encoded bytes (typically read from a root certificates bundle).
@@ -61,6 +71,38 @@ type Resource struct {
 	ShellImage            string `json:"-"`
 }
 
+// ExecConfig is the configuration of a client-go credential plugin.
+type ExecConfig struct {
+	// Command is the command to execute.
+	Command string `json:"command"`
+	// Args are the arguments of the command.
+	Args []string `json:"args,omitempty"`
+	// Env are the environment variables set in addition to the ones of the step.
+	Env []ExecEnvVar `json:"env,omitempty"`
+	// APIVersion is the version of the ExecCredential of the plugin,
+	// defaulting to DefaultExecAPIVersion.
+	APIVersion string `json:"apiVersion,omitempty"`
+	// ProvideClusterInfo passes the information of the cluster to the plugin.
+	ProvideClusterInfo bool `json:"provideClusterInfo,omitempty"`
+}
+
+// ExecEnvVar is an environment variable of a credential plugin.
+type ExecEnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Context is a context of the kubeconfig using the cluster and the user of the resource.
+type Context struct {
+	Name string `json:"name"`
+	// Namespace isn't written to kubeconfig if this is empty
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// DefaultExecAPIVersion is the version of the ExecCredential of the credential plugins
+// which don't specify one.
+const DefaultExecAPIVersion = "client.authentication.k8s.io/v1beta1"
+
 // NewResource create a new k8s cluster resource to pass to a pipeline task
 func NewResource(name string, kubeconfigWriterImage, shellImage string, r *resource.PipelineResource) (*Resource, error) {
 	if r.Spec.Type != resource.PipelineResourceTypeCluster {
@@ -86,6 +128,28 @@ func NewResource(name string, kubeconfigWriterImage, shellImage string, r *resou
 			clusterResource.Password = param.Value
 		case strings.EqualFold(param.Name, "Token"):
 			clusterResource.Token = param.Value
+		case strings.EqualFold(param.Name, "TokenFile"):
+			clusterResource.TokenFile = param.Value
+		case strings.EqualFold(param.Name, "Exec"):
+			if param.Value != "" {
+				exec := &ExecConfig{}
+				if err := json.Unmarshal([]byte(param.Value), exec); err != nil {
+					return nil, fmt.Errorf("cluster.Resource: Invalid Exec of cluster resource %s: %w", name, err)
+				}
+				if exec.Command == "" {
+					return nil, fmt.Errorf("cluster.Resource: Exec of cluster resource %s must have a command", name)
+				}
+				if exec.APIVersion == "" {
+					exec.APIVersion = DefaultExecAPIVersion
+				}
+				clusterResource.Exec = exec
+			}
+		case strings.EqualFold(param.Name, "Contexts"):
+			if param.Value != "" {
+				if err := json.Unmarshal([]byte(param.Value), &clusterResource.Contexts); err != nil {
+					return nil, fmt.Errorf("cluster.Resource: Invalid Contexts of cluster resource %s: %w", name, err)
+				}
+			}
 		case strings.EqualFold(param.Name, "Insecure"):
 			b, _ := strconv.ParseBool(param.Value)
 			clusterResource.Insecure = b
@@ -108,6 +172,17 @@ func NewResource(name string, kubeconfigWriterImage, shellImage string, r *resou
 	}
 	clusterResource.Secrets = r.Spec.SecretParams
 
+	contexts := map[string]bool{name: true}
+	for _, c := range clusterResource.Contexts {
+		if c.Name == "" {
+			return nil, fmt.Errorf("cluster.Resource: Contexts of cluster resource %s must have a name", name)
+		}
+		if contexts[c.Name] {
+			return nil, fmt.Errorf("cluster.Resource: Duplicate context %s of cluster resource %s", c.Name, name)
+		}
+		contexts[c.Name] = true
+	}
+
 	if len(clusterResource.CAData) == 0 {
 		clusterResource.Insecure = true
 		for _, secret := range clusterResource.Secrets {
@@ -147,6 +222,7 @@ func (s *Resource) Replacements() map[string]string {
 		"password":              s.Password,
 		"namespace":             s.Namespace,
 		"token":                 s.Token,
+		"tokenFile":             s.TokenFile,
 		"insecure":              strconv.FormatBool(s.Insecure),
 		"cadata":                string(s.CAData),
 		"clientKeyData":         string(s.ClientKeyData),
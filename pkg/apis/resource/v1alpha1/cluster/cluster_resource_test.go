@@ -211,6 +211,86 @@ func TestNewClusterResource(t *testing.T) {
 			Namespace:             "my-namespace",
 			KubeconfigWriterImage: "override-with-kubeconfig-writer:latest",
 		},
+	}, {
+		desc: "resource with tokenFile and contexts",
+		resource: &resourcev1alpha1.PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-resource",
+			},
+			Spec: resourcev1alpha1.PipelineResourceSpec{
+				Type: resourcev1alpha1.PipelineResourceTypeCluster,
+				Params: []resourcev1alpha1.ResourceParam{
+					{
+						Name:  "url",
+						Value: "http://10.10.10.10",
+					},
+					{
+						Name:  "cadata",
+						Value: "bXktY2x1c3Rlci1jZXJ0Cg",
+					},
+					{
+						Name:  "tokenFile",
+						Value: "/var/run/secrets/tokens/cluster-token",
+					},
+					{
+						Name:  "contexts",
+						Value: `[{"name":"staging","namespace":"staging"},{"name":"production","namespace":"production"}]`,
+					},
+				},
+			},
+		},
+		want: &cluster.Resource{
+			Name:      "test-resource",
+			Type:      resourcev1alpha1.PipelineResourceTypeCluster,
+			URL:       "http://10.10.10.10",
+			CAData:    []byte("my-cluster-cert"),
+			TokenFile: "/var/run/secrets/tokens/cluster-token",
+			Contexts: []cluster.Context{{
+				Name:      "staging",
+				Namespace: "staging",
+			}, {
+				Name:      "production",
+				Namespace: "production",
+			}},
+			KubeconfigWriterImage: "override-with-kubeconfig-writer:latest",
+		},
+	}, {
+		desc: "resource with exec",
+		resource: &resourcev1alpha1.PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-resource",
+			},
+			Spec: resourcev1alpha1.PipelineResourceSpec{
+				Type: resourcev1alpha1.PipelineResourceTypeCluster,
+				Params: []resourcev1alpha1.ResourceParam{
+					{
+						Name:  "url",
+						Value: "http://10.10.10.10",
+					},
+					{
+						Name:  "cadata",
+						Value: "bXktY2x1c3Rlci1jZXJ0Cg",
+					},
+					{
+						Name:  "exec",
+						Value: `{"command":"aws","args":["eks","get-token","--cluster-name","prod"],"env":[{"name":"AWS_REGION","value":"eu-west-1"}]}`,
+					},
+				},
+			},
+		},
+		want: &cluster.Resource{
+			Name:   "test-resource",
+			Type:   resourcev1alpha1.PipelineResourceTypeCluster,
+			URL:    "http://10.10.10.10",
+			CAData: []byte("my-cluster-cert"),
+			Exec: &cluster.ExecConfig{
+				Command:    "aws",
+				Args:       []string{"eks", "get-token", "--cluster-name", "prod"},
+				Env:        []cluster.ExecEnvVar{{Name: "AWS_REGION", Value: "eu-west-1"}},
+				APIVersion: cluster.DefaultExecAPIVersion,
+			},
+			KubeconfigWriterImage: "override-with-kubeconfig-writer:latest",
+		},
 	}, {
 		desc: "basic resource with secrets",
 		resource: &resourcev1alpha1.PipelineResource{
@@ -268,6 +348,49 @@ func TestNewClusterResource(t *testing.T) {
 	}
 }
 
+func TestNewClusterResource_Invalid(t *testing.T) {
+	for _, c := range []struct {
+		desc   string
+		params []resourcev1alpha1.ResourceParam
+	}{{
+		desc:   "malformed exec",
+		params: []resourcev1alpha1.ResourceParam{{Name: "exec", Value: "aws eks get-token"}},
+	}, {
+		desc:   "exec without command",
+		params: []resourcev1alpha1.ResourceParam{{Name: "exec", Value: `{"args":["eks","get-token"]}`}},
+	}, {
+		desc:   "malformed contexts",
+		params: []resourcev1alpha1.ResourceParam{{Name: "contexts", Value: `{"name":"staging"}`}},
+	}, {
+		desc:   "context without name",
+		params: []resourcev1alpha1.ResourceParam{{Name: "contexts", Value: `[{"namespace":"staging"}]`}},
+	}, {
+		desc:   "duplicate contexts",
+		params: []resourcev1alpha1.ResourceParam{{Name: "contexts", Value: `[{"name":"staging"},{"name":"staging"}]`}},
+	}, {
+		desc:   "context named after the resource",
+		params: []resourcev1alpha1.ResourceParam{{Name: "contexts", Value: `[{"name":"test-resource"}]`}},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			r := &resourcev1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-resource",
+				},
+				Spec: resourcev1alpha1.PipelineResourceSpec{
+					Type: resourcev1alpha1.PipelineResourceTypeCluster,
+					Params: append([]resourcev1alpha1.ResourceParam{{
+						Name:  "url",
+						Value: "http://10.10.10.10",
+					}}, c.params...),
+				},
+			}
+			if _, err := cluster.NewResource("test-resource", "override-with-kubeconfig-writer:latest", "override-with-shell-image:latest", r); err == nil {
+				t.Error("NewResource() got success, wanted error")
+			}
+		})
+	}
+}
+
 func TestClusterResource_GetInputTaskModifier(t *testing.T) {
 	names.TestingSeed()
 	clusterResource := &cluster.Resource{
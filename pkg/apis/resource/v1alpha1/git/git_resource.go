@@ -31,6 +31,8 @@ import (
 
 var (
 	gitSource = "git-source"
+	// trustedKeysMountPath is where the Secret of the keys trusted to sign the revision is mounted.
+	trustedKeysMountPath = "/var/git-trusted-keys"
 )
 
 // Resource is an endpoint from which to get data which is required
@@ -50,7 +52,14 @@ type Resource struct {
 	HTTPProxy  string `json:"httpProxy"`
 	HTTPSProxy string `json:"httpsProxy"`
 	NOProxy    string `json:"noProxy"`
-	GitImage   string `json:"-"`
+	// Filter is the object filter of a partial clone, e.g. "blob:none".
+	Filter string `json:"filter"`
+	LFS    bool   `json:"lfs"`
+	// TrustedKeysSecret is the name of the Secret of the GPG and SSH public keys trusted to sign the revision.
+	TrustedKeysSecret string `json:"trustedKeysSecret"`
+	// TrustedKeysSecretKey is the key of the TrustedKeysSecret to mount, or empty to mount all of its keys.
+	TrustedKeysSecretKey string `json:"trustedKeysSecretKey"`
+	GitImage             string `json:"-"`
 }
 
 // NewResource creates a new git resource to pass to a Task
@@ -86,6 +95,16 @@ func NewResource(name, gitImage string, r *resource.PipelineResource) (*Resource
 			gitResource.HTTPSProxy = param.Value
 		case strings.EqualFold(param.Name, "NOProxy"):
 			gitResource.NOProxy = param.Value
+		case strings.EqualFold(param.Name, "Filter"):
+			gitResource.Filter = param.Value
+		case strings.EqualFold(param.Name, "LFS"):
+			gitResource.LFS = toBool(param.Value, false)
+		}
+	}
+	for _, secret := range r.Spec.SecretParams {
+		if strings.EqualFold(secret.FieldName, "TrustedKeys") {
+			gitResource.TrustedKeysSecret = secret.SecretName
+			gitResource.TrustedKeysSecretKey = secret.SecretKey
 		}
 	}
 
@@ -140,6 +159,8 @@ func (s *Resource) Replacements() map[string]string {
 		"httpProxy":  s.HTTPProxy,
 		"httpsProxy": s.HTTPSProxy,
 		"noProxy":    s.NOProxy,
+		"filter":     s.Filter,
+		"lfs":        strconv.FormatBool(s.LFS),
 	}
 }
 
@@ -166,6 +187,31 @@ func (s *Resource) GetInputTaskModifier(_ *v1beta1.TaskSpec, path string) (v1bet
 	if !s.SSLVerify {
 		args = append(args, "-sslVerify=false")
 	}
+	if s.Filter != "" {
+		args = append(args, "-filter", s.Filter)
+	}
+	if s.LFS {
+		args = append(args, "-lfs")
+	}
+	var volumes []corev1.Volume
+	var volumeMounts []corev1.VolumeMount
+	if s.TrustedKeysSecret != "" {
+		volName := fmt.Sprintf("volume-%s-%s", s.Name, s.TrustedKeysSecret)
+		secret := &corev1.SecretVolumeSource{SecretName: s.TrustedKeysSecret}
+		if s.TrustedKeysSecretKey != "" {
+			secret.Items = []corev1.KeyToPath{{Key: s.TrustedKeysSecretKey, Path: s.TrustedKeysSecretKey}}
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name:         volName,
+			VolumeSource: corev1.VolumeSource{Secret: secret},
+		})
+		volumeMounts = append(volumeMounts, corev1.VolumeMount{
+			Name:      volName,
+			MountPath: trustedKeysMountPath,
+			ReadOnly:  true,
+		})
+		args = append(args, "-trustedKeysPath", trustedKeysMountPath)
+	}
 
 	env := []corev1.EnvVar{{
 		Name:  "TEKTON_RESOURCE_NAME",
@@ -194,7 +240,8 @@ func (s *Resource) GetInputTaskModifier(_ *v1beta1.TaskSpec, path string) (v1bet
 		Args:       args,
 		WorkingDir: pipeline.WorkspaceDir,
 		// This is used to populate the ResourceResult status.
-		Env: env,
+		Env:          env,
+		VolumeMounts: volumeMounts,
 		SecurityContext: &corev1.SecurityContext{
 			// The git pipeline resource only works when running as root.
 			RunAsUser: ptr.Int64(0),
@@ -203,6 +250,7 @@ func (s *Resource) GetInputTaskModifier(_ *v1beta1.TaskSpec, path string) (v1bet
 
 	return &v1beta1.InternalTaskModifier{
 		StepsToPrepend: []v1beta1.Step{step},
+		Volumes:        volumes,
 	}, nil
 }
 
@@ -490,6 +490,81 @@ func TestNewGitResource_Valid(t *testing.T) {
 			HTTPSProxy: "",
 			NOProxy:    "*",
 		},
+	}, {
+		desc: "With Filter and LFS",
+		pipelineResource: &resourcev1alpha1.PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-resource",
+			},
+			Spec: resourcev1alpha1.PipelineResourceSpec{
+				Type: resourcev1alpha1.PipelineResourceTypeGit,
+				Params: []resourcev1alpha1.ResourceParam{
+					{
+						Name:  "URL",
+						Value: "git@github.com:test/test.git",
+					},
+					{
+						Name:  "Revision",
+						Value: "test",
+					},
+					{
+						Name:  "Filter",
+						Value: "blob:none",
+					},
+					{
+						Name:  "LFS",
+						Value: "true",
+					},
+				},
+			},
+		},
+		want: &git.Resource{
+			Name:       "test-resource",
+			Type:       resourcev1alpha1.PipelineResourceTypeGit,
+			URL:        "git@github.com:test/test.git",
+			Revision:   "test",
+			Refspec:    "",
+			GitImage:   "override-with-git:latest",
+			Submodules: true,
+			Depth:      1,
+			SSLVerify:  true,
+			Filter:     "blob:none",
+			LFS:        true,
+		},
+	}, {
+		desc: "With TrustedKeys secret",
+		pipelineResource: &resourcev1alpha1.PipelineResource{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test-resource",
+			},
+			Spec: resourcev1alpha1.PipelineResourceSpec{
+				Type: resourcev1alpha1.PipelineResourceTypeGit,
+				Params: []resourcev1alpha1.ResourceParam{
+					{
+						Name:  "URL",
+						Value: "git@github.com:test/test.git",
+					},
+				},
+				SecretParams: []resourcev1alpha1.SecretParam{
+					{
+						FieldName:  "trustedKeys",
+						SecretKey:  "signers.pub",
+						SecretName: "release-signers",
+					},
+				},
+			},
+		},
+		want: &git.Resource{
+			Name:                 "test-resource",
+			Type:                 resourcev1alpha1.PipelineResourceTypeGit,
+			URL:                  "git@github.com:test/test.git",
+			GitImage:             "override-with-git:latest",
+			Submodules:           true,
+			Depth:                1,
+			SSLVerify:            true,
+			TrustedKeysSecret:    "release-signers",
+			TrustedKeysSecretKey: "signers.pub",
+		},
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
 			got, err := git.NewResource("test-resource", "override-with-git:latest", tc.pipelineResource)
@@ -517,6 +592,8 @@ func TestGitResource_Replacements(t *testing.T) {
 		HTTPProxy:  "http-proxy.git.com",
 		HTTPSProxy: "https-proxy.git.com",
 		NOProxy:    "*",
+		Filter:     "blob:none",
+		LFS:        true,
 	}
 
 	want := map[string]string{
@@ -531,6 +608,8 @@ func TestGitResource_Replacements(t *testing.T) {
 		"httpProxy":  "http-proxy.git.com",
 		"httpsProxy": "https-proxy.git.com",
 		"noProxy":    "*",
+		"filter":     "blob:none",
+		"lfs":        "true",
 	}
 
 	got := r.Replacements()
@@ -896,6 +975,43 @@ func TestGitResource_GetDownloadTaskModifier(t *testing.T) {
 			},
 			SecurityContext: securityContext,
 		},
+	}, {
+		desc: "With Filter and LFS",
+		gitResource: &git.Resource{
+			Name:       "git-resource",
+			Type:       resourcev1alpha1.PipelineResourceTypeGit,
+			URL:        "git@github.com:test/test.git",
+			Revision:   "master",
+			Refspec:    "",
+			GitImage:   "override-with-git:latest",
+			Submodules: true,
+			Depth:      1,
+			SSLVerify:  true,
+			Filter:     "blob:none",
+			LFS:        true,
+		},
+		want: v1beta1.Step{
+			Name:    "git-source-git-resource-mnq6l",
+			Image:   "override-with-git:latest",
+			Command: []string{"/ko-app/git-init"},
+			Args: []string{
+				"-url",
+				"git@github.com:test/test.git",
+				"-path",
+				"/test/test",
+				"-revision",
+				"master",
+				"-filter",
+				"blob:none",
+				"-lfs",
+			},
+			WorkingDir: "/workspace",
+			Env: []corev1.EnvVar{
+				{Name: "TEKTON_RESOURCE_NAME", Value: "git-resource"},
+				{Name: "HOME", Value: pipeline.HomeDir},
+			},
+			SecurityContext: securityContext,
+		},
 	}} {
 		t.Run(tc.desc, func(t *testing.T) {
 			ts := v1beta1.TaskSpec{}
@@ -910,3 +1026,79 @@ func TestGitResource_GetDownloadTaskModifier(t *testing.T) {
 		})
 	}
 }
+
+func TestGitResource_GetDownloadTaskModifier_TrustedKeys(t *testing.T) {
+	for _, tc := range []struct {
+		desc       string
+		secretKey  string
+		wantSecret *corev1.SecretVolumeSource
+	}{{
+		desc:       "all the keys of the secret",
+		wantSecret: &corev1.SecretVolumeSource{SecretName: "release-signers"},
+	}, {
+		desc:      "one key of the secret",
+		secretKey: "release.asc",
+		wantSecret: &corev1.SecretVolumeSource{
+			SecretName: "release-signers",
+			Items:      []corev1.KeyToPath{{Key: "release.asc", Path: "release.asc"}},
+		},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			names.TestingSeed()
+			r := &git.Resource{
+				Name:                 "git-resource",
+				Type:                 resourcev1alpha1.PipelineResourceTypeGit,
+				URL:                  "git@github.com:test/test.git",
+				Revision:             "master",
+				GitImage:             "override-with-git:latest",
+				Submodules:           true,
+				Depth:                1,
+				SSLVerify:            true,
+				TrustedKeysSecret:    "release-signers",
+				TrustedKeysSecretKey: tc.secretKey,
+			}
+
+			modifier, err := r.GetInputTaskModifier(&v1beta1.TaskSpec{}, "/test/test")
+			if err != nil {
+				t.Fatalf("Unexpected error getting GetDownloadTaskModifier: %s", err)
+			}
+
+			wantSteps := []v1beta1.Step{{
+				Name:    "git-source-git-resource-9l9zj",
+				Image:   "override-with-git:latest",
+				Command: []string{"/ko-app/git-init"},
+				Args: []string{
+					"-url",
+					"git@github.com:test/test.git",
+					"-path",
+					"/test/test",
+					"-revision",
+					"master",
+					"-trustedKeysPath",
+					"/var/git-trusted-keys",
+				},
+				WorkingDir: "/workspace",
+				Env: []corev1.EnvVar{
+					{Name: "TEKTON_RESOURCE_NAME", Value: "git-resource"},
+					{Name: "HOME", Value: pipeline.HomeDir},
+				},
+				VolumeMounts: []corev1.VolumeMount{{
+					Name:      "volume-git-resource-release-signers",
+					MountPath: "/var/git-trusted-keys",
+					ReadOnly:  true,
+				}},
+				SecurityContext: &corev1.SecurityContext{RunAsUser: ptr.Int64(0)},
+			}}
+			if d := cmp.Diff(wantSteps, modifier.GetStepsToPrepend()); d != "" {
+				t.Errorf("Mismatch of GitResource DownloadContainerSpec %s", diff.PrintWantGot(d))
+			}
+			wantVolumes := []corev1.Volume{{
+				Name:         "volume-git-resource-release-signers",
+				VolumeSource: corev1.VolumeSource{Secret: tc.wantSecret},
+			}}
+			if d := cmp.Diff(wantVolumes, modifier.GetVolumes()); d != "" {
+				t.Errorf("Mismatch of GitResource Volumes %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
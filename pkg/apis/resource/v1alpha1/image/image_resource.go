@@ -25,12 +25,29 @@ import (
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
 )
 
+// The sources of the digest of an output image.
+const (
+	// DigestSourceLayout reads the digest from the index.json file of an OCI image layout in the output
+	// directory of the image. The image is skipped if the output directory is not an OCI image layout.
+	DigestSourceLayout = "layout"
+	// DigestSourceFile reads the digest from the DigestFile file in the output directory of the image.
+	DigestSourceFile = "file"
+	// DigestSourceRegistry resolves the digest of the image URL from the registry.
+	DigestSourceRegistry = "registry"
+)
+
+// DigestFile is the name of the file of the digest of an output image, for the DigestSourceFile source.
+// It contains either the digest, or one or more image references with digests, one per line.
+const DigestFile = "digest"
+
 // Resource defines an endpoint where artifacts can be stored, such as images.
 type Resource struct {
-	Name           string                                `json:"name"`
-	Type           resourcev1alpha1.PipelineResourceType `json:"type"`
-	URL            string                                `json:"url"`
-	Digest         string                                `json:"digest"`
+	Name   string                                `json:"name"`
+	Type   resourcev1alpha1.PipelineResourceType `json:"type"`
+	URL    string                                `json:"url"`
+	Digest string                                `json:"digest"`
+	// DigestSource is where the digest of the output image is read from, DigestSourceLayout by default.
+	DigestSource   string `json:"digestSource,omitempty"`
 	OutputImageDir string
 }
 
@@ -50,8 +67,15 @@ func NewResource(name string, r *resourcev1alpha1.PipelineResource) (*Resource,
 			ir.URL = param.Value
 		case strings.EqualFold(param.Name, "Digest"):
 			ir.Digest = param.Value
+		case strings.EqualFold(param.Name, "DigestSource"):
+			ir.DigestSource = param.Value
 		}
 	}
+	switch ir.DigestSource {
+	case "", DigestSourceLayout, DigestSourceFile, DigestSourceRegistry:
+	default:
+		return nil, fmt.Errorf("ImageResource: Invalid digestSource %q, must be one of %q, %q or %q", ir.DigestSource, DigestSourceLayout, DigestSourceFile, DigestSourceRegistry)
+	}
 
 	return ir, nil
 }
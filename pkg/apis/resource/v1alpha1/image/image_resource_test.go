@@ -44,40 +44,82 @@ func TestNewImageResource_Invalid(t *testing.T) {
 	}
 }
 
-func TestNewImageResource_Valid(t *testing.T) {
-	want := &image.Resource{
-		Name:   "image-resource",
-		Type:   v1alpha1.PipelineResourceTypeImage,
-		URL:    "https://test.com/test/test",
-		Digest: "test",
-	}
-
+func TestNewImageResource_InvalidDigestSource(t *testing.T) {
 	r := &v1alpha1.PipelineResource{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: "image-resource",
+			Name: "test-resource",
 		},
 		Spec: v1alpha1.PipelineResourceSpec{
 			Type: v1alpha1.PipelineResourceTypeImage,
-			Params: []v1alpha1.ResourceParam{
-				{
-					Name:  "URL",
-					Value: "https://test.com/test/test",
-				},
-				{
-					Name:  "Digest",
-					Value: "test",
-				},
-			},
+			Params: []v1alpha1.ResourceParam{{
+				Name:  "DigestSource",
+				Value: "index.json",
+			}},
 		},
 	}
 
-	got, err := image.NewResource("image-resource", r)
-	if err != nil {
-		t.Fatalf("Unexpected error creating Image resource: %s", err)
+	_, err := image.NewResource("test-resource", r)
+	if err == nil {
+		t.Error("Expected error creating Image resource")
 	}
+}
 
-	if d := cmp.Diff(want, got); d != "" {
-		t.Errorf("Mismatch of Image resource: %s", diff.PrintWantGot(d))
+func TestNewImageResource_Valid(t *testing.T) {
+	for _, tc := range []struct {
+		desc         string
+		digestSource string
+	}{{
+		desc: "default digest source",
+	}, {
+		desc:         "layout digest source",
+		digestSource: image.DigestSourceLayout,
+	}, {
+		desc:         "file digest source",
+		digestSource: image.DigestSourceFile,
+	}, {
+		desc:         "registry digest source",
+		digestSource: image.DigestSourceRegistry,
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			want := &image.Resource{
+				Name:         "image-resource",
+				Type:         v1alpha1.PipelineResourceTypeImage,
+				URL:          "https://test.com/test/test",
+				Digest:       "test",
+				DigestSource: tc.digestSource,
+			}
+
+			r := &v1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "image-resource",
+				},
+				Spec: v1alpha1.PipelineResourceSpec{
+					Type: v1alpha1.PipelineResourceTypeImage,
+					Params: []v1alpha1.ResourceParam{
+						{
+							Name:  "URL",
+							Value: "https://test.com/test/test",
+						},
+						{
+							Name:  "Digest",
+							Value: "test",
+						},
+					},
+				},
+			}
+			if tc.digestSource != "" {
+				r.Spec.Params = append(r.Spec.Params, v1alpha1.ResourceParam{Name: "DigestSource", Value: tc.digestSource})
+			}
+
+			got, err := image.NewResource("image-resource", r)
+			if err != nil {
+				t.Fatalf("Unexpected error creating Image resource: %s", err)
+			}
+
+			if d := cmp.Diff(want, got); d != "" {
+				t.Errorf("Mismatch of Image resource: %s", diff.PrintWantGot(d))
+			}
+		})
 	}
 }
 
@@ -54,6 +54,10 @@ const (
 
 	// PipelineResourceTypeGCS is the subtype for the GCSResources, which is backed by a GCS blob/directory.
 	PipelineResourceTypeGCS PipelineResourceType = "gcs"
+
+	// PipelineResourceTypeS3 is the subtype for the S3Resources, which is backed by an object or a prefix of
+	// an S3-compatible storage.
+	PipelineResourceTypeS3 PipelineResourceType = "s3"
 )
 
 // AllResourceTypes can be used for validation to check if a provided Resource type is one of the known types.
@@ -18,6 +18,7 @@ package v1alpha1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -65,6 +66,21 @@ func (rs *PipelineResourceSpec) Validate(ctx context.Context) *apis.FieldError {
 				clientCertificateDataFound = true
 			case strings.EqualFold(param.Name, "Token"):
 				authFound = true
+			case strings.EqualFold(param.Name, "TokenFile"):
+				authFound = true
+			case strings.EqualFold(param.Name, "Exec"):
+				if param.Value != "" {
+					if err := validateClusterExec(param.Value); err != nil {
+						return err
+					}
+					authFound = true
+				}
+			case strings.EqualFold(param.Name, "Contexts"):
+				if param.Value != "" {
+					if err := validateClusterContexts(param.Value); err != nil {
+						return err
+					}
+				}
 			case strings.EqualFold(param.Name, "insecure"):
 				b, _ := strconv.ParseBool(param.Value)
 				isInsecure = b
@@ -87,7 +103,7 @@ func (rs *PipelineResourceSpec) Validate(ctx context.Context) *apis.FieldError {
 
 		// One auth method must be supplied
 		if !(authFound) {
-			return apis.ErrMissingField("username or CAData  or token param or clientKeyData or ClientCertificateData")
+			return apis.ErrMissingField("username or CAData  or token param or tokenFile or exec or clientKeyData or ClientCertificateData")
 		}
 		if !cadataFound && !isInsecure {
 			return apis.ErrMissingField("CAData param")
@@ -131,9 +147,45 @@ func (rs *PipelineResourceSpec) Validate(ctx context.Context) *apis.FieldError {
 	return apis.ErrInvalidValue("spec.type", rs.Type)
 }
 
+// validateClusterExec validates the JSON of the client-go credential plugin of a cluster resource.
+func validateClusterExec(value string) *apis.FieldError {
+	var exec struct {
+		Command string `json:"command"`
+	}
+	if err := json.Unmarshal([]byte(value), &exec); err != nil {
+		return apis.ErrInvalidValue(fmt.Sprintf("invalid JSON: %v", err), "spec.params.exec")
+	}
+	if exec.Command == "" {
+		return apis.ErrMissingField("spec.params.exec.command")
+	}
+	return nil
+}
+
+// validateClusterContexts validates the JSON of the additional contexts of a cluster resource,
+// which must have distinct names.
+func validateClusterContexts(value string) *apis.FieldError {
+	var contexts []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(value), &contexts); err != nil {
+		return apis.ErrInvalidValue(fmt.Sprintf("invalid JSON: %v", err), "spec.params.contexts")
+	}
+	names := map[string]bool{}
+	for i, c := range contexts {
+		if c.Name == "" {
+			return apis.ErrMissingField(fmt.Sprintf("spec.params.contexts[%d].name", i))
+		}
+		if names[c.Name] {
+			return apis.ErrInvalidValue(fmt.Sprintf("duplicate context %q", c.Name), fmt.Sprintf("spec.params.contexts[%d].name", i))
+		}
+		names[c.Name] = true
+	}
+	return nil
+}
+
 // AllowedStorageType returns true if the provided string can be used as a storage type, and false otherwise
 func AllowedStorageType(gotType string) bool {
-	return gotType == PipelineResourceTypeGCS
+	return gotType == PipelineResourceTypeGCS || gotType == PipelineResourceTypeS3
 }
 
 func validateURL(u, path string) *apis.FieldError {
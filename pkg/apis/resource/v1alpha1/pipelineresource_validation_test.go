@@ -75,7 +75,7 @@ func TestResourceValidation_Invalid(t *testing.T) {
 					}},
 				},
 			},
-			want: apis.ErrMissingField("username or CAData  or token param or clientKeyData or ClientCertificateData"),
+			want: apis.ErrMissingField("username or CAData  or token param or tokenFile or exec or clientKeyData or ClientCertificateData"),
 		}, {
 			name: "cluster with missing cadata",
 			res: &v1alpha1.PipelineResource{
@@ -96,6 +96,82 @@ func TestResourceValidation_Invalid(t *testing.T) {
 				},
 			},
 			want: apis.ErrMissingField("CAData param"),
+		}, {
+			name: "cluster with invalid exec",
+			res: &v1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "temp",
+				},
+				Spec: v1alpha1.PipelineResourceSpec{
+					Type: v1alpha1.PipelineResourceTypeCluster,
+					Params: []v1alpha1.ResourceParam{{
+						Name: "url", Value: "http://10.10.10.10",
+					}, {
+						Name: "exec", Value: "aws eks get-token",
+					}, {
+						Name: "insecure", Value: "true",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue("invalid JSON: invalid character 'a' looking for beginning of value", "spec.params.exec"),
+		}, {
+			name: "cluster with exec without command",
+			res: &v1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "temp",
+				},
+				Spec: v1alpha1.PipelineResourceSpec{
+					Type: v1alpha1.PipelineResourceTypeCluster,
+					Params: []v1alpha1.ResourceParam{{
+						Name: "url", Value: "http://10.10.10.10",
+					}, {
+						Name: "exec", Value: `{"args":["eks","get-token","--cluster-name","prod"]}`,
+					}, {
+						Name: "insecure", Value: "true",
+					}},
+				},
+			},
+			want: apis.ErrMissingField("spec.params.exec.command"),
+		}, {
+			name: "cluster with context without name",
+			res: &v1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "temp",
+				},
+				Spec: v1alpha1.PipelineResourceSpec{
+					Type: v1alpha1.PipelineResourceTypeCluster,
+					Params: []v1alpha1.ResourceParam{{
+						Name: "url", Value: "http://10.10.10.10",
+					}, {
+						Name: "token", Value: "my-token",
+					}, {
+						Name: "contexts", Value: `[{"namespace":"staging"}]`,
+					}, {
+						Name: "insecure", Value: "true",
+					}},
+				},
+			},
+			want: apis.ErrMissingField("spec.params.contexts[0].name"),
+		}, {
+			name: "cluster with duplicate contexts",
+			res: &v1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "temp",
+				},
+				Spec: v1alpha1.PipelineResourceSpec{
+					Type: v1alpha1.PipelineResourceTypeCluster,
+					Params: []v1alpha1.ResourceParam{{
+						Name: "url", Value: "http://10.10.10.10",
+					}, {
+						Name: "token", Value: "my-token",
+					}, {
+						Name: "contexts", Value: `[{"name":"staging"},{"name":"staging","namespace":"other"}]`,
+					}, {
+						Name: "insecure", Value: "true",
+					}},
+				},
+			},
+			want: apis.ErrInvalidValue(`duplicate context "staging"`, "spec.params.contexts[1].name"),
 		}, {
 			name: "storage with no type",
 			res: &v1alpha1.PipelineResource{
@@ -253,6 +329,62 @@ func TestClusterResourceValidation_Valid(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "specify tokenFile",
+			res: &v1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "temp",
+				},
+				Spec: v1alpha1.PipelineResourceSpec{
+					Type: v1alpha1.PipelineResourceTypeCluster,
+					Params: []v1alpha1.ResourceParam{{
+						Name: "url", Value: "http://10.10.10.10",
+					}, {
+						Name: "tokenFile", Value: "/var/run/secrets/tokens/cluster-token",
+					}, {
+						Name: "insecure", Value: "true",
+					}},
+				},
+			},
+		},
+		{
+			name: "specify exec",
+			res: &v1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "temp",
+				},
+				Spec: v1alpha1.PipelineResourceSpec{
+					Type: v1alpha1.PipelineResourceTypeCluster,
+					Params: []v1alpha1.ResourceParam{{
+						Name: "url", Value: "http://10.10.10.10",
+					}, {
+						Name: "exec", Value: `{"command":"aws","args":["eks","get-token","--cluster-name","prod"]}`,
+					}, {
+						Name: "insecure", Value: "true",
+					}},
+				},
+			},
+		},
+		{
+			name: "specify contexts",
+			res: &v1alpha1.PipelineResource{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "temp",
+				},
+				Spec: v1alpha1.PipelineResourceSpec{
+					Type: v1alpha1.PipelineResourceTypeCluster,
+					Params: []v1alpha1.ResourceParam{{
+						Name: "url", Value: "http://10.10.10.10",
+					}, {
+						Name: "token", Value: "my-token",
+					}, {
+						Name: "contexts", Value: `[{"name":"staging","namespace":"staging"},{"name":"prod"}]`,
+					}, {
+						Name: "insecure", Value: "true",
+					}},
+				},
+			},
+		},
 		{
 			name: "specify pullrequest with no secrets",
 			res: &v1alpha1.PipelineResource{
@@ -284,6 +416,10 @@ func TestAllowedGCSStorageType(t *testing.T) {
 			storageType: "gcs",
 			want:        true,
 		},
+		{name: "storage with s3 type",
+			storageType: "s3",
+			want:        true,
+		},
 		{name: "storage with incorrent type",
 			storageType: "t",
 			want:        false,
@@ -18,10 +18,12 @@ package storage
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resource "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/internal/objectstore"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -32,15 +34,26 @@ import (
 const secretVolumeMountPath = "/var/bucketsecret"
 
 // ArtifactBucket contains the Storage bucket configuration defined in the
-// Bucket config map.
+// Bucket config map. The artifacts are copied with gsutil, or with s3-copy when
+// the Location is an s3:// URL and no boto configuration is provided for gsutil.
 // +k8s:deepcopy-gen=true
 type ArtifactBucket struct {
 	Name     string
 	Location string
 	Secrets  []resource.SecretParam
 
+	// S3Endpoint, S3Region and S3PathStyle configure the access to the
+	// S3-compatible storage of an s3:// Location.
+	S3Endpoint  string
+	S3Region    string
+	S3PathStyle bool
+	// S3SecretName is the name of the secret holding the credentials of the
+	// S3-compatible storage.
+	S3SecretName string
+
 	ShellImage  string
 	GsutilImage string
+	S3CopyImage string
 }
 
 // GetType returns the type of the artifact storage
@@ -55,13 +68,24 @@ func (b *ArtifactBucket) StorageBasePath(pr *v1beta1.PipelineRun) string {
 
 // GetCopyFromStorageToSteps returns a container used to download artifacts from temporary storage
 func (b *ArtifactBucket) GetCopyFromStorageToSteps(name, sourcePath, destinationPath string) []v1beta1.Step {
-	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
-
-	return []v1beta1.Step{{
+	mkdirStep := v1beta1.Step{
 		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-dest-mkdir-%s", name)),
 		Image:   b.ShellImage,
 		Command: []string{"mkdir", "-p", destinationPath},
-	}, {
+	}
+	if b.isS3() {
+		return []v1beta1.Step{mkdirStep, {
+			Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-from-%s", name)),
+			Image:   b.S3CopyImage,
+			Command: []string{s3CopyCommand},
+			Args:    append(s3CopyArgs(b.S3Endpoint, b.S3Region, b.S3PathStyle), "-r", fmt.Sprintf("%s/%s", b.Location, sourcePath), destinationPath),
+			Env:     b.s3CredentialsEnvVars(),
+		}}
+	}
+
+	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
+
+	return []v1beta1.Step{mkdirStep, {
 		Name:         names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-from-%s", name)),
 		Image:        b.GsutilImage,
 		Command:      []string{"gsutil"},
@@ -73,6 +97,16 @@ func (b *ArtifactBucket) GetCopyFromStorageToSteps(name, sourcePath, destination
 
 // GetCopyToStorageFromSteps returns a container used to upload artifacts for temporary storage
 func (b *ArtifactBucket) GetCopyToStorageFromSteps(name, sourcePath, destinationPath string) []v1beta1.Step {
+	if b.isS3() {
+		return []v1beta1.Step{{
+			Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("artifact-copy-to-%s", name)),
+			Image:   b.S3CopyImage,
+			Command: []string{s3CopyCommand},
+			Args:    append(s3CopyArgs(b.S3Endpoint, b.S3Region, b.S3PathStyle), "-r", sourcePath, fmt.Sprintf("%s/%s", b.Location, destinationPath)),
+			Env:     b.s3CredentialsEnvVars(),
+		}}
+	}
+
 	envVars, secretVolumeMount := getSecretEnvVarsAndVolumeMounts("bucket", secretVolumeMountPath, b.Secrets)
 
 	return []v1beta1.Step{{
@@ -101,3 +135,46 @@ func (b *ArtifactBucket) GetSecretsVolumes() []corev1.Volume {
 	}
 	return volumes
 }
+
+// isS3 returns true if the bucket is in an S3-compatible storage accessed with s3-copy.
+func (b *ArtifactBucket) isS3() bool {
+	if !strings.HasPrefix(b.Location, "s3://") {
+		return false
+	}
+	for _, s := range b.Secrets {
+		if s.FieldName == "BOTO_CONFIG" {
+			return false
+		}
+	}
+	return true
+}
+
+// s3CredentialsEnvVars returns the environment variables of s3-copy holding the
+// credentials of the S3-compatible storage, read from the keys of S3SecretName.
+func (b *ArtifactBucket) s3CredentialsEnvVars() []corev1.EnvVar {
+	if b.S3SecretName == "" {
+		return nil
+	}
+	var envVars []corev1.EnvVar
+	optional := true
+	for _, e := range []struct {
+		name, key string
+		optional  *bool
+	}{
+		{"AWS_ACCESS_KEY_ID", objectstore.AccessKeyIDKey, nil},
+		{"AWS_SECRET_ACCESS_KEY", objectstore.SecretAccessKeyKey, nil},
+		{"AWS_SESSION_TOKEN", objectstore.SessionTokenKey, &optional},
+	} {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: e.name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: b.S3SecretName},
+					Key:                  e.key,
+					Optional:             e.optional,
+				},
+			},
+		})
+	}
+	return envVars
+}
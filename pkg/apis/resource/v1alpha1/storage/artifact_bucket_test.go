@@ -102,3 +102,68 @@ func TestGetSecretsVolumes(t *testing.T) {
 		t.Errorf("Diff:\n%s", diff.PrintWantGot(d))
 	}
 }
+
+func TestS3BucketGetCopySteps(t *testing.T) {
+	s3Bucket := storage.ArtifactBucket{
+		Location:     "s3://fake-bucket",
+		S3Endpoint:   "http://minio.minio.svc:9000",
+		S3Region:     "us-east-1",
+		S3PathStyle:  true,
+		S3SecretName: "minio-credentials",
+		ShellImage:   "busybox",
+		S3CopyImage:  "override-with-s3-copy:latest",
+	}
+	optional := true
+	secretEnv := func(name, key string, optional *bool) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "minio-credentials"},
+					Key:                  key,
+					Optional:             optional,
+				},
+			},
+		}
+	}
+	env := []corev1.EnvVar{
+		secretEnv("AWS_ACCESS_KEY_ID", "access-key-id", nil),
+		secretEnv("AWS_SECRET_ACCESS_KEY", "secret-access-key", nil),
+		secretEnv("AWS_SESSION_TOKEN", "session-token", &optional),
+	}
+
+	names.TestingSeed()
+	wantFrom := []v1beta1.Step{{
+		Name:    "artifact-dest-mkdir-workspace-9l9zj",
+		Image:   "busybox",
+		Command: []string{"mkdir", "-p", "/workspace/destination"},
+	}, {
+		Name:    "artifact-copy-from-workspace-mz4c7",
+		Image:   "override-with-s3-copy:latest",
+		Command: []string{"/ko-app/s3-copy"},
+		Args:    []string{"-region", "us-east-1", "-endpoint", "http://minio.minio.svc:9000", "-r", "s3://fake-bucket/src-path", "/workspace/destination"},
+		Env:     env,
+	}}
+	if d := cmp.Diff(wantFrom, s3Bucket.GetCopyFromStorageToSteps("workspace", "src-path", "/workspace/destination")); d != "" {
+		t.Errorf("Copy from storage steps %s", diff.PrintWantGot(d))
+	}
+
+	names.TestingSeed()
+	wantTo := []v1beta1.Step{{
+		Name:    "artifact-copy-to-workspace-9l9zj",
+		Image:   "override-with-s3-copy:latest",
+		Command: []string{"/ko-app/s3-copy"},
+		Args:    []string{"-region", "us-east-1", "-endpoint", "http://minio.minio.svc:9000", "-r", "src-path", "s3://fake-bucket/workspace/destination"},
+		Env:     env,
+	}}
+	if d := cmp.Diff(wantTo, s3Bucket.GetCopyToStorageFromSteps("workspace", "src-path", "workspace/destination")); d != "" {
+		t.Errorf("Copy to storage steps %s", diff.PrintWantGot(d))
+	}
+
+	// The buckets configured with a boto configuration are still accessed with gsutil.
+	s3Bucket.GsutilImage = "gcr.io/google.com/cloudsdktool/cloud-sdk"
+	s3Bucket.Secrets = []resourcev1alpha1.SecretParam{{FieldName: "BOTO_CONFIG", SecretName: secretName, SecretKey: "boto"}}
+	if got := s3Bucket.GetCopyToStorageFromSteps("workspace", "src-path", "workspace/destination"); got[0].Image != s3Bucket.GsutilImage {
+		t.Errorf("Expected the artifacts to be copied with gsutil, got image %s", got[0].Image)
+	}
+}
@@ -40,6 +40,7 @@ var images = pipeline.Images{
 	GsutilImage:              "gcr.io/google.com/cloudsdktool/cloud-sdk",
 	PRImage:                  "override-with-pr:latest",
 	ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
+	S3CopyImage:              "override-with-s3-copy:latest",
 }
 
 func TestInvalidNewStorageResource(t *testing.T) {
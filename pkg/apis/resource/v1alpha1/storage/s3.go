@@ -0,0 +1,222 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/names"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultS3Region is the region of the S3Resources that don't specify one.
+	DefaultS3Region = "us-east-1"
+
+	s3CopyCommand = "/ko-app/s3-copy"
+)
+
+// s3CredentialsFields are the field names of the secrets of the S3Resources, which
+// are set as environment variables of the steps.
+var s3CredentialsFields = []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"}
+
+// S3Resource is an object, or a directory, of a bucket of an S3-compatible storage
+// such as AWS S3 or MinIO, from which to get artifacts which is required by a Task
+// for context, or to which to upload the artifacts produced by a Task.
+type S3Resource struct {
+	Name     string                                `json:"name"`
+	Type     resourcev1alpha1.PipelineResourceType `json:"type"`
+	Location string                                `json:"location"`
+	TypeDir  bool                                  `json:"typeDir"`
+	// Endpoint is the endpoint of the storage, defaulting to the AWS S3 endpoint of the Region.
+	Endpoint string `json:"endpoint"`
+	Region   string `json:"region"`
+	// PathStyle addresses the bucket in the path of the requests instead of in the host name.
+	PathStyle bool `json:"pathStyle"`
+	// Secret holds a struct to indicate a field name and corresponding secret name to populate it
+	Secrets []resourcev1alpha1.SecretParam `json:"secrets"`
+
+	ShellImage  string `json:"-"`
+	S3CopyImage string `json:"-"`
+}
+
+// NewS3Resource creates a new S3 resource to pass to a Task
+func NewS3Resource(name string, images pipeline.Images, r *resourcev1alpha1.PipelineResource) (*S3Resource, error) {
+	if r.Spec.Type != resourcev1alpha1.PipelineResourceTypeStorage {
+		return nil, fmt.Errorf("S3Resource: Cannot create an S3 resource from a %s Pipeline Resource", r.Spec.Type)
+	}
+	s := &S3Resource{
+		Name:        name,
+		Type:        r.Spec.Type,
+		Region:      DefaultS3Region,
+		PathStyle:   true,
+		Secrets:     r.Spec.SecretParams,
+		ShellImage:  images.ShellImage,
+		S3CopyImage: images.S3CopyImage,
+	}
+
+	for _, param := range r.Spec.Params {
+		switch {
+		case strings.EqualFold(param.Name, "Location"):
+			s.Location = param.Value
+		case strings.EqualFold(param.Name, "Dir"):
+			s.TypeDir = true // if dir flag is present then its a dir
+		case strings.EqualFold(param.Name, "Endpoint"):
+			s.Endpoint = param.Value
+		case strings.EqualFold(param.Name, "Region"):
+			if param.Value != "" {
+				s.Region = param.Value
+			}
+		case strings.EqualFold(param.Name, "PathStyle"):
+			pathStyle, err := strconv.ParseBool(param.Value)
+			if err != nil {
+				return nil, fmt.Errorf("S3Resource: Invalid PathStyle %q of S3 resource %s: %w", param.Value, r.Name, err)
+			}
+			s.PathStyle = pathStyle
+		}
+	}
+
+	if s.Location == "" {
+		return nil, fmt.Errorf("S3Resource: Need Location to be specified in order to create S3 resource %s", r.Name)
+	}
+	if u, err := url.Parse(s.Location); err != nil || u.Scheme != "s3" || u.Host == "" {
+		return nil, fmt.Errorf("S3Resource: Location of S3 resource %s must be an s3://bucket/key URL, got %q", r.Name, s.Location)
+	}
+	if s.Endpoint != "" {
+		if u, err := url.Parse(s.Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return nil, fmt.Errorf("S3Resource: Endpoint of S3 resource %s must be an http or https URL, got %q", r.Name, s.Endpoint)
+		}
+	}
+	return s, nil
+}
+
+// GetName returns the name of the resource
+func (s S3Resource) GetName() string {
+	return s.Name
+}
+
+// GetType returns the type of the resource, in this case "storage"
+func (s S3Resource) GetType() resourcev1alpha1.PipelineResourceType {
+	return resourcev1alpha1.PipelineResourceTypeStorage
+}
+
+// GetSecretParams returns the resource secret params
+func (s *S3Resource) GetSecretParams() []resourcev1alpha1.SecretParam { return s.Secrets }
+
+// Replacements is used for template replacement on an S3Resource inside of a Taskrun.
+func (s *S3Resource) Replacements() map[string]string {
+	return map[string]string{
+		"name":     s.Name,
+		"type":     s.Type,
+		"location": s.Location,
+		"endpoint": s.Endpoint,
+		"region":   s.Region,
+	}
+}
+
+// GetOutputTaskModifier returns the TaskModifier to be used when this resource is an output.
+func (s *S3Resource) GetOutputTaskModifier(ts *v1beta1.TaskSpec, sourcePath string) (v1beta1.TaskModifier, error) {
+	args := s3CopyArgs(s.Endpoint, s.Region, s.PathStyle)
+	if s.TypeDir {
+		args = append(args, "-r", sourcePath, s.Location)
+	} else {
+		// The file named after the object is uploaded.
+		args = append(args, filepath.Join(sourcePath, path.Base(s.Location)), s.Location)
+	}
+
+	step := v1beta1.Step{
+		Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("upload-%s", s.Name)),
+		Image:   s.S3CopyImage,
+		Command: []string{s3CopyCommand},
+		Args:    args,
+		Env:     getS3CredentialsEnvVars(s.Secrets),
+	}
+
+	return &v1beta1.InternalTaskModifier{
+		StepsToAppend: []v1beta1.Step{step},
+	}, nil
+}
+
+// GetInputTaskModifier returns the TaskModifier to be used when this resource is an input.
+func (s *S3Resource) GetInputTaskModifier(ts *v1beta1.TaskSpec, destinationPath string) (v1beta1.TaskModifier, error) {
+	if destinationPath == "" {
+		return nil, fmt.Errorf("S3Resource: Expect Destination Directory param to be set %s", s.Name)
+	}
+	args := s3CopyArgs(s.Endpoint, s.Region, s.PathStyle)
+	if s.TypeDir {
+		args = append(args, "-r")
+	}
+	args = append(args, s.Location, destinationPath)
+
+	steps := []v1beta1.Step{
+		CreateDirStep(s.ShellImage, s.Name, destinationPath),
+		{
+			Name:    names.SimpleNameGenerator.RestrictLengthWithRandomSuffix(fmt.Sprintf("fetch-%s", s.Name)),
+			Image:   s.S3CopyImage,
+			Command: []string{s3CopyCommand},
+			Args:    args,
+			Env:     getS3CredentialsEnvVars(s.Secrets),
+		},
+	}
+
+	return &v1beta1.InternalTaskModifier{
+		StepsToPrepend: steps,
+	}, nil
+}
+
+// s3CopyArgs returns the arguments of s3-copy to access the S3-compatible storage.
+func s3CopyArgs(endpoint, region string, pathStyle bool) []string {
+	args := []string{"-region", region}
+	if endpoint != "" {
+		args = append(args, "-endpoint", endpoint)
+	}
+	if !pathStyle {
+		args = append(args, "-path-style=false")
+	}
+	return args
+}
+
+// getS3CredentialsEnvVars returns the environment variables holding the credentials of the
+// S3-compatible storage, read from the keys of the secrets.
+func getS3CredentialsEnvVars(secrets []resourcev1alpha1.SecretParam) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+	for _, field := range s3CredentialsFields {
+		for _, secretParam := range secrets {
+			if secretParam.FieldName == field {
+				envVars = append(envVars, corev1.EnvVar{
+					Name: field,
+					ValueFrom: &corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: secretParam.SecretName},
+							Key:                  secretParam.SecretKey,
+						},
+					},
+				})
+				break
+			}
+		}
+	}
+	return envVars
+}
@@ -0,0 +1,262 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1/storage"
+	"github.com/tektoncd/pipeline/test/diff"
+	"github.com/tektoncd/pipeline/test/names"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var s3SecretParams = []resourcev1alpha1.SecretParam{{
+	FieldName:  "AWS_ACCESS_KEY_ID",
+	SecretKey:  "accesskey",
+	SecretName: "minio-credentials",
+}, {
+	FieldName:  "AWS_SECRET_ACCESS_KEY",
+	SecretKey:  "secretkey",
+	SecretName: "minio-credentials",
+}}
+
+var s3EnvVars = []corev1.EnvVar{{
+	Name: "AWS_ACCESS_KEY_ID",
+	ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "minio-credentials"},
+			Key:                  "accesskey",
+		},
+	},
+}, {
+	Name: "AWS_SECRET_ACCESS_KEY",
+	ValueFrom: &corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "minio-credentials"},
+			Key:                  "secretkey",
+		},
+	},
+}}
+
+func s3PipelineResource(params ...resourcev1alpha1.ResourceParam) *resourcev1alpha1.PipelineResource {
+	return &resourcev1alpha1.PipelineResource{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "s3-resource",
+		},
+		Spec: resourcev1alpha1.PipelineResourceSpec{
+			Type:         resourcev1alpha1.PipelineResourceTypeStorage,
+			Params:       append([]resourcev1alpha1.ResourceParam{{Name: "type", Value: "s3"}}, params...),
+			SecretParams: s3SecretParams,
+		},
+	}
+}
+
+func TestValidNewS3Resource(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		params []resourcev1alpha1.ResourceParam
+		want   *storage.S3Resource
+	}{{
+		name:   "defaults",
+		params: []resourcev1alpha1.ResourceParam{{Name: "Location", Value: "s3://fake-bucket/app.tar.gz"}},
+		want: &storage.S3Resource{
+			Name:        "test-resource",
+			Type:        resourcev1alpha1.PipelineResourceTypeStorage,
+			Location:    "s3://fake-bucket/app.tar.gz",
+			Region:      "us-east-1",
+			PathStyle:   true,
+			Secrets:     s3SecretParams,
+			ShellImage:  "busybox",
+			S3CopyImage: "override-with-s3-copy:latest",
+		},
+	}, {
+		name: "all set",
+		params: []resourcev1alpha1.ResourceParam{
+			{Name: "Location", Value: "s3://fake-bucket/sources"},
+			{Name: "dir", Value: "anything"},
+			{Name: "endpoint", Value: "http://minio.minio.svc:9000"},
+			{Name: "region", Value: "eu-west-1"},
+			{Name: "pathStyle", Value: "false"},
+		},
+		want: &storage.S3Resource{
+			Name:        "test-resource",
+			Type:        resourcev1alpha1.PipelineResourceTypeStorage,
+			Location:    "s3://fake-bucket/sources",
+			TypeDir:     true,
+			Endpoint:    "http://minio.minio.svc:9000",
+			Region:      "eu-west-1",
+			Secrets:     s3SecretParams,
+			ShellImage:  "busybox",
+			S3CopyImage: "override-with-s3-copy:latest",
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := storage.NewResource("test-resource", images, s3PipelineResource(tc.params...))
+			if err != nil {
+				t.Fatalf("Unexpected error creating S3 resource: %s", err)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Mismatch of S3 resource %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestInvalidNewS3Resource(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		params []resourcev1alpha1.ResourceParam
+	}{{
+		name: "no location",
+	}, {
+		name:   "location not an s3 url",
+		params: []resourcev1alpha1.ResourceParam{{Name: "Location", Value: "gs://fake-bucket"}},
+	}, {
+		name: "endpoint not an http url",
+		params: []resourcev1alpha1.ResourceParam{
+			{Name: "Location", Value: "s3://fake-bucket"},
+			{Name: "endpoint", Value: "minio:9000"},
+		},
+	}, {
+		name: "invalid path style",
+		params: []resourcev1alpha1.ResourceParam{
+			{Name: "Location", Value: "s3://fake-bucket"},
+			{Name: "pathStyle", Value: "sometimes"},
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := storage.NewS3Resource("test-resource", images, s3PipelineResource(tc.params...)); err == nil {
+				t.Error("Expected error creating S3 resource")
+			}
+		})
+	}
+}
+
+func TestS3GetInputTaskModifier(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		s3Resource *storage.S3Resource
+		wantArgs   []string
+	}{{
+		name: "file",
+		s3Resource: &storage.S3Resource{
+			Name:        "s3-valid",
+			Location:    "s3://some-bucket/app.tar.gz",
+			Region:      "us-east-1",
+			PathStyle:   true,
+			Secrets:     s3SecretParams,
+			ShellImage:  "busybox",
+			S3CopyImage: "override-with-s3-copy:latest",
+		},
+		wantArgs: []string{"-region", "us-east-1", "s3://some-bucket/app.tar.gz", "/workspace"},
+	}, {
+		name: "directory with endpoint",
+		s3Resource: &storage.S3Resource{
+			Name:        "s3-valid",
+			Location:    "s3://some-bucket/sources",
+			TypeDir:     true,
+			Endpoint:    "https://s3.example.com",
+			Region:      "eu-west-1",
+			Secrets:     s3SecretParams,
+			ShellImage:  "busybox",
+			S3CopyImage: "override-with-s3-copy:latest",
+		},
+		wantArgs: []string{"-region", "eu-west-1", "-endpoint", "https://s3.example.com", "-path-style=false", "-r", "s3://some-bucket/sources", "/workspace"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			ts := v1beta1.TaskSpec{}
+			got, err := tc.s3Resource.GetInputTaskModifier(&ts, "/workspace")
+			if err != nil {
+				t.Fatalf("GetInputTaskModifier() = %v", err)
+			}
+			want := []v1beta1.Step{{
+				Name:    "create-dir-s3-valid-9l9zj",
+				Image:   "busybox",
+				Command: []string{"mkdir", "-p", "/workspace"},
+			}, {
+				Name:    "fetch-s3-valid-mz4c7",
+				Image:   "override-with-s3-copy:latest",
+				Command: []string{"/ko-app/s3-copy"},
+				Args:    tc.wantArgs,
+				Env:     s3EnvVars,
+			}}
+			if d := cmp.Diff(want, got.GetStepsToPrepend()); d != "" {
+				t.Errorf("Error mismatch between download containers spec %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
+func TestS3GetOutputTaskModifier(t *testing.T) {
+	for _, tc := range []struct {
+		name       string
+		s3Resource *storage.S3Resource
+		wantArgs   []string
+	}{{
+		name: "file",
+		s3Resource: &storage.S3Resource{
+			Name:        "s3-valid",
+			Location:    "s3://some-bucket/app.tar.gz",
+			Region:      "us-east-1",
+			PathStyle:   true,
+			Secrets:     s3SecretParams,
+			S3CopyImage: "override-with-s3-copy:latest",
+		},
+		wantArgs: []string{"-region", "us-east-1", "/workspace/output/app.tar.gz", "s3://some-bucket/app.tar.gz"},
+	}, {
+		name: "directory",
+		s3Resource: &storage.S3Resource{
+			Name:        "s3-valid",
+			Location:    "s3://some-bucket/sources",
+			TypeDir:     true,
+			Endpoint:    "http://minio.minio.svc:9000",
+			Region:      "us-east-1",
+			PathStyle:   true,
+			Secrets:     s3SecretParams,
+			S3CopyImage: "override-with-s3-copy:latest",
+		},
+		wantArgs: []string{"-region", "us-east-1", "-endpoint", "http://minio.minio.svc:9000", "-r", "/workspace/output", "s3://some-bucket/sources"},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			names.TestingSeed()
+			ts := v1beta1.TaskSpec{}
+			got, err := tc.s3Resource.GetOutputTaskModifier(&ts, "/workspace/output")
+			if err != nil {
+				t.Fatalf("GetOutputTaskModifier() = %v", err)
+			}
+			want := []v1beta1.Step{{
+				Name:    "upload-s3-valid-9l9zj",
+				Image:   "override-with-s3-copy:latest",
+				Command: []string{"/ko-app/s3-copy"},
+				Args:    tc.wantArgs,
+				Env:     s3EnvVars,
+			}}
+			if d := cmp.Diff(want, got.GetStepsToAppend()); d != "" {
+				t.Errorf("Error mismatch between upload containers spec %s", diff.PrintWantGot(d))
+			}
+			if len(got.GetVolumes()) != 0 {
+				t.Errorf("Expected no volumes, got %v", got.GetVolumes())
+			}
+		})
+	}
+}
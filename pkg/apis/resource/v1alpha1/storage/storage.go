@@ -46,6 +46,9 @@ func NewResource(name string, images pipeline.Images, r *resource.PipelineResour
 			if strings.EqualFold(param.Value, resource.PipelineResourceTypeGCS) {
 				return NewGCSResource(name, images, r)
 			}
+			if strings.EqualFold(param.Value, resource.PipelineResourceTypeS3) {
+				return NewS3Resource(name, images, r)
+			}
 			return nil, fmt.Errorf("%s is an invalid or unimplemented PipelineStorageResource", param.Value)
 		}
 	}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"encoding/json"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SerializeToMetadata serializes the input field and adds it as an annotation to
+// the metadata under the input key. The annotations map is copied before it is
+// modified, so that metadata shared with the conversion source is left untouched.
+func SerializeToMetadata(meta *metav1.ObjectMeta, field interface{}, key string) error {
+	bytes, err := json.Marshal(field)
+	if err != nil {
+		return fmt.Errorf("error serializing field: %w", err)
+	}
+	annotations := make(map[string]string, len(meta.Annotations)+1)
+	for k, v := range meta.Annotations {
+		annotations[k] = v
+	}
+	annotations[key] = string(bytes)
+	meta.Annotations = annotations
+	return nil
+}
+
+// DeserializeFromMetadata takes the value of the input key from the metadata's annotations,
+// deserializes it into "to", and removes the key from the metadata's annotations.
+// It is a no-op if the key is not present.
+func DeserializeFromMetadata(meta *metav1.ObjectMeta, to interface{}, key string) error {
+	str, ok := meta.Annotations[key]
+	if !ok {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(str), to); err != nil {
+		return fmt.Errorf("error deserializing key %s from metadata: %w", key, err)
+	}
+	var annotations map[string]string
+	for k, v := range meta.Annotations {
+		if k == key {
+			continue
+		}
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[k] = v
+	}
+	meta.Annotations = annotations
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package version
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/test/diff"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type testStruct struct {
+	Field string `json:"field"`
+}
+
+func TestSerializationRoundTrip(t *testing.T) {
+	original := map[string]string{"foo": "bar"}
+	meta := metav1.ObjectMeta{Annotations: original}
+	source := testStruct{Field: "value"}
+	key := "my-key"
+
+	if err := SerializeToMetadata(&meta, source, key); err != nil {
+		t.Fatalf("Serialization error: %s", err)
+	}
+	if got, want := meta.Annotations[key], `{"field":"value"}`; got != want {
+		t.Errorf("Expected annotation %q but got %q", want, got)
+	}
+	if _, ok := original[key]; ok {
+		t.Errorf("Expected original annotations to be left unmodified but got %v", original)
+	}
+
+	sink := &testStruct{}
+	if err := DeserializeFromMetadata(&meta, sink, key); err != nil {
+		t.Fatalf("Deserialization error: %s", err)
+	}
+	if d := cmp.Diff(&source, sink); d != "" {
+		t.Errorf("Unexpected deserialized value %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(original, meta.Annotations); d != "" {
+		t.Errorf("Unexpected annotations after deserialization %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestDeserializeFromMetadata(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		meta    metav1.ObjectMeta
+		want    *testStruct
+		wantErr bool
+	}{{
+		name: "no annotations",
+		want: &testStruct{},
+	}, {
+		name: "missing key",
+		meta: metav1.ObjectMeta{Annotations: map[string]string{"other": "value"}},
+		want: &testStruct{},
+	}, {
+		name: "only key",
+		meta: metav1.ObjectMeta{Annotations: map[string]string{"my-key": `{"field":"value"}`}},
+		want: &testStruct{Field: "value"},
+	}, {
+		name:    "invalid value",
+		meta:    metav1.ObjectMeta{Annotations: map[string]string{"my-key": "not-json"}},
+		want:    &testStruct{},
+		wantErr: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := &testStruct{}
+			err := DeserializeFromMetadata(&tc.meta, got, "my-key")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("DeserializeFromMetadata() error = %v, wantErr %t", err, tc.wantErr)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected deserialized value %s", diff.PrintWantGot(d))
+			}
+			if _, ok := tc.meta.Annotations["my-key"]; ok && !tc.wantErr {
+				t.Errorf("Expected key to be removed from annotations, got %v", tc.meta.Annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// StatusAnnotationKey is the annotation recording whether the record of a
+	// finished run was archived.
+	StatusAnnotationKey = pipeline.GroupName + "/archiveStatus"
+	// LocationAnnotationKey is the annotation recording the URL the record of a
+	// finished run was archived to.
+	LocationAnnotationKey = pipeline.GroupName + "/archiveLocation"
+
+	// StatusArchived indicates that the record of the run was archived.
+	StatusArchived = "Archived"
+	// StatusFailed indicates that the record of the run couldn't be archived,
+	// archiving it will be retried.
+	StatusFailed = "Failed"
+)
+
+// Archiver writes the records of finished runs to an external storage.
+type Archiver interface {
+	// Write stores the JSON record under the given key, and returns the URL
+	// of the stored record.
+	Write(ctx context.Context, key string, record []byte) (string, error)
+}
+
+// Factory returns the Archiver writing to the location configured in
+// config-archive, parsed as the given URL.
+type Factory func(ctx context.Context, kubeclient kubernetes.Interface, cfg *config.Archive, location *url.URL) (Archiver, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]Factory{
+		"file": newFileArchiver,
+		"s3":   newS3Archiver,
+	}
+)
+
+// Register makes the Archiver returned by the factory available for the
+// locations with the given URL scheme, replacing any Archiver registered
+// for this scheme.
+func Register(scheme string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[scheme] = factory
+}
+
+// New returns the Archiver for the location configured in config-archive.
+func New(ctx context.Context, kubeclient kubernetes.Interface, cfg *config.Archive) (Archiver, error) {
+	location, err := url.Parse(cfg.Location)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive location %q: %w", cfg.Location, err)
+	}
+	factoriesMu.RLock()
+	factory, ok := factories[location.Scheme]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("no archiver is registered for the archive location %q", cfg.Location)
+	}
+	return factory(ctx, kubeclient, cfg, location)
+}
+
+// Object is a run whose record can be archived.
+type Object interface {
+	metav1.Object
+	runtime.Object
+}
+
+// IsArchived returns true if the record of the run was archived.
+func IsArchived(obj metav1.Object) bool {
+	return obj.GetAnnotations()[StatusAnnotationKey] == StatusArchived
+}
+
+// Run writes the record of the finished run, including its full status, with the
+// Archiver, and records the outcome in the annotations of the run.
+func Run(ctx context.Context, a Archiver, obj Object, gvk schema.GroupVersionKind) error {
+	// Objects from listers don't have their TypeMeta set.
+	record := obj.DeepCopyObject()
+	record.GetObjectKind().SetGroupVersionKind(gvk)
+	b, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the record of %s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+
+	location, err := a.Write(ctx, Key(obj, gvk), b)
+	if err != nil {
+		setAnnotation(obj, StatusAnnotationKey, StatusFailed)
+		return fmt.Errorf("failed to archive the record of %s %s: %w", gvk.Kind, obj.GetName(), err)
+	}
+	setAnnotation(obj, StatusAnnotationKey, StatusArchived)
+	setAnnotation(obj, LocationAnnotationKey, location)
+	return nil
+}
+
+// Key returns the key the record of the run is stored under, unique across the
+// runs of the cluster: <namespace>/<kind>/<name>-<uid>.json
+func Key(obj metav1.Object, gvk schema.GroupVersionKind) string {
+	return path.Join(obj.GetNamespace(), strings.ToLower(gvk.Kind), fmt.Sprintf("%s-%s.json", obj.GetName(), obj.GetUID()))
+}
+
+func setAnnotation(obj metav1.Object, key, value string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[key] = value
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,144 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/archive"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+type fakeArchiver struct {
+	records map[string][]byte
+	err     error
+}
+
+func (a *fakeArchiver) Write(_ context.Context, key string, record []byte) (string, error) {
+	if a.err != nil {
+		return "", a.err
+	}
+	a.records[key] = record
+	return "fake://" + key, nil
+}
+
+func finishedPipelineRun() *v1beta1.PipelineRun {
+	return &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pr", Namespace: "foo", UID: "1234"},
+		Status: v1beta1.PipelineRunStatus{
+			Status: duckv1beta1.Status{
+				Conditions: duckv1beta1.Conditions{{
+					Type:   apis.ConditionSucceeded,
+					Status: corev1.ConditionTrue,
+				}},
+			},
+			PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineResults: []v1beta1.PipelineRunResult{{
+					Name:  "digest",
+					Value: *v1beta1.NewArrayOrString("sha256:abc"),
+				}},
+			},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	pr := finishedPipelineRun()
+	a := &fakeArchiver{records: map[string][]byte{}}
+
+	if err := archive.Run(context.Background(), a, pr, v1beta1.SchemeGroupVersion.WithKind("PipelineRun")); err != nil {
+		t.Fatalf("Run() = %v", err)
+	}
+
+	wantAnnotations := map[string]string{
+		archive.StatusAnnotationKey:   archive.StatusArchived,
+		archive.LocationAnnotationKey: "fake://foo/pipelinerun/pr-1234.json",
+	}
+	if d := cmp.Diff(wantAnnotations, pr.Annotations); d != "" {
+		t.Errorf("Unexpected annotations %s", diff.PrintWantGot(d))
+	}
+	if !archive.IsArchived(pr) {
+		t.Error("expected the PipelineRun to be archived")
+	}
+
+	var got v1beta1.PipelineRun
+	if err := json.Unmarshal(a.records["foo/pipelinerun/pr-1234.json"], &got); err != nil {
+		t.Fatalf("Unmarshalling the record: %v", err)
+	}
+	want := finishedPipelineRun()
+	want.TypeMeta = metav1.TypeMeta{APIVersion: "tekton.dev/v1beta1", Kind: "PipelineRun"}
+	if d := cmp.Diff(want, &got); d != "" {
+		t.Errorf("Unexpected record %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestRunFailure(t *testing.T) {
+	pr := finishedPipelineRun()
+	a := &fakeArchiver{err: errors.New("storage unavailable")}
+
+	if err := archive.Run(context.Background(), a, pr, v1beta1.SchemeGroupVersion.WithKind("PipelineRun")); err == nil {
+		t.Fatal("expected an error but got nil")
+	}
+	if d := cmp.Diff(map[string]string{archive.StatusAnnotationKey: archive.StatusFailed}, pr.Annotations); d != "" {
+		t.Errorf("Unexpected annotations %s", diff.PrintWantGot(d))
+	}
+	if archive.IsArchived(pr) {
+		t.Error("expected the PipelineRun not to be archived")
+	}
+}
+
+func TestNew(t *testing.T) {
+	archive.Register("fake", func(context.Context, kubernetes.Interface, *config.Archive, *url.URL) (archive.Archiver, error) {
+		return &fakeArchiver{}, nil
+	})
+
+	for _, tc := range []struct {
+		location string
+		wantErr  bool
+	}{{
+		location: "fake://somewhere",
+	}, {
+		location: "file:///var/run/tekton/archive",
+	}, {
+		location: "s3://tekton-archive/runs",
+	}, {
+		location: "gs://tekton-archive/runs",
+		wantErr:  true,
+	}, {
+		location: "file://",
+		wantErr:  true,
+	}} {
+		t.Run(tc.location, func(t *testing.T) {
+			_, err := archive.New(context.Background(), nil, &config.Archive{Location: tc.location, Region: config.DefaultArchiveRegion})
+			if (err != nil) != tc.wantErr {
+				t.Errorf("New() = %v, expected error %t", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"k8s.io/client-go/kubernetes"
+)
+
+// fileArchiver writes the records to a directory, typically on a volume
+// mounted in the controller.
+type fileArchiver struct {
+	dir string
+}
+
+func newFileArchiver(_ context.Context, _ kubernetes.Interface, _ *config.Archive, location *url.URL) (Archiver, error) {
+	if location.Path == "" {
+		return nil, fmt.Errorf("the archive location %q has no path", location.String())
+	}
+	return &fileArchiver{dir: location.Path}, nil
+}
+
+// Write implements Archiver.
+func (a *fileArchiver) Write(_ context.Context, key string, record []byte) (string, error) {
+	p := filepath.Join(a.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return "", err
+	}
+	// Write to a temporary file first so that a record is never partially written.
+	f, err := os.CreateTemp(filepath.Dir(p), ".archive-")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(record); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(f.Name(), p); err != nil {
+		return "", err
+	}
+	return (&url.URL{Scheme: "file", Path: p}).String(), nil
+}
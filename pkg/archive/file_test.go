@@ -0,0 +1,62 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+)
+
+func TestFileArchiverWrite(t *testing.T) {
+	dir := t.TempDir()
+	location := &url.URL{Scheme: "file", Path: dir}
+	a, err := newFileArchiver(context.Background(), nil, &config.Archive{Location: location.String()}, location)
+	if err != nil {
+		t.Fatalf("newFileArchiver() = %v", err)
+	}
+
+	for _, record := range []string{`{"first":true}`, `{"second":true}`} {
+		got, err := a.Write(context.Background(), "foo/taskrun/tr-1234.json", []byte(record))
+		if err != nil {
+			t.Fatalf("Write() = %v", err)
+		}
+		p := filepath.Join(dir, "foo", "taskrun", "tr-1234.json")
+		if want := "file://" + p; got != want {
+			t.Errorf("Write() = %q, want %q", got, want)
+		}
+		b, err := os.ReadFile(p)
+		if err != nil {
+			t.Fatalf("Reading the record: %v", err)
+		}
+		if string(b) != record {
+			t.Errorf("Expected the record %s, got %s", record, b)
+		}
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, "foo", "taskrun"))
+	if err != nil {
+		t.Fatalf("Reading the archive: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Expected only the record in the archive, got %d files", len(entries))
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/internal/objectstore"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/system"
+)
+
+const (
+	// AccessKeyIDKey is the key of the access key ID in the secret holding the
+	// credentials of the S3-compatible storage.
+	AccessKeyIDKey = objectstore.AccessKeyIDKey
+	// SecretAccessKeyKey is the key of the secret access key in the secret holding
+	// the credentials of the S3-compatible storage.
+	SecretAccessKeyKey = objectstore.SecretAccessKeyKey
+	// SessionTokenKey is the key of the optional session token in the secret holding
+	// the credentials of the S3-compatible storage.
+	SessionTokenKey = objectstore.SessionTokenKey
+)
+
+// s3Archiver writes the records to a bucket of an S3-compatible storage, such as
+// AWS S3 or MinIO.
+type s3Archiver struct {
+	client *objectstore.S3Client
+	bucket string
+	prefix string
+}
+
+func newS3Archiver(ctx context.Context, kubeclient kubernetes.Interface, cfg *config.Archive, location *url.URL) (Archiver, error) {
+	if location.Host == "" {
+		return nil, fmt.Errorf("the archive location %q has no bucket", location.String())
+	}
+	var credentials *aws.Credentials
+	if cfg.SecretName != "" {
+		secret, err := kubeclient.CoreV1().Secrets(system.Namespace()).Get(ctx, cfg.SecretName, metav1.GetOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the archive credentials secret %q: %w", cfg.SecretName, err)
+		}
+		credentials = &aws.Credentials{
+			AccessKeyID:     string(secret.Data[AccessKeyIDKey]),
+			SecretAccessKey: string(secret.Data[SecretAccessKeyKey]),
+			SessionToken:    string(secret.Data[SessionTokenKey]),
+		}
+	}
+	client, err := objectstore.NewS3Client(cfg.Endpoint, cfg.Region, credentials)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Archiver{
+		client: client,
+		bucket: location.Host,
+		prefix: strings.Trim(location.Path, "/"),
+	}, nil
+}
+
+// Write implements Archiver.
+func (a *s3Archiver) Write(ctx context.Context, key string, record []byte) (string, error) {
+	return a.client.Put(ctx, a.bucket, path.Join(a.prefix, key), bytes.NewReader(record), "application/json")
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakek8s "k8s.io/client-go/kubernetes/fake"
+	"knative.dev/pkg/system"
+	_ "knative.dev/pkg/system/testing" // Setup system.Namespace()
+)
+
+func TestS3ArchiverWrite(t *testing.T) {
+	var gotPath, gotAuthorization, gotBody string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected a PUT request, got %s", r.Method)
+		}
+		b, _ := io.ReadAll(r.Body)
+		gotPath, gotAuthorization, gotBody = r.URL.Path, r.Header.Get("Authorization"), string(b)
+	}))
+	defer server.Close()
+
+	kubeclient := fakek8s.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "archive-credentials", Namespace: system.Namespace()},
+		Data: map[string][]byte{
+			AccessKeyIDKey:     []byte("minio"),
+			SecretAccessKeyKey: []byte("minio123"),
+		},
+	})
+	cfg := &config.Archive{
+		Location:   "s3://tekton-archive/runs/",
+		Endpoint:   server.URL,
+		Region:     config.DefaultArchiveRegion,
+		SecretName: "archive-credentials",
+	}
+	location, _ := url.Parse(cfg.Location)
+	a, err := newS3Archiver(context.Background(), kubeclient, cfg, location)
+	if err != nil {
+		t.Fatalf("newS3Archiver() = %v", err)
+	}
+
+	got, err := a.Write(context.Background(), "foo/pipelinerun/pr-1234.json", []byte(`{"kind":"PipelineRun"}`))
+	if err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+	if want := "s3://tekton-archive/runs/foo/pipelinerun/pr-1234.json"; got != want {
+		t.Errorf("Write() = %q, want %q", got, want)
+	}
+	if want := "/tekton-archive/runs/foo/pipelinerun/pr-1234.json"; gotPath != want {
+		t.Errorf("Expected the record to be stored at %q, got %q", want, gotPath)
+	}
+	if !strings.HasPrefix(gotAuthorization, "AWS4-HMAC-SHA256 Credential=minio/") {
+		t.Errorf("Expected the request to be signed with the access key ID, got %q", gotAuthorization)
+	}
+	if gotBody != `{"kind":"PipelineRun"}` {
+		t.Errorf("Unexpected record %s", gotBody)
+	}
+}
+
+func TestS3ArchiverWriteError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		io.WriteString(w, "<Error><Code>AccessDenied</Code></Error>")
+	}))
+	defer server.Close()
+
+	cfg := &config.Archive{Location: "s3://tekton-archive", Endpoint: server.URL, Region: config.DefaultArchiveRegion}
+	location, _ := url.Parse(cfg.Location)
+	a, err := newS3Archiver(context.Background(), fakek8s.NewSimpleClientset(), cfg, location)
+	if err != nil {
+		t.Fatalf("newS3Archiver() = %v", err)
+	}
+	_, err = a.Write(context.Background(), "foo/taskrun/tr-1234.json", []byte(`{}`))
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Errorf("Expected an AccessDenied error, got %v", err)
+	}
+}
+
+func TestNewS3ArchiverMissingSecret(t *testing.T) {
+	cfg := &config.Archive{Location: "s3://tekton-archive", Region: config.DefaultArchiveRegion, SecretName: "missing"}
+	location, _ := url.Parse(cfg.Location)
+	if _, err := newS3Archiver(context.Background(), fakek8s.NewSimpleClientset(), cfg, location); err == nil {
+		t.Error("expected an error but got nil")
+	}
+}
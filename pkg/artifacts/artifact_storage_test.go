@@ -46,6 +46,7 @@ var (
 		GsutilImage:              "gcr.io/google.com/cloudsdktool/cloud-sdk",
 		PRImage:                  "override-with-pr:latest",
 		ImageDigestExporterImage: "override-with-imagedigest-exporter-image:latest",
+		S3CopyImage:              "override-with-s3-copy:latest",
 	}
 	pipelinerun = &v1beta1.PipelineRun{
 		ObjectMeta: metav1.ObjectMeta{
@@ -211,6 +212,7 @@ func TestInitializeArtifactStorage(t *testing.T) {
 			}},
 			ShellImage:  "busybox",
 			GsutilImage: "gcr.io/google.com/cloudsdktool/cloud-sdk",
+			S3CopyImage: "override-with-s3-copy:latest",
 		},
 	}, {
 		desc: "location empty",
@@ -256,6 +258,7 @@ func TestInitializeArtifactStorage(t *testing.T) {
 			Location:    "gs://fake-bucket",
 			ShellImage:  "busybox",
 			GsutilImage: "gcr.io/google.com/cloudsdktool/cloud-sdk",
+			S3CopyImage: "override-with-s3-copy:latest",
 		},
 	}, {
 		desc: "valid bucket with boto config",
@@ -268,14 +271,35 @@ func TestInitializeArtifactStorage(t *testing.T) {
 		storagetype: "bucket",
 		expectedArtifactStorage: &storage.ArtifactBucket{
 			Location:    "s3://fake-bucket",
+			S3Region:    "us-east-1",
+			S3PathStyle: true,
 			ShellImage:  "busybox",
 			GsutilImage: "gcr.io/google.com/cloudsdktool/cloud-sdk",
+			S3CopyImage: "override-with-s3-copy:latest",
 			Secrets: []resourcev1alpha1.SecretParam{{
 				FieldName:  "BOTO_CONFIG",
 				SecretKey:  "sakey",
 				SecretName: "secret1",
 			}},
 		},
+	}, {
+		desc: "valid s3 bucket",
+		storageConfig: map[string]string{
+			config.BucketLocationKey:     "s3://fake-bucket",
+			config.BucketS3EndpointKey:   "http://minio.minio.svc:9000",
+			config.BucketS3SecretNameKey: "minio-credentials",
+		},
+		storagetype: "bucket",
+		expectedArtifactStorage: &storage.ArtifactBucket{
+			Location:     "s3://fake-bucket",
+			S3Endpoint:   "http://minio.minio.svc:9000",
+			S3Region:     "us-east-1",
+			S3PathStyle:  true,
+			S3SecretName: "minio-credentials",
+			ShellImage:   "busybox",
+			GsutilImage:  "gcr.io/google.com/cloudsdktool/cloud-sdk",
+			S3CopyImage:  "override-with-s3-copy:latest",
+		},
 	}} {
 		t.Run(c.desc, func(t *testing.T) {
 			fakekubeclient := fakek8s.NewSimpleClientset()
@@ -524,6 +548,7 @@ func TestGetArtifactStorageWithConfig(t *testing.T) {
 			}},
 			ShellImage:  "busybox",
 			GsutilImage: "gcr.io/google.com/cloudsdktool/cloud-sdk",
+			S3CopyImage: "override-with-s3-copy:latest",
 		},
 	}, {
 		desc: "location empty",
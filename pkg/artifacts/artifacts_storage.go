@@ -157,10 +157,17 @@ func newArtifactBucketFromConfig(ctx context.Context, images pipeline.Images) *s
 	c := &storage.ArtifactBucket{
 		ShellImage:  images.ShellImage,
 		GsutilImage: images.GsutilImage,
+		S3CopyImage: images.S3CopyImage,
 	}
 
 	bucketConfig := config.FromContextOrDefaults(ctx).ArtifactBucket
 	c.Location = bucketConfig.Location
+	if strings.HasPrefix(c.Location, "s3://") {
+		c.S3Endpoint = bucketConfig.S3Endpoint
+		c.S3Region = bucketConfig.S3Region
+		c.S3PathStyle = bucketConfig.S3PathStyle
+		c.S3SecretName = bucketConfig.S3SecretName
+	}
 	sp := resourcev1alpha1.SecretParam{}
 	if bucketConfig.ServiceAccountSecretName != "" && bucketConfig.ServiceAccountSecretKey != "" {
 		sp.SecretName = bucketConfig.ServiceAccountSecretName
@@ -28,10 +28,22 @@ type FakeTektonV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeTektonV1) Pipelines(namespace string) v1.PipelineInterface {
+	return &FakePipelines{c, namespace}
+}
+
+func (c *FakeTektonV1) PipelineRuns(namespace string) v1.PipelineRunInterface {
+	return &FakePipelineRuns{c, namespace}
+}
+
 func (c *FakeTektonV1) Tasks(namespace string) v1.TaskInterface {
 	return &FakeTasks{c, namespace}
 }
 
+func (c *FakeTektonV1) TaskRuns(namespace string) v1.TaskRunInterface {
+	return &FakeTaskRuns{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeTektonV1) RESTClient() rest.Interface {
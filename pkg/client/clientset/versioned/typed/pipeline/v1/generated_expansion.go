@@ -18,4 +18,10 @@ limitations under the License.
 
 package v1
 
+type PipelineExpansion interface{}
+
+type PipelineRunExpansion interface{}
+
 type TaskExpansion interface{}
+
+type TaskRunExpansion interface{}
@@ -24,6 +24,8 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
@@ -31,11 +33,31 @@ import (
 	"github.com/tektoncd/pipeline/pkg/credentials"
 )
 
-const annotationPrefix = "tekton.dev/docker-"
+const (
+	annotationPrefix = "tekton.dev/docker-"
+
+	// credHelperAnnotationPrefix is the prefix of the annotations whose value is a
+	// registry=helper pair, the credentials of the registry being provided by the
+	// docker-credential-<helper> binary of the image of the Step.
+	credHelperAnnotationPrefix = annotationPrefix + "cred-helper-"
+	// credsStoreAnnotation is the annotation whose value is the helper providing the
+	// credentials of the registries without other credentials.
+	credsStoreAnnotation = annotationPrefix + "creds-store"
+	// mirrorAnnotationPrefix is the prefix of the annotations whose value is a
+	// registry=mirror pair, the credentials of the registry being used for the mirror.
+	mirrorAnnotationPrefix = annotationPrefix + "mirror-"
+)
+
+// helperNameRegex matches the names of the credential helpers, which are the suffixes
+// of the docker-credential-<helper> binaries.
+var helperNameRegex = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
 
 var config basicDocker
 var dockerConfig arrayArg
 var dockerCfg arrayArg
+var credHelpers credHelpersArg
+var credsStore credsStoreArg
+var mirrors mirrorsArg
 
 // AddFlags adds CLI flags that dockercreds supports to a given flag.FlagSet.
 func AddFlags(flagSet *flag.FlagSet) {
@@ -46,9 +68,15 @@ func flags(fs *flag.FlagSet) {
 	config = basicDocker{make(map[string]entry)}
 	dockerConfig = arrayArg{[]string{}}
 	dockerCfg = arrayArg{[]string{}}
+	credHelpers = credHelpersArg{make(map[string]string)}
+	credsStore = credsStoreArg{}
+	mirrors = mirrorsArg{}
 	fs.Var(&config, "basic-docker", "List of secret=url pairs.")
 	fs.Var(&dockerConfig, "docker-config", "Docker config.json secret file.")
 	fs.Var(&dockerCfg, "docker-cfg", "Docker .dockercfg secret file.")
+	fs.Var(&credHelpers, "docker-cred-helper", "List of registry=helper pairs.")
+	fs.Var(&credsStore, "docker-creds-store", "Credential helper of the registries without other credentials.")
+	fs.Var(&mirrors, "docker-mirror", "List of registry=mirror pairs.")
 }
 
 // As the flag is read, this status is populated.
@@ -104,8 +132,102 @@ func (aa *arrayArg) String() string {
 	return strings.Join(aa.Values, ",")
 }
 
+// credHelpersArg implements flag.Value for the registry=helper pairs.
+type credHelpersArg struct {
+	Helpers map[string]string
+}
+
+// Set sets the credential helper of a registry from a value in the format of "registry=helper"
+func (ch *credHelpersArg) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" {
+		return fmt.Errorf("expect entries of the form registry=helper, got: %v", value)
+	}
+	registry, helper := parts[0], parts[1]
+	if !helperNameRegex.MatchString(helper) {
+		return fmt.Errorf("invalid credential helper name %q for registry %v", helper, registry)
+	}
+	if h, ok := ch.Helpers[registry]; ok && h != helper {
+		return fmt.Errorf("multiple credential helpers for registry: %v", registry)
+	}
+	ch.Helpers[registry] = helper
+	return nil
+}
+
+func (ch *credHelpersArg) String() string {
+	if ch == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	var pairs []string
+	for k, v := range ch.Helpers {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", k, v))
+	}
+	return strings.Join(pairs, ",")
+}
+
+// credsStoreArg implements flag.Value for the credential helper of the registries
+// without other credentials.
+type credsStoreArg struct {
+	Helper string
+}
+
+// Set sets the credential helper, which can't be set to different helpers.
+func (cs *credsStoreArg) Set(value string) error {
+	if !helperNameRegex.MatchString(value) {
+		return fmt.Errorf("invalid credential helper name %q", value)
+	}
+	if cs.Helper != "" && cs.Helper != value {
+		return fmt.Errorf("multiple credential stores: %v and %v", cs.Helper, value)
+	}
+	cs.Helper = value
+	return nil
+}
+
+func (cs *credsStoreArg) String() string {
+	if cs == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	return cs.Helper
+}
+
+type mirror struct {
+	Registry string
+	Mirror   string
+}
+
+// mirrorsArg implements flag.Value for the registry=mirror pairs.
+type mirrorsArg struct {
+	Mirrors []mirror
+}
+
+// Set adds a mirror of a registry from a value in the format of "registry=mirror"
+func (ma *mirrorsArg) Set(value string) error {
+	parts := strings.SplitN(value, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("expect entries of the form registry=mirror, got: %v", value)
+	}
+	ma.Mirrors = append(ma.Mirrors, mirror{Registry: parts[0], Mirror: parts[1]})
+	return nil
+}
+
+func (ma *mirrorsArg) String() string {
+	if ma == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	var pairs []string
+	for _, m := range ma.Mirrors {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", m.Registry, m.Mirror))
+	}
+	return strings.Join(pairs, ",")
+}
+
 type configFile struct {
-	Auth map[string]entry `json:"auths"`
+	Auth        map[string]entry  `json:"auths"`
+	CredHelpers map[string]string `json:"credHelpers,omitempty"`
+	CredsStore  string            `json:"credsStore,omitempty"`
 }
 
 type entry struct {
@@ -152,20 +274,49 @@ func (*basicDockerBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
 	var flags []string
 	switch secret.Type {
 	case corev1.SecretTypeBasicAuth:
-		for _, v := range credentials.SortAnnotations(secret.Annotations, annotationPrefix) {
+		for _, v := range credentials.SortAnnotations(urlAnnotations(secret.Annotations), annotationPrefix) {
 			flags = append(flags, fmt.Sprintf("-basic-docker=%s=%s", secret.Name, v))
 		}
 	case corev1.SecretTypeDockerConfigJson:
 		flags = append(flags, fmt.Sprintf("-docker-config=%s", secret.Name))
 	case corev1.SecretTypeDockercfg:
 		flags = append(flags, fmt.Sprintf("-docker-cfg=%s", secret.Name))
-	default:
-		return flags
 	}
 
 	return flags
 }
 
+// HelperAnnotations extracts flags for the credential helpers, the
+// credential store and the registry mirrors from the supplied
+// annotations of a secret, of any type, or of a service account.
+// These flags don't need the secret to be mounted.
+func HelperAnnotations(annotations map[string]string) []string {
+	var flags []string
+	for _, v := range credentials.SortAnnotations(annotations, credHelperAnnotationPrefix) {
+		flags = append(flags, fmt.Sprintf("-docker-cred-helper=%s", v))
+	}
+	if v, ok := annotations[credsStoreAnnotation]; ok {
+		flags = append(flags, fmt.Sprintf("-docker-creds-store=%s", v))
+	}
+	for _, v := range credentials.SortAnnotations(annotations, mirrorAnnotationPrefix) {
+		flags = append(flags, fmt.Sprintf("-docker-mirror=%s", v))
+	}
+	return flags
+}
+
+// urlAnnotations returns the annotations whose value is the URL of a registry,
+// without the annotations of the credential helpers and the mirrors.
+func urlAnnotations(annotations map[string]string) map[string]string {
+	m := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if strings.HasPrefix(k, credHelperAnnotationPrefix) || k == credsStoreAnnotation || strings.HasPrefix(k, mirrorAnnotationPrefix) {
+			continue
+		}
+		m[k] = v
+	}
+	return m
+}
+
 // Write builds a .docker/config.json file from a combination
 // of kubernetes docker registry secrets and tekton docker
 // secret entries and writes it to the given directory. If
@@ -175,6 +326,8 @@ func (*basicDockerBuilder) Write(directory string) error {
 	basicDocker := filepath.Join(dockerDir, "config.json")
 	cf := configFile{Auth: config.Entries}
 	auth := map[string]entry{}
+	helpers := map[string]string{}
+	store := ""
 
 	for _, secretName := range dockerCfg.Values {
 		dockerConfigAuthMap, err := authsFromDockerCfg(secretName)
@@ -187,18 +340,52 @@ func (*basicDockerBuilder) Write(directory string) error {
 	}
 
 	for _, secretName := range dockerConfig.Values {
-		dockerConfigAuthMap, err := authsFromDockerConfig(secretName)
+		c, err := configFromDockerConfig(secretName)
 		if err != nil {
 			return err
 		}
-		for k, v := range dockerConfigAuthMap {
+		for k, v := range c.Auth {
 			auth[k] = v
 		}
+		for k, v := range c.CredHelpers {
+			helpers[k] = v
+		}
+		if c.CredsStore != "" {
+			store = c.CredsStore
+		}
 	}
 	for k, v := range config.Entries {
 		auth[k] = v
 	}
-	if len(auth) == 0 {
+	for k, v := range credHelpers.Helpers {
+		helpers[k] = v
+	}
+	if credsStore.Helper != "" {
+		store = credsStore.Helper
+	}
+	// The mirrors are accessed with the credentials of the registries they mirror,
+	// unless they have their own.
+	for _, m := range mirrors.Mirrors {
+		authKeys := make([]string, 0, len(auth))
+		for k := range auth {
+			authKeys = append(authKeys, k)
+		}
+		if k, ok := findRegistry(authKeys, m.Registry); ok {
+			if _, ok := findRegistry(authKeys, m.Mirror); !ok {
+				auth[m.Mirror] = auth[k]
+			}
+		}
+		helperKeys := make([]string, 0, len(helpers))
+		for k := range helpers {
+			helperKeys = append(helperKeys, k)
+		}
+		if k, ok := findRegistry(helperKeys, m.Registry); ok {
+			if _, ok := findRegistry(helperKeys, m.Mirror); !ok {
+				helpers[m.Mirror] = helpers[k]
+			}
+		}
+	}
+	if len(auth) == 0 && len(helpers) == 0 && store == "" {
 		return nil
 	}
 	if err := os.MkdirAll(dockerDir, os.ModePerm); err != nil {
@@ -206,6 +393,10 @@ func (*basicDockerBuilder) Write(directory string) error {
 	}
 
 	cf.Auth = auth
+	if len(helpers) > 0 {
+		cf.CredHelpers = helpers
+	}
+	cf.CredsStore = store
 	content, err := json.Marshal(cf)
 	if err != nil {
 		return err
@@ -224,19 +415,47 @@ func authsFromDockerCfg(secret string) (map[string]entry, error) {
 	return m, err
 }
 
-func authsFromDockerConfig(secret string) (map[string]entry, error) {
+func configFromDockerConfig(secret string) (*configFile, error) {
 	secretPath := credentials.VolumeName(secret)
-	m := make(map[string]entry)
 	c := configFile{}
 	data, err := ioutil.ReadFile(filepath.Join(secretPath, corev1.DockerConfigJsonKey))
 	if err != nil {
-		return m, err
+		return nil, err
 	}
 	if err := json.Unmarshal(data, &c); err != nil {
-		return m, err
+		return nil, err
 	}
-	for k, v := range c.Auth {
-		m[k] = v
+	return &c, nil
+}
+
+// findRegistry returns the key of the registry among the keys of a Docker config, which
+// can be its host or a URL of its host, e.g. "gcr.io" or "https://gcr.io/v2/".
+func findRegistry(keys []string, registry string) (string, bool) {
+	sort.Strings(keys)
+	host := registryHost(registry)
+	for _, k := range keys {
+		if k == registry {
+			return k, true
+		}
+	}
+	for _, k := range keys {
+		if registryHost(k) == host {
+			return k, true
+		}
+	}
+	return "", false
+}
+
+// registryHost returns the host of a registry given as a host or as a URL, the
+// hosts of Docker Hub being returned as docker.io.
+func registryHost(registry string) string {
+	registry = strings.TrimPrefix(strings.TrimPrefix(registry, "https://"), "http://")
+	if i := strings.Index(registry, "/"); i >= 0 {
+		registry = registry[:i]
+	}
+	switch registry {
+	case "index.docker.io", "registry-1.docker.io":
+		return "docker.io"
 	}
-	return m, nil
+	return registry
 }
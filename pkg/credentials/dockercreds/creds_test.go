@@ -212,6 +212,32 @@ func TestMatchingAnnotations(t *testing.T) {
 			"-basic-docker=ssh=keys2",
 			"-basic-docker=ssh=keys3",
 		},
+	}, {
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeBasicAuth,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "mirrored",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s0", annotationPrefix):           "https://docker.io",
+					fmt.Sprintf("%s0", mirrorAnnotationPrefix):     "docker.io=mirror.example.com",
+					fmt.Sprintf("%s1", mirrorAnnotationPrefix):     "docker.io=mirror2.example.com",
+					fmt.Sprintf("%s0", credHelperAnnotationPrefix): "gcr.io=gcloud",
+					credsStoreAnnotation:                           "pass",
+				},
+			},
+		},
+		wantFlag: []string{"-basic-docker=mirrored=https://docker.io"},
+	}, {
+		secret: &corev1.Secret{
+			Type: corev1.SecretTypeOpaque,
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "ecr",
+				Annotations: map[string]string{
+					fmt.Sprintf("%s0", credHelperAnnotationPrefix): "123456789012.dkr.ecr.us-east-1.amazonaws.com=ecr-login",
+				},
+			},
+		},
+		wantFlag: []string(nil),
 	}}
 
 	nb := NewBuilder()
@@ -223,6 +249,25 @@ func TestMatchingAnnotations(t *testing.T) {
 	}
 }
 
+func TestHelperAnnotations(t *testing.T) {
+	annotations := map[string]string{
+		fmt.Sprintf("%s0", annotationPrefix):           "https://docker.io",
+		fmt.Sprintf("%s0", mirrorAnnotationPrefix):     "docker.io=mirror.example.com",
+		fmt.Sprintf("%s1", mirrorAnnotationPrefix):     "docker.io=mirror2.example.com",
+		fmt.Sprintf("%s0", credHelperAnnotationPrefix): "gcr.io=gcloud",
+		credsStoreAnnotation:                           "pass",
+	}
+	want := []string{
+		"-docker-cred-helper=gcr.io=gcloud",
+		"-docker-creds-store=pass",
+		"-docker-mirror=docker.io=mirror.example.com",
+		"-docker-mirror=docker.io=mirror2.example.com",
+	}
+	if got := HelperAnnotations(annotations); !cmp.Equal(want, got) {
+		t.Errorf("HelperAnnotations() Mismatch of flags; wanted: %v got: %v", want, got)
+	}
+}
+
 func TestMultipleFlagHandling(t *testing.T) {
 	credentials.VolumePath = t.TempDir()
 	fooDir := credentials.VolumeName("foo")
@@ -322,3 +367,136 @@ func TestNoAuthProvided(t *testing.T) {
 		t.Errorf("expected does not exist error but received: %v", err)
 	}
 }
+
+func TestCredHelpersAndMirrors(t *testing.T) {
+	credentials.VolumePath = t.TempDir()
+	fooDir := credentials.VolumeName("foo")
+	if err := os.MkdirAll(fooDir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", fooDir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(fooDir, corev1.BasicAuthUsernameKey), []byte("bar"), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(username) = %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(fooDir, corev1.BasicAuthPasswordKey), []byte("baz"), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(password) = %v", err)
+	}
+	configDir := credentials.VolumeName("config")
+	if err := os.MkdirAll(configDir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", configDir, err)
+	}
+	dockerConfigFile := `{"auths":{},"credHelpers":{"quay.io":"quay"},"credsStore":"osxkeychain"}`
+	if err := ioutil.WriteFile(filepath.Join(configDir, corev1.DockerConfigJsonKey), []byte(dockerConfigFile), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(.dockerconfigjson) = %v", err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	if err := fs.Parse([]string{
+		"-basic-docker=foo=https://index.docker.io/v1/",
+		"-docker-config=config",
+		"-docker-cred-helper=gcr.io=gcloud",
+		"-docker-cred-helper=gcr.io=gcloud",
+		"-docker-creds-store=pass",
+		"-docker-mirror=docker.io=mirror.example.com",
+		"-docker-mirror=gcr.io=gcr-mirror.example.com",
+		"-docker-mirror=quay.io=quay-mirror.example.com",
+	}); err != nil {
+		t.Fatalf("flag.CommandLine.Parse() = %v", err)
+	}
+
+	if err := NewBuilder().Write(credentials.VolumePath); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(credentials.VolumePath, ".docker", "config.json"))
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile(.docker/config.json) = %v", err)
+	}
+	// The credentials of Docker Hub are configured for index.docker.io.
+	expected := `{"auths":{"https://index.docker.io/v1/":{"username":"bar","password":"baz","auth":"YmFyOmJheg==","email":"not@val.id"},` +
+		`"mirror.example.com":{"username":"bar","password":"baz","auth":"YmFyOmJheg==","email":"not@val.id"}},` +
+		`"credHelpers":{"gcr-mirror.example.com":"gcloud","gcr.io":"gcloud","quay-mirror.example.com":"quay","quay.io":"quay"},"credsStore":"pass"}`
+	if string(b) != expected {
+		t.Errorf("got: %v, wanted: %v", string(b), expected)
+	}
+}
+
+func TestMirrorOfRegistryURL(t *testing.T) {
+	credentials.VolumePath = t.TempDir()
+	fooDir := credentials.VolumeName("foo")
+	if err := os.MkdirAll(fooDir, os.ModePerm); err != nil {
+		t.Fatalf("os.MkdirAll(%s) = %v", fooDir, err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(fooDir, corev1.BasicAuthUsernameKey), []byte("bar"), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(username) = %v", err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(fooDir, corev1.BasicAuthPasswordKey), []byte("baz"), 0777); err != nil {
+		t.Fatalf("ioutil.WriteFile(password) = %v", err)
+	}
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	if err := fs.Parse([]string{
+		"-basic-docker=foo=https://registry.example.com",
+		"-docker-mirror=registry.example.com=mirror.example.com",
+	}); err != nil {
+		t.Fatalf("flag.CommandLine.Parse() = %v", err)
+	}
+
+	if err := NewBuilder().Write(credentials.VolumePath); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(credentials.VolumePath, ".docker", "config.json"))
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile(.docker/config.json) = %v", err)
+	}
+	expected := `{"auths":{"https://registry.example.com":{"username":"bar","password":"baz","auth":"YmFyOmJheg==","email":"not@val.id"},` +
+		`"mirror.example.com":{"username":"bar","password":"baz","auth":"YmFyOmJheg==","email":"not@val.id"}}}`
+	if string(b) != expected {
+		t.Errorf("got: %v, wanted: %v", string(b), expected)
+	}
+}
+
+func TestCredsStoreOnly(t *testing.T) {
+	credentials.VolumePath = t.TempDir()
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	AddFlags(fs)
+	if err := fs.Parse([]string{"-docker-creds-store=ecr-login"}); err != nil {
+		t.Fatalf("flag.CommandLine.Parse() = %v", err)
+	}
+
+	if err := NewBuilder().Write(credentials.VolumePath); err != nil {
+		t.Fatalf("Write() = %v", err)
+	}
+
+	b, err := ioutil.ReadFile(filepath.Join(credentials.VolumePath, ".docker", "config.json"))
+	if err != nil {
+		t.Fatalf("ioutil.ReadFile(.docker/config.json) = %v", err)
+	}
+	expected := `{"auths":{},"credsStore":"ecr-login"}`
+	if string(b) != expected {
+		t.Errorf("got: %v, wanted: %v", string(b), expected)
+	}
+}
+
+func TestMalformedHelperFlags(t *testing.T) {
+	for _, args := range [][]string{
+		{"-docker-cred-helper=gcr.io"},
+		{"-docker-cred-helper==gcloud"},
+		{"-docker-cred-helper=gcr.io=../gcloud"},
+		{"-docker-cred-helper=gcr.io=gcloud", "-docker-cred-helper=gcr.io=pass"},
+		{"-docker-creds-store=pass", "-docker-creds-store=osxkeychain"},
+		{"-docker-creds-store=a b"},
+		{"-docker-mirror=docker.io"},
+		{"-docker-mirror=docker.io="},
+	} {
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(ioutil.Discard)
+		AddFlags(fs)
+		if err := fs.Parse(args); err == nil {
+			t.Errorf("fs.Parse(%v) = nil, want an error", args)
+		}
+	}
+}
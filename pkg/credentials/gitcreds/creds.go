@@ -29,11 +29,13 @@ const (
 	annotationPrefix = "tekton.dev/git-"
 	basicAuthFlag    = "basic-git"
 	sshFlag          = "ssh-git"
+	githubAppFlag    = "github-app-git"
 )
 
 var (
-	basicConfig basicGitConfig
-	sshConfig   sshGitConfig
+	basicConfig     basicGitConfig
+	sshConfig       sshGitConfig
+	githubAppConfig githubAppGitConfig
 )
 
 // AddFlags adds CLI flags that gitcreds supports to a given flag.FlagSet.
@@ -47,6 +49,9 @@ func flags(fs *flag.FlagSet) {
 
 	sshConfig = sshGitConfig{entries: make(map[string][]sshEntry)}
 	fs.Var(&sshConfig, sshFlag, "List of secret=url pairs.")
+
+	githubAppConfig = githubAppGitConfig{basic: &basicConfig, entries: make(map[string]string)}
+	fs.Var(&githubAppConfig, githubAppFlag, "List of secret=url pairs.")
 }
 
 type gitConfigBuilder struct{}
@@ -67,6 +72,9 @@ func (*gitConfigBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
 	case corev1.SecretTypeSSHAuth:
 		flagName = sshFlag
 
+	case SecretTypeGitHubApp:
+		flagName = githubAppFlag
+
 	default:
 		return flags
 	}
@@ -78,8 +86,14 @@ func (*gitConfigBuilder) MatchingAnnotations(secret *corev1.Secret) []string {
 }
 
 func (*gitConfigBuilder) Write(directory string) error {
-	if err := basicConfig.Write(directory); err != nil {
+	// The installation tokens of the GitHub Apps are written along the basic-auth
+	// credentials, which are written even if some of the tokens can't be minted.
+	basic, tokenErr := githubAppConfig.withTokens()
+	if err := basic.Write(directory); err != nil {
+		return err
+	}
+	if err := sshConfig.Write(directory); err != nil {
 		return err
 	}
-	return sshConfig.Write(directory)
+	return tokenErr
 }
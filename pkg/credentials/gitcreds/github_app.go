@@ -0,0 +1,294 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitcreds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/tektoncd/pipeline/pkg/credentials"
+)
+
+const (
+	// SecretTypeGitHubApp is the type of the secrets holding the credentials of a
+	// GitHub App, from which installation tokens are minted to authenticate to Git.
+	SecretTypeGitHubApp corev1.SecretType = "tekton.dev/github-app"
+
+	// GitHubAppIDKey is the key of the ID of the GitHub App in its secrets.
+	GitHubAppIDKey = "appID"
+	// GitHubAppPrivateKeyKey is the key of the PEM encoded private key of the GitHub App
+	// in its secrets.
+	GitHubAppPrivateKeyKey = "privateKey"
+	// GitHubAppInstallationIDKey is the optional key of the ID of the installation of the
+	// GitHub App in its secrets. When omitted, the GitHub App must have a single installation.
+	GitHubAppInstallationIDKey = "installationID"
+	// GitHubAppAPIURLKey is the optional key of the URL of the GitHub API in the secrets
+	// of the GitHub App. When omitted, it is derived from the URL of the Git server.
+	GitHubAppAPIURLKey = "apiURL"
+
+	// githubAppUsername is the username that GitHub expects along installation tokens.
+	githubAppUsername = "x-access-token"
+	// githubAppJWTLifetime is how long the JWTs authenticating as the GitHub App are valid,
+	// below the 10 minutes accepted by GitHub.
+	githubAppJWTLifetime = 9 * time.Minute
+)
+
+// githubAppHTTPClient is the client of the GitHub API minting the installation tokens.
+var githubAppHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+// As the flag is read, this status is populated.
+// githubAppGitConfig implements flag.Value
+type githubAppGitConfig struct {
+	// basic holds the basic-auth credentials, which can't be given for the same URLs.
+	basic   *basicGitConfig
+	entries map[string]string
+	// The order we see things, by URL.
+	order []string
+}
+
+func (dc *githubAppGitConfig) String() string {
+	if dc == nil {
+		// According to flag.Value this can happen.
+		return ""
+	}
+	var urls []string
+	for _, k := range dc.order {
+		urls = append(urls, fmt.Sprintf("%s=%s", dc.entries[k], k))
+	}
+	return strings.Join(urls, ",")
+}
+
+// Set sets a secret for a given URL from a "secret=url" value. No token is minted
+// until the credentials are written.
+func (dc *githubAppGitConfig) Set(value string) error {
+	parts := strings.Split(value, "=")
+	if len(parts) != 2 {
+		return fmt.Errorf("expect entries of the form secret=url, got: %v", value)
+	}
+	secret := parts[0]
+	url := parts[1]
+
+	if _, ok := dc.entries[url]; ok {
+		return fmt.Errorf("multiple entries for url: %v", url)
+	}
+	if _, ok := dc.basic.entries[url]; ok {
+		return fmt.Errorf("multiple entries for url: %v", url)
+	}
+
+	dc.entries[url] = secret
+	dc.order = append(dc.order, url)
+	return nil
+}
+
+// withTokens mints an installation token of the GitHub App of each URL, and returns the
+// basic-auth credentials along with the tokens, which are written the same way. The URLs
+// whose token can't be minted are left out, and the first error is returned.
+func (dc *githubAppGitConfig) withTokens() (*basicGitConfig, error) {
+	if len(dc.entries) == 0 {
+		return dc.basic, nil
+	}
+	basic := &basicGitConfig{
+		entries: make(map[string]basicEntry, len(dc.basic.entries)+len(dc.entries)),
+		order:   append([]string(nil), dc.basic.order...),
+	}
+	for k, v := range dc.basic.entries {
+		basic.entries[k] = v
+	}
+	var firstErr error
+	for _, k := range dc.order {
+		e, err := newGitHubAppEntry(k, dc.entries[k])
+		if err == nil {
+			if _, ok := basic.entries[k]; ok {
+				err = fmt.Errorf("multiple entries for url: %v", k)
+			}
+		}
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		basic.entries[k] = *e
+		basic.order = append(basic.order, k)
+	}
+	return basic, firstErr
+}
+
+func newGitHubAppEntry(u, secret string) (*basicEntry, error) {
+	token, err := githubAppToken(credentials.VolumeName(secret), u)
+	if err != nil {
+		return nil, fmt.Errorf("secret %s: %w", secret, err)
+	}
+	pu, err := url.Parse(u)
+	if err != nil {
+		return nil, err
+	}
+	pu.User = url.UserPassword(githubAppUsername, token)
+
+	return &basicEntry{
+		secret:   secret,
+		username: githubAppUsername,
+		password: token,
+		authURL:  pu,
+	}, nil
+}
+
+// githubAppToken mints an installation token for a given URL of the GitHub App of
+// the secret mounted at secretPath.
+func githubAppToken(secretPath, u string) (string, error) {
+	appID, err := readGitHubAppKey(secretPath, GitHubAppIDKey, true)
+	if err != nil {
+		return "", err
+	}
+	privateKey, err := readGitHubAppKey(secretPath, GitHubAppPrivateKeyKey, true)
+	if err != nil {
+		return "", err
+	}
+	installationID, err := readGitHubAppKey(secretPath, GitHubAppInstallationIDKey, false)
+	if err != nil {
+		return "", err
+	}
+	apiURL, err := readGitHubAppKey(secretPath, GitHubAppAPIURLKey, false)
+	if err != nil {
+		return "", err
+	}
+	if apiURL == "" {
+		pu, err := url.Parse(u)
+		if err != nil {
+			return "", err
+		}
+		apiURL = githubAPIURL(pu)
+	}
+
+	app, err := newGitHubApp(appID, privateKey, strings.TrimSuffix(apiURL, "/"))
+	if err != nil {
+		return "", err
+	}
+	if installationID == "" {
+		if installationID, err = app.installationID(); err != nil {
+			return "", err
+		}
+	}
+	return app.installationToken(installationID)
+}
+
+// readGitHubAppKey reads the value of a key of the secret of a GitHub App. The keys
+// which aren't required are empty when missing.
+func readGitHubAppKey(secretPath, key string, required bool) (string, error) {
+	b, err := ioutil.ReadFile(filepath.Join(secretPath, key))
+	if err != nil {
+		if !required && errors.Is(err, os.ErrNotExist) {
+			return "", nil
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(b)), nil
+}
+
+// githubAPIURL returns the URL of the API of the GitHub, or GitHub Enterprise Server,
+// serving the Git repositories at the given URL.
+func githubAPIURL(u *url.URL) string {
+	if strings.EqualFold(u.Hostname(), "github.com") || strings.EqualFold(u.Hostname(), "www.github.com") {
+		return "https://api.github.com"
+	}
+	return fmt.Sprintf("%s://%s/api/v3", u.Scheme, u.Host)
+}
+
+// githubApp authenticates to the GitHub API as a GitHub App.
+type githubApp struct {
+	id     string
+	jwt    string
+	apiURL string
+}
+
+func newGitHubApp(appID, privateKey, apiURL string) (*githubApp, error) {
+	key, err := jwt.ParseRSAPrivateKeyFromPEM([]byte(privateKey))
+	if err != nil {
+		return nil, fmt.Errorf("parsing the private key of GitHub App %s: %w", appID, err)
+	}
+	// The issue time is set in the past to allow for clock drift.
+	now := time.Now()
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.RegisteredClaims{
+		Issuer:    appID,
+		IssuedAt:  jwt.NewNumericDate(now.Add(-time.Minute)),
+		ExpiresAt: jwt.NewNumericDate(now.Add(githubAppJWTLifetime)),
+	}).SignedString(key)
+	if err != nil {
+		return nil, fmt.Errorf("signing the JWT of GitHub App %s: %w", appID, err)
+	}
+	return &githubApp{id: appID, jwt: signed, apiURL: apiURL}, nil
+}
+
+// installationID returns the ID of the single installation of the GitHub App.
+func (a *githubApp) installationID() (string, error) {
+	var installations []struct {
+		ID int64 `json:"id"`
+	}
+	if err := a.do(http.MethodGet, "/app/installations", http.StatusOK, &installations); err != nil {
+		return "", fmt.Errorf("listing the installations of GitHub App %s: %w", a.id, err)
+	}
+	if len(installations) != 1 {
+		return "", fmt.Errorf("GitHub App %s has %d installations, the %q key must select one of them", a.id, len(installations), GitHubAppInstallationIDKey)
+	}
+	return fmt.Sprint(installations[0].ID), nil
+}
+
+// installationToken mints a token of an installation of the GitHub App.
+func (a *githubApp) installationToken(installationID string) (string, error) {
+	var token struct {
+		Token string `json:"token"`
+	}
+	path := fmt.Sprintf("/app/installations/%s/access_tokens", url.PathEscape(installationID))
+	if err := a.do(http.MethodPost, path, http.StatusCreated, &token); err != nil {
+		return "", fmt.Errorf("minting a token of installation %s of GitHub App %s: %w", installationID, a.id, err)
+	}
+	if token.Token == "" {
+		return "", fmt.Errorf("minting a token of installation %s of GitHub App %s: no token in the response", installationID, a.id)
+	}
+	return token.Token, nil
+}
+
+func (a *githubApp) do(method, path string, wantStatus int, v interface{}) error {
+	req, err := http.NewRequest(method, a.apiURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+a.jwt)
+
+	resp, err := githubAppHTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != wantStatus {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("status %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
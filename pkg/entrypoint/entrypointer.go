@@ -80,6 +80,8 @@ type Entrypointer struct {
 	OnError string
 	// StepMetadataDir is the directory for a step where the step related metadata can be stored
 	StepMetadataDir string
+	// LogUploader optionally uploads the logs of the step once it finished
+	LogUploader LogUploader
 }
 
 // Waiter encapsulates waiting for files to exist.
@@ -158,6 +160,20 @@ func (e Entrypointer) Go() error {
 		}
 	}
 
+	if e.LogUploader != nil {
+		// Failing to upload the logs doesn't fail the step, they are still available
+		// as long as the pod exists.
+		if location, uErr := e.LogUploader.Upload(context.Background()); uErr != nil {
+			logger.Errorf("Error while uploading the step logs: %s", uErr)
+		} else if location != "" {
+			output = append(output, v1beta1.PipelineResourceResult{
+				Key:        LogLocationKey,
+				Value:      location,
+				ResultType: v1beta1.InternalTektonResultType,
+			})
+		}
+	}
+
 	var ee *exec.ExitError
 	switch {
 	case err != nil && e.BreakpointOnFailure:
@@ -434,6 +434,65 @@ func TestEntrypointer_OnError(t *testing.T) {
 	}
 }
 
+func TestEntrypointerLogUploader(t *testing.T) {
+	for _, c := range []struct {
+		desc         string
+		uploader     *fakeLogUploader
+		wantLocation string
+	}{{
+		desc:         "logs uploaded",
+		uploader:     &fakeLogUploader{location: "s3://tekton-logs/foo/pod/step.log"},
+		wantLocation: "s3://tekton-logs/foo/pod/step.log",
+	}, {
+		desc:     "no logs",
+		uploader: &fakeLogUploader{},
+	}, {
+		desc:     "upload failed",
+		uploader: &fakeLogUploader{err: errors.New("storage unavailable")},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			terminationFile, err := ioutil.TempFile("", "termination")
+			if err != nil {
+				t.Fatalf("unexpected error creating temporary termination file: %v", err)
+			}
+			defer os.Remove(terminationFile.Name())
+
+			// Failing to upload the logs doesn't fail the step.
+			if err := (Entrypointer{
+				Command:         []string{"echo", "some", "args"},
+				Waiter:          &fakeWaiter{},
+				Runner:          &fakeRunner{},
+				PostWriter:      &fakePostWriter{},
+				TerminationPath: terminationFile.Name(),
+				LogUploader:     c.uploader,
+			}).Go(); err != nil {
+				t.Fatalf("Entrypointer failed: %v", err)
+			}
+			if !c.uploader.uploaded {
+				t.Error("Expected the logs to be uploaded")
+			}
+
+			fileContents, err := ioutil.ReadFile(terminationFile.Name())
+			if err != nil {
+				t.Fatalf("Reading the termination file: %v", err)
+			}
+			var entries []v1beta1.PipelineResourceResult
+			if err := json.Unmarshal(fileContents, &entries); err != nil {
+				t.Fatalf("Unmarshalling the termination file: %v", err)
+			}
+			var gotLocation string
+			for _, result := range entries {
+				if result.Key == LogLocationKey && result.ResultType == v1beta1.InternalTektonResultType {
+					gotLocation = result.Value
+				}
+			}
+			if gotLocation != c.wantLocation {
+				t.Errorf("Got the log location %q, want %q", gotLocation, c.wantLocation)
+			}
+		})
+	}
+}
+
 type fakeWaiter struct{ waited []string }
 
 func (f *fakeWaiter) Wait(file string, _ bool, _ bool) error {
@@ -503,3 +562,14 @@ func (f *fakeExitErrorRunner) Run(ctx context.Context, args ...string) error {
 	f.args = &args
 	return exec.Command("ls", "/bogus/path").Run()
 }
+
+type fakeLogUploader struct {
+	location string
+	err      error
+	uploaded bool
+}
+
+func (f *fakeLogUploader) Upload(ctx context.Context) (string, error) {
+	f.uploaded = true
+	return f.location, f.err
+}
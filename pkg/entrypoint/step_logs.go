@@ -0,0 +1,138 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/tektoncd/pipeline/pkg/internal/objectstore"
+)
+
+const (
+	// LogLocationKey is the key of the termination message result holding the URL
+	// the logs of the step were uploaded to.
+	LogLocationKey = "LogLocation"
+
+	// DefaultStepLogsUploadTimeout is how long the upload of the logs of a step may
+	// take before it is cancelled, so that a stalled storage doesn't hang the step.
+	DefaultStepLogsUploadTimeout = 5 * time.Minute
+)
+
+// LogUploader uploads the logs of the step once it finished.
+type LogUploader interface {
+	// Upload uploads the logs of the step and returns their location, or
+	// an empty location if the step didn't write any log.
+	Upload(ctx context.Context) (string, error)
+}
+
+// StepLogsUploader uploads the logs of the step written to a local file to an
+// S3-compatible storage or with a PUT request to an HTTP endpoint.
+type StepLogsUploader struct {
+	// Path is the file the logs of the step are written to.
+	Path string
+	// URL is the s3, http or https URL the logs are uploaded to.
+	URL string
+	// Endpoint is the endpoint of the S3-compatible storage, defaulting to
+	// the AWS S3 endpoint of the Region.
+	Endpoint string
+	// Region is the region of the S3-compatible storage.
+	Region string
+	// Credentials sign the requests to the S3-compatible storage.
+	Credentials *aws.Credentials
+	// Token is the bearer token of the requests to the HTTP endpoint.
+	Token string
+	// HTTPClient sends the requests, defaulting to http.DefaultClient.
+	HTTPClient *http.Client
+	// Timeout bounds the upload, defaulting to DefaultStepLogsUploadTimeout.
+	Timeout time.Duration
+}
+
+var _ LogUploader = (*StepLogsUploader)(nil)
+
+// Upload implements LogUploader.
+func (u *StepLogsUploader) Upload(ctx context.Context) (string, error) {
+	f, err := os.Open(u.Path)
+	if os.IsNotExist(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	timeout := u.Timeout
+	if timeout == 0 {
+		timeout = DefaultStepLogsUploadTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	location, err := url.Parse(u.URL)
+	if err != nil {
+		return "", fmt.Errorf("invalid step logs location %q: %w", u.URL, err)
+	}
+	client := u.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	switch location.Scheme {
+	case "s3":
+		s3, err := objectstore.NewS3Client(u.Endpoint, u.Region, u.Credentials)
+		if err != nil {
+			return "", err
+		}
+		s3.HTTPClient = client
+		return s3.Put(ctx, location.Host, strings.TrimPrefix(location.Path, "/"), f, "text/plain")
+	case "http", "https":
+		return u.URL, u.put(ctx, client, f)
+	default:
+		return "", fmt.Errorf("unsupported step logs location %q", u.URL)
+	}
+}
+
+func (u *StepLogsUploader) put(ctx context.Context, client *http.Client, f *os.File) error {
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u.URL, io.NopCloser(f))
+	if err != nil {
+		return err
+	}
+	req.ContentLength = info.Size()
+	req.Header.Set("Content-Type", "text/plain")
+	if u.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+u.Token)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("uploading the step logs to %s failed with status %s: %s", u.URL, resp.Status, b)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package entrypoint
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+type recordedRequest struct {
+	path, authorization, body string
+}
+
+func newRecordingServer(t *testing.T, status int, got *recordedRequest) *httptest.Server {
+	t.Helper()
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected a PUT request, got %s", r.Method)
+		}
+		b, _ := io.ReadAll(r.Body)
+		*got = recordedRequest{path: r.URL.Path, authorization: r.Header.Get("Authorization"), body: string(b)}
+		w.WriteHeader(status)
+	}))
+}
+
+func writeStepLogs(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "logs")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestStepLogsUploaderHTTP(t *testing.T) {
+	var got recordedRequest
+	server := newRecordingServer(t, http.StatusCreated, &got)
+	defer server.Close()
+
+	u := &StepLogsUploader{
+		Path:  writeStepLogs(t, "hello world\n"),
+		URL:   server.URL + "/logs/foo/pod/step.log",
+		Token: "secret-token",
+	}
+	location, err := u.Upload(context.Background())
+	if err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	if location != u.URL {
+		t.Errorf("Upload() = %q, want %q", location, u.URL)
+	}
+	want := recordedRequest{path: "/logs/foo/pod/step.log", authorization: "Bearer secret-token", body: "hello world\n"}
+	if got != want {
+		t.Errorf("Got the request %+v, want %+v", got, want)
+	}
+}
+
+func TestStepLogsUploaderS3(t *testing.T) {
+	var got recordedRequest
+	server := newRecordingServer(t, http.StatusOK, &got)
+	defer server.Close()
+
+	u := &StepLogsUploader{
+		Path:        writeStepLogs(t, "hello world\n"),
+		URL:         "s3://tekton-logs/steps/foo/pod/step.log",
+		Endpoint:    server.URL,
+		Region:      "us-east-1",
+		Credentials: &aws.Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"},
+	}
+	location, err := u.Upload(context.Background())
+	if err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	if location != u.URL {
+		t.Errorf("Upload() = %q, want %q", location, u.URL)
+	}
+	if got.path != "/tekton-logs/steps/foo/pod/step.log" || got.body != "hello world\n" {
+		t.Errorf("Unexpected request %+v", got)
+	}
+	if !strings.HasPrefix(got.authorization, "AWS4-HMAC-SHA256 Credential=minio/") {
+		t.Errorf("Expected the request to be signed with the access key ID, got %q", got.authorization)
+	}
+}
+
+func TestStepLogsUploaderNoLogs(t *testing.T) {
+	u := &StepLogsUploader{
+		Path: filepath.Join(t.TempDir(), "logs"),
+		URL:  "https://logs.example.com/foo/pod/step.log",
+	}
+	location, err := u.Upload(context.Background())
+	if err != nil || location != "" {
+		t.Errorf("Upload() = (%q, %v), want no location", location, err)
+	}
+}
+
+func TestStepLogsUploaderError(t *testing.T) {
+	var got recordedRequest
+	server := newRecordingServer(t, http.StatusForbidden, &got)
+	defer server.Close()
+
+	u := &StepLogsUploader{
+		Path: writeStepLogs(t, "hello world\n"),
+		URL:  server.URL + "/foo/pod/step.log",
+	}
+	if _, err := u.Upload(context.Background()); err == nil {
+		t.Error("expected an error but got nil")
+	}
+}
+
+func TestStepLogsUploaderStalledServer(t *testing.T) {
+	stalled := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Never respond until the test is done.
+		<-stalled
+	}))
+	defer server.Close()
+	defer close(stalled)
+
+	u := &StepLogsUploader{
+		Path:    writeStepLogs(t, "hello world\n"),
+		URL:     server.URL + "/foo/pod/step.log",
+		Timeout: 100 * time.Millisecond,
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := u.Upload(context.Background())
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Error("expected an error but got nil")
+		}
+	case <-time.After(10 * time.Second):
+		t.Fatal("Upload() didn't time out against a stalled server")
+	}
+}
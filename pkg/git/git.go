@@ -25,6 +25,7 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -39,10 +40,20 @@ const (
 var (
 	// sshURLRegexFormat matches the url of SSH git repository
 	sshURLRegexFormat = regexp.MustCompile(`(ssh://[\w\d\.]+|.+@?.+\..+:)(:[\d]+){0,1}/*(.*)`)
+	// commitSHARegex matches a full SHA-1 or SHA-256 commit hash
+	commitSHARegex = regexp.MustCompile(`^([0-9a-f]{40}|[0-9a-f]{64})$`)
 )
 
 func run(logger *zap.SugaredLogger, dir string, args ...string) (string, error) {
+	return runWithEnv(logger, dir, nil, args...)
+}
+
+// runWithEnv runs git with the environment variables of env added to those of the process.
+func runWithEnv(logger *zap.SugaredLogger, dir string, env []string, args ...string) (string, error) {
 	c := exec.Command("git", args...)
+	if len(env) > 0 {
+		c.Env = append(os.Environ(), env...)
+	}
 	var output bytes.Buffer
 	c.Stderr = &output
 	c.Stdout = &output
@@ -71,14 +82,22 @@ type FetchSpec struct {
 	HTTPSProxy                string
 	NOProxy                   string
 	SparseCheckoutDirectories string
+	// Filter is the object filter of a partial clone, e.g. "blob:none".
+	Filter string
+	// LFS fetches the Git LFS objects of the revision.
+	LFS bool
+	// TrustedKeysPath is the directory of the GPG and SSH public keys trusted to sign the revision. The
+	// signature of the revision is verified before checking it out when it is set.
+	TrustedKeysPath string
 }
 
 // Fetch fetches the specified git repository at the revision into path, using the refspec to fetch if provided.
-func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
+// It returns the signature of the revision when spec.TrustedKeysPath is set, and nil otherwise.
+func Fetch(logger *zap.SugaredLogger, spec FetchSpec) (*Signature, error) {
 	homepath, err := homedir.Dir()
 	if err != nil {
 		logger.Errorf("Unexpected error getting the user home directory: %v", err)
-		return err
+		return nil, err
 	}
 	if os.Geteuid() == 0 {
 		homepath = "/root"
@@ -86,54 +105,65 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	ensureHomeEnv(logger, homepath)
 	validateGitAuth(logger, pipeline.CredsDir, spec.URL)
 
+	if spec.LFS {
+		// Fail before fetching anything rather than after a possibly long fetch.
+		if err := checkLFSInstalled(logger); err != nil {
+			return nil, err
+		}
+	}
+
 	if spec.Path != "" {
 		if _, err := run(logger, "", "init", spec.Path); err != nil {
-			return err
+			return nil, err
 		}
 		if err := os.Chdir(spec.Path); err != nil {
-			return fmt.Errorf("failed to change directory with path %s; err: %w", spec.Path, err)
+			return nil, fmt.Errorf("failed to change directory with path %s; err: %w", spec.Path, err)
 		}
 		if _, err := run(logger, "", "config", "--add", "--global", "safe.directory", spec.Path); err != nil {
-			return err
+			return nil, err
 		}
 	} else {
 		if _, err := run(logger, "", "init"); err != nil {
-			return err
+			return nil, err
 		}
 		if _, err := run(logger, "", "config", "--add", "--global", "safe.directory", "/"); err != nil {
-			return err
+			return nil, err
 		}
 	}
 	if err := configSparseCheckout(logger, spec); err != nil {
-		return err
+		return nil, err
 	}
 	trimmedURL := strings.TrimSpace(spec.URL)
 	if _, err := run(logger, "", "remote", "add", "origin", trimmedURL); err != nil {
-		return err
+		return nil, err
 	}
 
 	hasKnownHosts, err := userHasKnownHostsFile(homepath)
 	if err != nil {
-		return fmt.Errorf("error checking for known_hosts file: %w", err)
+		return nil, fmt.Errorf("error checking for known_hosts file: %w", err)
 	}
 	if !hasKnownHosts {
 		if _, err := run(logger, "", "config", "core.sshCommand", sshMissingKnownHostsSSHCommand); err != nil {
 			err = fmt.Errorf("error disabling strict host key checking: %w", err)
 			logger.Warnf(err.Error())
-			return err
+			return nil, err
 		}
 	}
 	if _, err := run(logger, "", "config", "http.sslVerify", strconv.FormatBool(spec.SSLVerify)); err != nil {
 		logger.Warnf("Failed to set http.sslVerify in git config: %s", err)
-		return err
+		return nil, err
 	}
 
 	fetchArgs := []string{"fetch"}
 	if spec.Submodules {
 		fetchArgs = append(fetchArgs, "--recurse-submodules=yes")
 	}
+	if spec.Filter != "" {
+		fetchArgs = append(fetchArgs, "--filter="+spec.Filter)
+	}
+	depthArg := ""
 	if spec.Depth > 0 {
-		fetchArgs = append(fetchArgs, fmt.Sprintf("--depth=%d", spec.Depth))
+		depthArg = fmt.Sprintf("--depth=%d", spec.Depth)
 	}
 
 	// Fetch the revision and verify with FETCH_HEAD
@@ -154,34 +184,61 @@ func Fetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	// non-fast-forward manner (though this cannot be possible on initial fetch, it can help
 	// when the refspec specifies the same destination twice)
 	fetchArgs = append(fetchArgs, "origin", "--update-head-ok", "--force")
-	fetchArgs = append(fetchArgs, fetchParam...)
-	if _, err := run(logger, spec.Path, fetchArgs...); err != nil {
-		return fmt.Errorf("failed to fetch %v: %v", fetchParam, err)
+	args := fetchArgs
+	if depthArg != "" {
+		args = append(args, depthArg)
+	}
+	args = append(args, fetchParam...)
+	if _, err := run(logger, spec.Path, args...); err != nil {
+		if spec.Refspec != "" || !commitSHARegex.MatchString(spec.Revision) {
+			return nil, fmt.Errorf("failed to fetch %v: %v", fetchParam, err)
+		}
+		// Servers that don't allow fetching unadvertised objects refuse to send the commit by its
+		// SHA, so fetch the history of all the branches and tags to find it instead.
+		logger.Warnf("Failed to fetch commit %s, fetching all branches and tags instead", spec.Revision)
+		allRefs := []string{"+refs/heads/*:refs/remotes/origin/*", "+refs/tags/*:refs/tags/*"}
+		if _, err := run(logger, spec.Path, append(fetchArgs, allRefs...)...); err != nil {
+			return nil, fmt.Errorf("failed to fetch %v: %v", allRefs, err)
+		}
+		checkoutParam = spec.Revision
 	}
 	// After performing a fetch, verify that the item to checkout is actually valid
 	if _, err := ShowCommit(logger, checkoutParam, spec.Path); err != nil {
-		return fmt.Errorf("error parsing %s after fetching refspec %s", checkoutParam, spec.Refspec)
+		return nil, fmt.Errorf("error parsing %s after fetching refspec %s", checkoutParam, spec.Refspec)
+	}
+	var signature *Signature
+	if spec.TrustedKeysPath != "" {
+		signature, err = VerifyCommit(logger, checkoutParam, spec.Path, spec.TrustedKeysPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify the signature of %s: %w", spec.Revision, err)
+		}
+		logger.Infof("Verified signature of %s by %s (%s)", spec.Revision, signature.Signer, signature.Key)
 	}
 
 	if _, err := run(logger, "", "checkout", "-f", checkoutParam); err != nil {
-		return err
+		return nil, err
+	}
+	if spec.LFS {
+		if err := lfsFetch(logger, spec); err != nil {
+			return nil, err
+		}
 	}
 
 	commit, err := ShowCommit(logger, "HEAD", spec.Path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	ref, err := showRef(logger, "HEAD", spec.Path)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	logger.Infof("Successfully cloned %s @ %s (%s) in path %s", trimmedURL, commit, ref, spec.Path)
 	if spec.Submodules {
 		if err := submoduleFetch(logger, spec); err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return signature, nil
 }
 
 // ShowCommit calls "git show ..." to get the commit SHA for the given revision
@@ -193,6 +250,105 @@ func ShowCommit(logger *zap.SugaredLogger, revision, path string) (string, error
 	return strings.TrimSuffix(output, "\n"), nil
 }
 
+const (
+	// maxSubjectLength and maxTagsLength bound the subject and the comma-separated tags
+	// of a commit, which are written to the 4KB termination message with the other results.
+	maxSubjectLength = 256
+	maxTagsLength    = 1024
+)
+
+// Commit describes a Git commit.
+type Commit struct {
+	SHA            string
+	Author         string
+	AuthorEmail    string
+	Committer      string
+	CommitterEmail string
+	// Timestamp is the committer date, in seconds since the Unix epoch.
+	Timestamp int64
+	// Subject is the first line of the commit message.
+	Subject string
+	// Tags are the tags pointing at the commit.
+	Tags []string
+}
+
+// ShowCommitMetadata calls "git show ..." and "git ls-remote ..." to get the metadata of the commit for the given revision
+func ShowCommitMetadata(logger *zap.SugaredLogger, revision, path string) (*Commit, error) {
+	output, err := run(logger, path, "show", "-q", "--pretty=format:%H%x00%an%x00%ae%x00%cn%x00%ce%x00%ct%x00%s", revision)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Split(strings.TrimSuffix(output, "\n"), "\x00")
+	if len(fields) != 7 {
+		return nil, fmt.Errorf("unexpected output of git show for %s: %q", revision, output)
+	}
+	timestamp, err := strconv.ParseInt(fields[5], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing commit timestamp %q: %w", fields[5], err)
+	}
+	commit := &Commit{
+		SHA:            fields[0],
+		Author:         fields[1],
+		AuthorEmail:    fields[2],
+		Committer:      fields[3],
+		CommitterEmail: fields[4],
+		Timestamp:      timestamp,
+		Subject:        fields[6],
+	}
+
+	if len(commit.Subject) > maxSubjectLength {
+		commit.Subject = truncate(commit.Subject, maxSubjectLength)
+	}
+
+	// The tags are best-effort: origin may not be reachable anymore, e.g. without credentials.
+	tags, err := remoteTags(logger, path, commit.SHA)
+	if err != nil {
+		logger.Warnf("Unable to get the tags of commit %s: %s", commit.SHA, err)
+	}
+	length := 0
+	for i, tag := range tags {
+		length += len(tag) + 1
+		if length > maxTagsLength {
+			logger.Warnf("Omitting %d of the %d tags of commit %s", len(tags)-i, len(tags), commit.SHA)
+			break
+		}
+		commit.Tags = append(commit.Tags, tag)
+	}
+	return commit, nil
+}
+
+// truncate returns the longest prefix of s of at most n bytes that doesn't split a rune.
+func truncate(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+// remoteTags calls "git ls-remote --tags origin" to get the tags of origin pointing at the commit,
+// since the tags aren't fetched along with the revision. Annotated tags point at the commit through
+// their peeled "^{}" entry.
+func remoteTags(logger *zap.SugaredLogger, path, sha string) ([]string, error) {
+	output, err := run(logger, path, "ls-remote", "--tags", "origin")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the tags of origin: %w", err)
+	}
+	var tags []string
+	seen := map[string]bool{}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 || fields[0] != sha {
+			continue
+		}
+		tag := strings.TrimSuffix(strings.TrimPrefix(fields[1], "refs/tags/"), "^{}")
+		if !seen[tag] {
+			seen[tag] = true
+			tags = append(tags, tag)
+		}
+	}
+	return tags, nil
+}
+
 func showRef(logger *zap.SugaredLogger, revision, path string) (string, error) {
 	output, err := run(logger, path, "show", "-q", "--pretty=format:%D", revision)
 	if err != nil {
@@ -218,6 +374,39 @@ func submoduleFetch(logger *zap.SugaredLogger, spec FetchSpec) error {
 	return nil
 }
 
+// checkLFSInstalled returns an error if git-lfs isn't installed, as in the default git-init image.
+func checkLFSInstalled(logger *zap.SugaredLogger) error {
+	if _, err := run(logger, "", "lfs", "version"); err != nil {
+		return fmt.Errorf("fetching Git LFS objects requires git-lfs, which is not installed in this image: %w", err)
+	}
+	return nil
+}
+
+// lfsFetch fetches the Git LFS objects of the checked out revision, and replaces their pointer files.
+func lfsFetch(logger *zap.SugaredLogger, spec FetchSpec) error {
+	if _, err := run(logger, spec.Path, "lfs", "install", "--local"); err != nil {
+		return fmt.Errorf("failed to install git lfs: %w", err)
+	}
+	if _, err := run(logger, spec.Path, "lfs", "pull"); err != nil {
+		return fmt.Errorf("failed to fetch lfs objects: %w", err)
+	}
+	logger.Infof("Successfully fetched lfs objects in path %s", spec.Path)
+	return nil
+}
+
+// CountLFSFiles calls "git lfs ls-files" to count the files of the revision checked out in path stored with Git LFS.
+func CountLFSFiles(logger *zap.SugaredLogger, path string) (int, error) {
+	output, err := run(logger, path, "lfs", "ls-files", "--name-only")
+	if err != nil {
+		return 0, err
+	}
+	output = strings.TrimSpace(output)
+	if output == "" {
+		return 0, nil
+	}
+	return len(strings.Split(output, "\n")), nil
+}
+
 // ensureHomeEnv works around an issue where ssh doesn't respect the HOME env variable. If HOME is set and
 // different from the user's detected home directory then symlink .ssh from the home directory to the HOME env
 // var. This way ssh will see the .ssh directory in the user's home directory even though it ignores
@@ -17,13 +17,16 @@ package git
 
 import (
 	"bufio"
+	"fmt"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
 	"go.uber.org/zap/zaptest/observer"
 )
 
@@ -366,7 +369,7 @@ func TestFetch(t *testing.T) {
 			targetPath := t.TempDir()
 			tt.spec.Path = targetPath
 
-			if err := Fetch(logger, tt.spec); (err != nil) != tt.wantErr {
+			if _, err := Fetch(logger, tt.spec); (err != nil) != tt.wantErr {
 				t.Errorf("Fetch() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
@@ -452,3 +455,226 @@ func checkLogMessage(logMessage string, log *observer.ObservedLogs, logLine int,
 		}
 	}
 }
+
+func TestFetchWithFilter(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+	if _, err := run(logger, gitDir, "config", "uploadpack.allowFilter", "true"); err != nil {
+		t.Fatal(err)
+	}
+
+	targetPath := t.TempDir()
+	spec := FetchSpec{
+		// Objects are only filtered when fetching with the git protocol, rather than copying a local repository.
+		URL:      "file://" + gitDir,
+		Revision: "main",
+		Path:     targetPath,
+		Depth:    1,
+		Filter:   "blob:none",
+	}
+	if _, err := Fetch(logger, spec); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+
+	// The repository is a partial clone, which fetches the missing objects from origin.
+	promisor, err := run(logger, targetPath, "config", "remote.origin.promisor")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.TrimSpace(promisor); got != "true" {
+		t.Errorf("remote.origin.promisor = %q, want true", got)
+	}
+}
+
+func TestFetchLFSNotInstalled(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+
+	// Only git is found in the PATH, so that git-lfs is missing even if it is installed.
+	gitPath, err := exec.LookPath("git")
+	if err != nil {
+		t.Fatal(err)
+	}
+	binDir := t.TempDir()
+	if err := os.Symlink(gitPath, filepath.Join(binDir, "git")); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", binDir)
+	t.Setenv("GIT_EXEC_PATH", binDir)
+
+	targetPath := t.TempDir()
+	spec := FetchSpec{
+		URL:      "file://" + gitDir,
+		Revision: "main",
+		Path:     targetPath,
+		LFS:      true,
+	}
+	_, err = Fetch(logger, spec)
+	if err == nil || !strings.Contains(err.Error(), "requires git-lfs") {
+		t.Fatalf("Fetch() = %v, want an error about the missing git-lfs", err)
+	}
+	// Nothing is fetched when git-lfs is missing.
+	if _, err := os.Stat(filepath.Join(targetPath, ".git")); !os.IsNotExist(err) {
+		t.Errorf("Expected no repository in %s, got %v", targetPath, err)
+	}
+}
+
+func TestFetchCommitNotAdvertised(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+	commit, err := ShowCommit(logger, "HEAD", gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := run(logger, gitDir, "commit", "--allow-empty", "-m", "Hello again"); err != nil {
+		t.Fatal(err)
+	}
+	// Version 0 of the git protocol doesn't allow fetching objects that aren't at the tip of a ref.
+	if _, err := run(logger, "", "config", "--global", "protocol.version", "0"); err != nil {
+		t.Fatal(err)
+	}
+
+	targetPath := t.TempDir()
+	spec := FetchSpec{
+		URL:      "file://" + gitDir,
+		Revision: commit,
+		Path:     targetPath,
+		Depth:    1,
+	}
+	if _, err := Fetch(logger, spec); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+
+	got, err := ShowCommit(logger, "HEAD", targetPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != commit {
+		t.Errorf("HEAD = %s, want %s", got, commit)
+	}
+}
+
+func TestShowCommitMetadata(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+	t.Setenv("GIT_AUTHOR_NAME", "Tekton Author")
+	t.Setenv("GIT_AUTHOR_EMAIL", "author@tekton.dev")
+	t.Setenv("GIT_COMMITTER_DATE", "1640995200 +0000")
+	if _, err := run(logger, gitDir, "commit", "--allow-empty", "-m", "Add a feature\n\nWith a description."); err != nil {
+		t.Fatal(err)
+	}
+	// Tags of origin pointing at the commit, lightweight or annotated, and at another commit.
+	for _, args := range [][]string{{"tag", "latest"}, {"tag", "-a", "v1.0.0", "-m", "Release v1.0.0"}, {"tag", "v0.9.0", "HEAD~1"}} {
+		if _, err := run(logger, gitDir, args...); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sha, err := ShowCommit(logger, "HEAD", gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetPath := t.TempDir()
+	spec := FetchSpec{
+		URL:      "file://" + gitDir,
+		Revision: "main",
+		Path:     targetPath,
+		Depth:    1,
+	}
+	if _, err := Fetch(logger, spec); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+
+	got, err := ShowCommitMetadata(logger, "HEAD", targetPath)
+	if err != nil {
+		t.Fatalf("ShowCommitMetadata() = %v", err)
+	}
+	want := &Commit{
+		SHA:            sha,
+		Author:         "Tekton Author",
+		AuthorEmail:    "author@tekton.dev",
+		Committer:      "Tekton Test",
+		CommitterEmail: "tester@tekton.dev",
+		Timestamp:      1640995200,
+		Subject:        "Add a feature",
+		Tags:           []string{"latest", "v1.0.0"},
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("ShowCommitMetadata() -want +got: %s", d)
+	}
+}
+
+func TestShowCommitMetadataTruncated(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+	subject := strings.Repeat("é", maxSubjectLength)
+	if _, err := run(logger, gitDir, "commit", "--allow-empty", "-m", subject); err != nil {
+		t.Fatal(err)
+	}
+	// More tags than fit in the termination message.
+	var tags []string
+	for i := 0; i < 100; i++ {
+		tag := fmt.Sprintf("release-candidate-%03d", i)
+		if _, err := run(logger, gitDir, "tag", tag); err != nil {
+			t.Fatal(err)
+		}
+		tags = append(tags, tag)
+	}
+
+	targetPath := t.TempDir()
+	spec := FetchSpec{
+		URL:      "file://" + gitDir,
+		Revision: "main",
+		Path:     targetPath,
+		Depth:    1,
+	}
+	if _, err := Fetch(logger, spec); err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+
+	got, err := ShowCommitMetadata(logger, "HEAD", targetPath)
+	if err != nil {
+		t.Fatalf("ShowCommitMetadata() = %v", err)
+	}
+	if want := strings.Repeat("é", maxSubjectLength/2); got.Subject != want {
+		t.Errorf("Subject = %q, want %q", got.Subject, want)
+	}
+	if want := tags[:maxTagsLength/len("release-candidate-000,")]; !cmp.Equal(want, got.Tags) {
+		t.Errorf("Tags = %v, want %v", got.Tags, want)
+	}
+}
+
+func TestShowCommitMetadataWithoutOrigin(t *testing.T) {
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+	if _, err := run(logger, gitDir, "tag", "latest"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The tags of origin can't be listed, which isn't an error.
+	got, err := ShowCommitMetadata(logger, "HEAD", gitDir)
+	if err != nil {
+		t.Fatalf("ShowCommitMetadata() = %v", err)
+	}
+	if got.Tags != nil {
+		t.Errorf("Tags = %v, want none", got.Tags)
+	}
+}
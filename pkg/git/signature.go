@@ -0,0 +1,189 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// The files of the trusted keys directory. Other files are ignored.
+const (
+	// allowedSignersFile is an SSH allowed signers file, see ssh-keygen(1).
+	allowedSignersFile = "allowed_signers"
+	// sshPublicKeyExt is the extension of SSH public keys, whose principal is the name of the file without
+	// the extension.
+	sshPublicKeyExt = ".pub"
+	// gpgArmoredKeyExt and gpgBinaryKeyExt are the extensions of GPG public keys.
+	gpgArmoredKeyExt = ".asc"
+	gpgBinaryKeyExt  = ".gpg"
+)
+
+// Signature describes the verified signature of a commit.
+type Signature struct {
+	// Signer is the user ID of the GPG key, or the principal of the SSH key, that signed the commit.
+	Signer string
+	// Key is the fingerprint of the key that signed the commit.
+	Key string
+}
+
+// VerifyCommit verifies that the commit for the given revision is signed by one of the GPG or SSH keys in the
+// keysPath directory, and returns its signature.
+func VerifyCommit(logger *zap.SugaredLogger, revision, path, keysPath string) (*Signature, error) {
+	tmp, err := ioutil.TempDir("", "git-trusted-keys")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmp)
+	gnupgHome := filepath.Join(tmp, "gnupg")
+	if err := os.Mkdir(gnupgHome, 0700); err != nil {
+		return nil, err
+	}
+	signersPath := filepath.Join(tmp, allowedSignersFile)
+	if err := loadTrustedKeys(logger, keysPath, gnupgHome, signersPath); err != nil {
+		return nil, fmt.Errorf("error loading trusted keys from %s: %w", keysPath, err)
+	}
+
+	output, err := runWithEnv(logger, path, []string{"GNUPGHOME=" + gnupgHome},
+		"-c", "gpg.ssh.allowedSignersFile="+signersPath,
+		"show", "-q", "--pretty=format:%G?%x00%GS%x00%GF%x00%GK", revision)
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Split(strings.TrimSuffix(output, "\n"), "\x00")
+	if len(fields) != 4 {
+		return nil, fmt.Errorf("unexpected output of git show for %s: %q", revision, output)
+	}
+	status, signer, key := fields[0], fields[1], fields[2]
+	if key == "" {
+		key = fields[3]
+	}
+	switch status {
+	case "G":
+		return &Signature{Signer: signer, Key: key}, nil
+	case "N":
+		return nil, fmt.Errorf("commit %s is not signed", revision)
+	case "B":
+		return nil, fmt.Errorf("commit %s has a bad signature from key %s", revision, key)
+	case "U", "E":
+		return nil, fmt.Errorf("commit %s is signed by key %s, which is not trusted", revision, key)
+	case "X", "Y":
+		return nil, fmt.Errorf("commit %s is signed by key %s, which has expired", revision, key)
+	case "R":
+		return nil, fmt.Errorf("commit %s is signed by key %s, which has been revoked", revision, key)
+	default:
+		return nil, fmt.Errorf("commit %s has an unknown signature status %q", revision, status)
+	}
+}
+
+// loadTrustedKeys imports the GPG keys of keysPath into the keyring of gnupgHome, and writes the SSH keys of
+// keysPath to the allowed signers file at signersPath.
+func loadTrustedKeys(logger *zap.SugaredLogger, keysPath, gnupgHome, signersPath string) error {
+	files, err := ioutil.ReadDir(keysPath)
+	if err != nil {
+		return err
+	}
+	var gpgKeys []string
+	var signers bytes.Buffer
+	for _, f := range files {
+		// Secrets mounted as volumes contain hidden directories of the keys, and symlinks to them.
+		if f.IsDir() || strings.HasPrefix(f.Name(), ".") {
+			continue
+		}
+		p := filepath.Join(keysPath, f.Name())
+		ext := filepath.Ext(f.Name())
+		switch {
+		case f.Name() == allowedSignersFile:
+			b, err := ioutil.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			signers.Write(b)
+			signers.WriteString("\n")
+		case ext == sshPublicKeyExt:
+			b, err := ioutil.ReadFile(p)
+			if err != nil {
+				return err
+			}
+			for _, line := range strings.Split(string(b), "\n") {
+				if line = strings.TrimSpace(line); line != "" && !strings.HasPrefix(line, "#") {
+					fmt.Fprintf(&signers, "%s %s\n", strings.TrimSuffix(f.Name(), ext), line)
+				}
+			}
+		case ext == gpgArmoredKeyExt || ext == gpgBinaryKeyExt:
+			gpgKeys = append(gpgKeys, p)
+		}
+	}
+	if len(gpgKeys) == 0 && signers.Len() == 0 {
+		return fmt.Errorf("no GPG or SSH keys found")
+	}
+	if err := ioutil.WriteFile(signersPath, signers.Bytes(), 0600); err != nil {
+		return err
+	}
+	if len(gpgKeys) == 0 {
+		return nil
+	}
+
+	env := []string{"GNUPGHOME=" + gnupgHome}
+	if _, err := runGPG(logger, env, nil, append([]string{"--import"}, gpgKeys...)...); err != nil {
+		return err
+	}
+	// The keys are trusted by the user who provided them, so the signatures they make are valid.
+	output, err := runGPG(logger, env, nil, "--with-colons", "--list-keys")
+	if err != nil {
+		return err
+	}
+	var ownerTrust bytes.Buffer
+	primary := false
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(line, ":")
+		switch {
+		case fields[0] == "pub":
+			primary = true
+		case fields[0] == "fpr" && primary && len(fields) > 9:
+			fmt.Fprintf(&ownerTrust, "%s:6:\n", fields[9])
+			primary = false
+		case fields[0] == "sub":
+			primary = false
+		}
+	}
+	_, err = runGPG(logger, env, &ownerTrust, "--import-ownertrust")
+	return err
+}
+
+func runGPG(logger *zap.SugaredLogger, env []string, stdin *bytes.Buffer, args ...string) (string, error) {
+	c := exec.Command("gpg", append([]string{"--batch"}, args...)...)
+	c.Env = append(os.Environ(), env...)
+	if stdin != nil {
+		c.Stdin = stdin
+	}
+	var stdout, stderr bytes.Buffer
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+	if err := c.Run(); err != nil {
+		logger.Errorf("Error running gpg %v: %v\n%v", args, err, stderr.String())
+		return "", err
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,225 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
+)
+
+// createSSHKey creates an SSH key pair in dir, and returns the paths of the private and the public key.
+func createSSHKey(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+	key := filepath.Join(dir, name)
+	if out, err := exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-C", name, "-f", key).CombinedOutput(); err != nil {
+		t.Fatalf("Error creating SSH key: %v\n%s", err, out)
+	}
+	return key, key + ".pub"
+}
+
+// createGPGKey creates a GPG key for uid in a new keyring, and returns the keyring and the armored public key.
+func createGPGKey(t *testing.T, uid string) (string, []byte) {
+	t.Helper()
+	gnupgHome, err := ioutil.TempDir("", "gnupg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = exec.Command("gpgconf", "--homedir", gnupgHome, "--kill", "all").Run()
+		os.RemoveAll(gnupgHome)
+	})
+	env := []string{"GNUPGHOME=" + gnupgHome}
+	logger := zaptest.NewLogger(t).Sugar()
+	if _, err := runGPG(logger, env, nil, "--passphrase", "", "--quick-gen-key", uid, "ed25519", "sign", "never"); err != nil {
+		t.Fatal(err)
+	}
+	key, err := runGPG(logger, env, nil, "--armor", "--export", uid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return gnupgHome, []byte(key)
+}
+
+// signedCommit creates a commit signed with the signing configuration of config, and returns its SHA.
+func signedCommit(t *testing.T, logger *zap.SugaredLogger, gitDir string, env []string, config ...string) string {
+	t.Helper()
+	var args []string
+	for _, c := range config {
+		args = append(args, "-c", c)
+	}
+	args = append(args, "commit", "--allow-empty", "-S", "-m", "Signed")
+	if _, err := runWithEnv(logger, gitDir, env, args...); err != nil {
+		t.Fatal(err)
+	}
+	sha, err := ShowCommit(logger, "HEAD", gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sha
+}
+
+func TestVerifyCommit(t *testing.T) {
+	for _, bin := range []string{"gpg", "ssh-keygen"} {
+		if _, err := exec.LookPath(bin); err != nil {
+			t.Skipf("%s not found", bin)
+		}
+	}
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+	unsigned, err := ShowCommit(logger, "HEAD", gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keysDir := t.TempDir()
+	trustedKey, trustedPub := createSSHKey(t, keysDir, "trusted")
+	untrustedKey, _ := createSSHKey(t, t.TempDir(), "untrusted")
+	sshSigned := signedCommit(t, logger, gitDir, nil, "gpg.format=ssh", "user.signingkey="+trustedKey)
+	sshUntrusted := signedCommit(t, logger, gitDir, nil, "gpg.format=ssh", "user.signingkey="+untrustedKey)
+	gnupgHome, gpgPub := createGPGKey(t, "Tekton Signer <signer@tekton.dev>")
+	gpgSigned := signedCommit(t, logger, gitDir, []string{"GNUPGHOME=" + gnupgHome}, "gpg.format=openpgp", "user.signingkey=signer@tekton.dev")
+
+	trustedPubKey, err := ioutil.ReadFile(trustedPub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Only the public keys are trusted.
+	for _, f := range []string{"trusted", "trusted.pub"} {
+		if err := os.Remove(filepath.Join(keysDir, f)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range []struct {
+		name       string
+		keys       map[string]string
+		revision   string
+		wantSigner string
+		wantErr    string
+	}{{
+		name:       "ssh public key",
+		keys:       map[string]string{"trusted.pub": string(trustedPubKey)},
+		revision:   sshSigned,
+		wantSigner: "trusted",
+	}, {
+		name:       "ssh allowed signers",
+		keys:       map[string]string{"allowed_signers": "tester@tekton.dev " + string(trustedPubKey)},
+		revision:   sshSigned,
+		wantSigner: "tester@tekton.dev",
+	}, {
+		name:     "ssh untrusted key",
+		keys:     map[string]string{"trusted.pub": string(trustedPubKey)},
+		revision: sshUntrusted,
+		wantErr:  "not trusted",
+	}, {
+		name:       "gpg key",
+		keys:       map[string]string{"signer.asc": string(gpgPub)},
+		revision:   gpgSigned,
+		wantSigner: "Tekton Signer <signer@tekton.dev>",
+	}, {
+		name:     "gpg unknown key",
+		keys:     map[string]string{"trusted.pub": string(trustedPubKey)},
+		revision: gpgSigned,
+		wantErr:  "not trusted",
+	}, {
+		name:     "unsigned",
+		keys:     map[string]string{"trusted.pub": string(trustedPubKey)},
+		revision: unsigned,
+		wantErr:  "is not signed",
+	}, {
+		name:     "no keys",
+		keys:     map[string]string{"README": "Nothing to see here"},
+		revision: sshSigned,
+		wantErr:  "no GPG or SSH keys found",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			keysPath := t.TempDir()
+			for name, content := range tc.keys {
+				if err := ioutil.WriteFile(filepath.Join(keysPath, name), []byte(content), 0600); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			got, err := VerifyCommit(logger, tc.revision, gitDir, keysPath)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("VerifyCommit() = %v, want error containing %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyCommit() = %v", err)
+			}
+			if got.Signer != tc.wantSigner {
+				t.Errorf("Signer = %q, want %q", got.Signer, tc.wantSigner)
+			}
+			if got.Key == "" {
+				t.Error("Key is empty")
+			}
+		})
+	}
+}
+
+func TestFetchVerifySignature(t *testing.T) {
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not found")
+	}
+	withTemporaryGitConfig(t)
+	logger := zaptest.NewLogger(t).Sugar()
+
+	gitDir := t.TempDir()
+	createTempGit(t, logger, gitDir, "")
+	key, pub := createSSHKey(t, t.TempDir(), "trusted")
+	pubKey, err := ioutil.ReadFile(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keysPath := t.TempDir()
+	if err := ioutil.WriteFile(filepath.Join(keysPath, "trusted.pub"), pubKey, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	spec := FetchSpec{
+		URL:             gitDir,
+		Revision:        "main",
+		Path:            t.TempDir(),
+		TrustedKeysPath: keysPath,
+	}
+	if _, err := Fetch(logger, spec); err == nil || !strings.Contains(err.Error(), "is not signed") {
+		t.Fatalf("Fetch() = %v, want an unsigned revision error", err)
+	}
+
+	signedCommit(t, logger, gitDir, nil, "gpg.format=ssh", "user.signingkey="+key)
+	spec.Path = t.TempDir()
+	signature, err := Fetch(logger, spec)
+	if err != nil {
+		t.Fatalf("Fetch() = %v", err)
+	}
+	if signature == nil || signature.Signer != "trusted" {
+		t.Errorf("Fetch() = %+v, want a signature by trusted", signature)
+	}
+}
@@ -18,7 +18,10 @@ package computeresources
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/internal/computeresources/limitrange"
 	"github.com/tektoncd/pipeline/pkg/pod"
 	corev1 "k8s.io/api/core/v1"
@@ -28,17 +31,86 @@ import (
 
 var resourceNames = []corev1.ResourceName{corev1.ResourceCPU, corev1.ResourceMemory, corev1.ResourceEphemeralStorage}
 
-// NewTransformer returns a pod.Transformer that will modify limits if needed
-func NewTransformer(ctx context.Context, namespace string, lister corev1listers.LimitRangeLister) pod.Transformer {
+// ErrInsufficientTaskLevelRequests is returned when the task-level compute resources requests
+// cannot be distributed over the step containers within the LimitRange minimum.
+var ErrInsufficientTaskLevelRequests = errors.New("task-level compute resources requests are lower than the LimitRange minimum")
+
+// NewTransformer returns a pod.Transformer that will modify limits if needed.
+// computeResources are the task-level compute resources of the TaskRun, if any.
+func NewTransformer(ctx context.Context, namespace string, lister corev1listers.LimitRangeLister, computeResources *corev1.ResourceRequirements) pod.Transformer {
 	return func(p *corev1.Pod) (*corev1.Pod, error) {
 		limitRange, err := limitrange.GetVirtualLimitRange(namespace, lister)
 		if err != nil {
 			return p, err
 		}
+		if computeResources != nil && config.FromContextOrDefaults(ctx).FeatureFlags.EnableAPIFields == config.AlphaAPIFields {
+			return transformPodWithTaskLevelRequests(p, limitRange, pod.TaskLevelRequests(computeResources))
+		}
 		return transformPodBasedOnLimitRange(p, limitRange), nil
 	}
 }
 
+// transformPodWithTaskLevelRequests modifies the pod's containers' resource requirements to meet the
+// constraints of the LimitRange, while keeping the sum of the step containers' requests equal to the
+// task-level requests, as assigned by the pod builder.
+// For each resource of the task-level requests:
+// - The LimitRange default requests are not applied to the step containers.
+// - Each step container but the first one is requested the LimitRange minimum, which is subtracted from
+// the request of the first step container. ErrInsufficientTaskLevelRequests is returned if the first step
+// container would be requested less than the minimum.
+// - Init container requests are capped at the task-level request, so that they don't raise the pod's
+// effective request.
+// Other resources are transformed as in transformPodBasedOnLimitRange.
+func transformPodWithTaskLevelRequests(p *corev1.Pod, limitRange *corev1.LimitRange, requests corev1.ResourceList) (*corev1.Pod, error) {
+	if limitRange == nil {
+		return p, nil
+	}
+	p = transformPodBasedOnLimitRange(p, limitRange)
+
+	var steps []int
+	for i, c := range p.Spec.Containers {
+		if pod.IsContainerStep(c.Name) {
+			steps = append(steps, i)
+		}
+	}
+	if len(steps) == 0 {
+		return p, nil
+	}
+
+	minRequests := getMinRequests(limitRange)
+	for name, request := range requests {
+		min := minRequests[name]
+		remaining := request.DeepCopy()
+		for _, i := range steps[1:] {
+			remaining.Sub(min)
+			setRequest(&p.Spec.Containers[i], name, min.DeepCopy())
+		}
+		if remaining.Cmp(min) < 0 {
+			return p, fmt.Errorf("%w: %s request %s does not cover the minimum %s of each of the %d steps",
+				ErrInsufficientTaskLevelRequests, name, request.String(), min.String(), len(steps))
+		}
+		setRequest(&p.Spec.Containers[steps[0]], name, remaining)
+
+		for i := range p.Spec.InitContainers {
+			if q, ok := p.Spec.InitContainers[i].Resources.Requests[name]; ok && q.Cmp(request) > 0 {
+				setRequest(&p.Spec.InitContainers[i], name, request.DeepCopy())
+			}
+		}
+	}
+	return p, nil
+}
+
+// setRequest sets the request of a container for a resource. The requests are copied first
+// since the LimitRange defaults may be shared between containers.
+func setRequest(c *corev1.Container, name corev1.ResourceName, q resource.Quantity) {
+	requests := c.Resources.Requests.DeepCopy()
+	if requests == nil {
+		requests = corev1.ResourceList{}
+	}
+	requests[name] = q
+	c.Resources.Requests = requests
+}
+
 // transformPodBasedOnLimitRange modifies the pod's containers' resource requirements to meet the constraints of the LimitRange.
 // The only supported type of LimitRange is "Container".
 // For any container:
@@ -175,6 +247,18 @@ func getDefaultContainerRequest(limitRange *corev1.LimitRange) corev1.ResourceLi
 	return r
 }
 
+// Returns the LimitRange minimum requests of containers
+func getMinRequests(limitRange *corev1.LimitRange) corev1.ResourceList {
+	// Support only Type Container to start with
+	var r corev1.ResourceList
+	for _, item := range limitRange.Spec.Limits {
+		if item.Type == corev1.LimitTypeContainer && item.Min != nil {
+			r = item.Min
+		}
+	}
+	return r
+}
+
 func takeTheMax(requestQ, defaultQ, maxQ resource.Quantity) resource.Quantity {
 	var q resource.Quantity = requestQ
 	if defaultQ.Cmp(q) > 0 {
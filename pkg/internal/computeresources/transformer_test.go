@@ -16,6 +16,7 @@ limitations under the License.
 package computeresources
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -975,6 +976,143 @@ func TestTransformerMultipleContainer(t *testing.T) {
 	}
 }
 
+func TestTransformerWithTaskLevelRequests(t *testing.T) {
+	// The pod as assigned by the pod builder for task-level requests of 2 CPUs.
+	podspec := func() corev1.PodSpec {
+		return corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name: "prepare",
+			}},
+			Containers: []corev1.Container{{
+				Name: "step-a",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			}, {
+				Name: "step-b",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0")},
+				},
+			}, {
+				Name: "step-c",
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0")},
+				},
+			}, {
+				Name: "sidecar-a",
+			}},
+		}
+	}
+	requests := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}
+
+	for _, tc := range []struct {
+		description string
+		limitRange  *corev1.LimitRange
+		want        corev1.PodSpec
+	}{{
+		description: "no limit range, no change",
+		want:        podspec(),
+	}, {
+		description: "limitRange minimum is taken from the first step",
+		limitRange: &corev1.LimitRange{Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+			Type: corev1.LimitTypeContainer,
+			Min:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("300m")},
+			DefaultRequest: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("1"),
+				corev1.ResourceMemory: resource.MustParse("300Mi"),
+			},
+		}}}},
+		want: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("300Mi")},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1400m"), corev1.ResourceMemory: resource.MustParse("100Mi")},
+				},
+			}, {
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("300m"), corev1.ResourceMemory: resource.MustParse("100Mi")},
+				},
+			}, {
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("300m"), corev1.ResourceMemory: resource.MustParse("100Mi")},
+				},
+			}, {
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("300Mi")},
+				},
+			}},
+		},
+	}, {
+		description: "init container requests are capped at the task-level requests",
+		limitRange: &corev1.LimitRange{Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+			Type:           corev1.LimitTypeContainer,
+			DefaultRequest: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+		}}}},
+		want: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			}},
+			Containers: []corev1.Container{{
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				},
+			}, {
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0")},
+				},
+			}, {
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0")},
+				},
+			}, {
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3")},
+				},
+			}},
+		},
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			pod := corev1.Pod{Spec: podspec()}
+			got, err := transformPodWithTaskLevelRequests(&pod, tc.limitRange, requests)
+			if err != nil {
+				t.Fatalf("transformPodWithTaskLevelRequests() = %v", err)
+			}
+			cmpRequestsAndLimits(t, tc.want, got.Spec)
+		})
+	}
+}
+
+func TestTransformerWithTaskLevelRequestsBelowMinimum(t *testing.T) {
+	pod := corev1.Pod{Spec: corev1.PodSpec{
+		Containers: []corev1.Container{{
+			Name: "step-a",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			},
+		}, {
+			Name: "step-b",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0")},
+			},
+		}},
+	}}
+	limitRange := &corev1.LimitRange{Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+		Type: corev1.LimitTypeContainer,
+		Min:  corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("300m")},
+	}}}}
+
+	_, err := transformPodWithTaskLevelRequests(&pod, limitRange, corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")})
+	if !errors.Is(err, ErrInsufficientTaskLevelRequests) {
+		t.Errorf("expected ErrInsufficientTaskLevelRequests, got %v", err)
+	}
+}
+
 func cmpRequestsAndLimits(t *testing.T, want, got corev1.PodSpec) {
 	// diff init containers
 	if len(want.InitContainers) != len(got.InitContainers) {
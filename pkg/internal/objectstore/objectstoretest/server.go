@@ -0,0 +1,133 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectstoretest provides an in-memory S3-compatible storage for tests.
+package objectstoretest
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// maxKeys is the number of keys listed per page, kept low so that the
+// pagination of the listings is exercised.
+const maxKeys = 2
+
+// Object is an object stored in the Server.
+type Object struct {
+	Content []byte
+	Header  http.Header
+}
+
+// Server is an S3-compatible storage serving path-style requests from memory.
+type Server struct {
+	*httptest.Server
+
+	mu sync.Mutex
+	// objects holds the objects of each bucket by key.
+	objects map[string]map[string]Object
+}
+
+// NewServer starts a Server with no buckets. The caller must close it.
+func NewServer() *Server {
+	s := &Server{objects: map[string]map[string]Object{}}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Bucket returns the objects of the bucket.
+func (s *Server) Bucket(bucket string) map[string]Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	objects := map[string]Object{}
+	for k, o := range s.objects[bucket] {
+		objects[k] = o
+	}
+	return objects
+}
+
+type listBucketResult struct {
+	XMLName  xml.Name `xml:"ListBucketResult"`
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken,omitempty"`
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	bucket, key := strings.TrimPrefix(r.URL.Path, "/"), ""
+	if i := strings.Index(bucket, "/"); i >= 0 {
+		bucket, key = bucket[:i], bucket[i+1:]
+	}
+
+	switch {
+	case r.Method == http.MethodPut && key != "":
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if s.objects[bucket] == nil {
+			s.objects[bucket] = map[string]Object{}
+		}
+		s.objects[bucket][key] = Object{Content: b, Header: r.Header.Clone()}
+	case r.Method == http.MethodGet && key != "":
+		o, ok := s.objects[bucket][key]
+		if !ok {
+			http.Error(w, "NoSuchKey", http.StatusNotFound)
+			return
+		}
+		for k, v := range o.Header {
+			if strings.HasPrefix(k, "X-Amz-Meta-") {
+				w.Header()[k] = v
+			}
+		}
+		w.Write(o.Content)
+	case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+		prefix := r.URL.Query().Get("prefix")
+		var keys []string
+		for k := range s.objects[bucket] {
+			if strings.HasPrefix(k, prefix) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		start, _ := strconv.Atoi(r.URL.Query().Get("continuation-token"))
+		result := listBucketResult{}
+		for i := start; i < len(keys) && i < start+maxKeys; i++ {
+			result.Contents = append(result.Contents, struct {
+				Key string `xml:"Key"`
+			}{keys[i]})
+		}
+		if start+maxKeys < len(keys) {
+			result.IsTruncated = true
+			result.NextContinuationToken = strconv.Itoa(start + maxKeys)
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		xml.NewEncoder(w).Encode(result)
+	default:
+		http.Error(w, "NotImplemented", http.StatusNotImplemented)
+	}
+}
@@ -0,0 +1,230 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package objectstore stores objects in S3-compatible storages.
+package objectstore
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+const (
+	// AccessKeyIDKey is the key of the access key ID in the secrets holding the
+	// credentials of an S3-compatible storage.
+	AccessKeyIDKey = "access-key-id"
+	// SecretAccessKeyKey is the key of the secret access key in the secrets holding
+	// the credentials of an S3-compatible storage.
+	SecretAccessKeyKey = "secret-access-key"
+	// SessionTokenKey is the key of the optional session token in the secrets holding
+	// the credentials of an S3-compatible storage.
+	SessionTokenKey = "session-token"
+)
+
+// S3Client stores objects in the buckets of an S3-compatible storage, such as AWS S3
+// or MinIO, with requests signed with AWS Signature Version 4.
+type S3Client struct {
+	HTTPClient *http.Client
+	Endpoint   *url.URL
+	Region     string
+	// PathStyle addresses the buckets in the path of the requests, as most S3-compatible
+	// storages expect, instead of in the host name.
+	PathStyle bool
+	// Credentials sign the requests, which are anonymous when nil.
+	Credentials *aws.Credentials
+
+	signer *v4.Signer
+}
+
+// Object is the content of an object, with its user-defined metadata.
+type Object struct {
+	Body     io.ReadCloser
+	Metadata map[string]string
+}
+
+// metadataPrefix is the prefix of the headers holding the user-defined metadata of the objects.
+const metadataPrefix = "X-Amz-Meta-"
+
+// emptyPayloadHash is the SHA-256 hash of the empty payload of the requests without a body.
+const emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+// NewS3Client returns an S3Client sending path-style requests to the endpoint, or to the
+// AWS S3 endpoint of the region when empty.
+func NewS3Client(endpoint, region string, credentials *aws.Credentials) (*S3Client, error) {
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", region)
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid S3 endpoint %q: %w", endpoint, err)
+	}
+	return &S3Client{
+		HTTPClient:  http.DefaultClient,
+		Endpoint:    u,
+		Region:      region,
+		PathStyle:   true,
+		Credentials: credentials,
+		signer:      v4.NewSigner(),
+	}, nil
+}
+
+// Put stores the content of the body under the key in the bucket, and returns the
+// s3:// URL of the object.
+func (c *S3Client) Put(ctx context.Context, bucket, key string, body io.ReadSeeker, contentType string) (string, error) {
+	return c.PutWithMetadata(ctx, bucket, key, body, contentType, nil)
+}
+
+// PutWithMetadata stores the content of the body under the key in the bucket along with the
+// user-defined metadata, and returns the s3:// URL of the object.
+func (c *S3Client) PutWithMetadata(ctx context.Context, bucket, key string, body io.ReadSeeker, contentType string, metadata map[string]string) (string, error) {
+	// The payload is hashed for the signature, then sent from the start.
+	h := sha256.New()
+	size, err := io.Copy(h, body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the content of %s: %w", key, err)
+	}
+	if _, err := body.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	payloadHash := hex.EncodeToString(h.Sum(nil))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.objectURL(bucket, key, nil), io.NopCloser(body))
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range metadata {
+		req.Header.Set(metadataPrefix+k, v)
+	}
+	resp, err := c.do(ctx, req, payloadHash)
+	if err != nil {
+		return "", fmt.Errorf("storing %s in bucket %s failed: %w", key, bucket, err)
+	}
+	resp.Body.Close()
+	return fmt.Sprintf("s3://%s/%s", bucket, key), nil
+}
+
+// Get returns the object stored under the key in the bucket. The caller must close
+// its body.
+func (c *S3Client) Get(ctx context.Context, bucket, key string) (*Object, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.objectURL(bucket, key, nil), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.do(ctx, req, emptyPayloadHash)
+	if err != nil {
+		return nil, fmt.Errorf("getting %s from bucket %s failed: %w", key, bucket, err)
+	}
+	metadata := map[string]string{}
+	for k := range resp.Header {
+		if strings.HasPrefix(k, metadataPrefix) {
+			metadata[strings.ToLower(strings.TrimPrefix(k, metadataPrefix))] = resp.Header.Get(k)
+		}
+	}
+	return &Object{Body: resp.Body, Metadata: metadata}, nil
+}
+
+type listBucketResult struct {
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// List returns the keys of the objects of the bucket starting with the prefix.
+func (c *S3Client) List(ctx context.Context, bucket, prefix string) ([]string, error) {
+	var keys []string
+	token := ""
+	for {
+		query := url.Values{"list-type": []string{"2"}, "prefix": []string{prefix}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.objectURL(bucket, "", query), nil)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.do(ctx, req, emptyPayloadHash)
+		if err != nil {
+			return nil, fmt.Errorf("listing %s in bucket %s failed: %w", prefix, bucket, err)
+		}
+		result := listBucketResult{}
+		err = xml.NewDecoder(resp.Body).Decode(&result)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode the objects of bucket %s: %w", bucket, err)
+		}
+		for _, o := range result.Contents {
+			keys = append(keys, o.Key)
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// objectURL returns the URL of the key in the bucket, or of the bucket when the key is empty.
+func (c *S3Client) objectURL(bucket, key string, query url.Values) string {
+	u := *c.Endpoint
+	if c.PathStyle {
+		u.Path = path.Join("/", u.Path, bucket, key)
+	} else {
+		u.Host = bucket + "." + u.Host
+		u.Path = path.Join("/", u.Path, key)
+	}
+	if key == "" && !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// do signs the request with the hash of its payload, sends it, and returns the response when
+// it is successful.
+func (c *S3Client) do(ctx context.Context, req *http.Request, payloadHash string) (*http.Response, error) {
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+	if c.Credentials != nil {
+		if err := c.signer.SignHTTP(ctx, *c.Credentials, req, payloadHash, "s3", c.Region, time.Now()); err != nil {
+			return nil, fmt.Errorf("failed to sign the request: %w", err)
+		}
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("status %s: %s", resp.Status, b)
+	}
+	return resp, nil
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectstore_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/internal/objectstore"
+	"github.com/tektoncd/pipeline/pkg/internal/objectstore/objectstoretest"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func TestNewS3ClientDefaultEndpoint(t *testing.T) {
+	c, err := objectstore.NewS3Client("", "eu-west-1", nil)
+	if err != nil {
+		t.Fatalf("NewS3Client() = %v", err)
+	}
+	if got, want := c.Endpoint.String(), "https://s3.eu-west-1.amazonaws.com"; got != want {
+		t.Errorf("Endpoint = %q, want %q", got, want)
+	}
+}
+
+func TestS3ClientPut(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		credentials *aws.Credentials
+		wantSigned  bool
+	}{{
+		name: "anonymous",
+	}, {
+		name:        "signed",
+		credentials: &aws.Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"},
+		wantSigned:  true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var gotPath, gotAuthorization, gotContentType, gotBody string
+			var gotLength int64
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPut {
+					t.Errorf("Expected a PUT request, got %s", r.Method)
+				}
+				b, _ := io.ReadAll(r.Body)
+				gotPath, gotAuthorization, gotContentType, gotBody, gotLength = r.URL.Path, r.Header.Get("Authorization"), r.Header.Get("Content-Type"), string(b), r.ContentLength
+			}))
+			defer server.Close()
+
+			c, err := objectstore.NewS3Client(server.URL, "us-east-1", tc.credentials)
+			if err != nil {
+				t.Fatalf("NewS3Client() = %v", err)
+			}
+			got, err := c.Put(context.Background(), "tekton-logs", "foo/step.log", strings.NewReader("hello world"), "text/plain")
+			if err != nil {
+				t.Fatalf("Put() = %v", err)
+			}
+			if want := "s3://tekton-logs/foo/step.log"; got != want {
+				t.Errorf("Put() = %q, want %q", got, want)
+			}
+			if want := "/tekton-logs/foo/step.log"; gotPath != want {
+				t.Errorf("Expected the object to be stored at %q, got %q", want, gotPath)
+			}
+			if gotBody != "hello world" || gotLength != int64(len("hello world")) {
+				t.Errorf("Unexpected content %q of length %d", gotBody, gotLength)
+			}
+			if gotContentType != "text/plain" {
+				t.Errorf("Unexpected content type %q", gotContentType)
+			}
+			if signed := strings.HasPrefix(gotAuthorization, "AWS4-HMAC-SHA256 Credential=minio/"); signed != tc.wantSigned {
+				t.Errorf("Expected the request to be signed %t, got Authorization %q", tc.wantSigned, gotAuthorization)
+			}
+		})
+	}
+}
+
+func TestS3ClientGetAndList(t *testing.T) {
+	server := objectstoretest.NewServer()
+	defer server.Close()
+	c, err := objectstore.NewS3Client(server.URL, "us-east-1", &aws.Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"})
+	if err != nil {
+		t.Fatalf("NewS3Client() = %v", err)
+	}
+	ctx := context.Background()
+	for _, key := range []string{"run/a.txt", "run/b/c.txt", "run/b/d.txt", "other/e.txt"} {
+		if _, err := c.PutWithMetadata(ctx, "artifacts", key, strings.NewReader(key), "text/plain", map[string]string{"mode": "0644"}); err != nil {
+			t.Fatalf("PutWithMetadata() = %v", err)
+		}
+	}
+
+	keys, err := c.List(ctx, "artifacts", "run/")
+	if err != nil {
+		t.Fatalf("List() = %v", err)
+	}
+	if d := cmp.Diff([]string{"run/a.txt", "run/b/c.txt", "run/b/d.txt"}, keys); d != "" {
+		t.Errorf("List() %s", diff.PrintWantGot(d))
+	}
+
+	o, err := c.Get(ctx, "artifacts", "run/b/c.txt")
+	if err != nil {
+		t.Fatalf("Get() = %v", err)
+	}
+	defer o.Body.Close()
+	b, err := io.ReadAll(o.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != "run/b/c.txt" {
+		t.Errorf("Get() returned content %q", b)
+	}
+	if d := cmp.Diff(map[string]string{"mode": "0644"}, o.Metadata); d != "" {
+		t.Errorf("Get() metadata %s", diff.PrintWantGot(d))
+	}
+
+	if _, err := c.Get(ctx, "artifacts", "missing"); err == nil || !strings.Contains(err.Error(), "404") {
+		t.Errorf("Get() of a missing object = %v, want a 404 error", err)
+	}
+}
+
+func TestS3ClientVirtualHostedStyle(t *testing.T) {
+	var gotHost, gotPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost, gotPath = r.Host, r.URL.Path
+	}))
+	defer server.Close()
+
+	c, err := objectstore.NewS3Client("http://s3.localhost", "us-east-1", nil)
+	if err != nil {
+		t.Fatalf("NewS3Client() = %v", err)
+	}
+	c.PathStyle = false
+	// The requests are sent to the test server, whatever their host.
+	c.HTTPClient = &http.Client{Transport: &http.Transport{
+		Proxy: func(*http.Request) (*url.URL, error) { return url.Parse(server.URL) },
+	}}
+	if _, err := c.Put(context.Background(), "tekton-logs", "foo/step.log", strings.NewReader("hello world"), "text/plain"); err != nil {
+		t.Fatalf("Put() = %v", err)
+	}
+	if gotHost != "tekton-logs.s3.localhost" || gotPath != "/foo/step.log" {
+		t.Errorf("Expected the object to be stored at tekton-logs.s3.localhost/foo/step.log, got %s%s", gotHost, gotPath)
+	}
+}
@@ -43,6 +43,7 @@ var (
 	pipelineTag    = tag.MustNewKey("pipeline")
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
+	reasonTag      = tag.MustNewKey("reason")
 
 	prDuration = stats.Float64(
 		"pipelinerun_duration_seconds",
@@ -59,11 +60,22 @@ var (
 		"Number of pipelineruns executing currently",
 		stats.UnitDimensionless)
 	runningPRsCountView *view.View
+
+	prDeletedCount = stats.Float64("pipelinerun_deleted_count",
+		"number of finished pipelineruns deleted by the controller",
+		stats.UnitDimensionless)
+	prDeletedCountView *view.View
 )
 
 const (
 	// ReasonCancelled indicates that a PipelineRun was cancelled.
 	ReasonCancelled = "Cancelled"
+	// DeletionReasonTTLExpired indicates that a PipelineRun was deleted because
+	// its ttlSecondsAfterFinished expired.
+	DeletionReasonTTLExpired = "TTLExpired"
+	// DeletionReasonMaxCompletedExceeded indicates that a PipelineRun was deleted
+	// because its Pipeline had more completed PipelineRuns than allowed.
+	DeletionReasonMaxCompletedExceeded = "MaxCompletedExceeded"
 )
 
 // Recorder holds keys for Tekton metrics
@@ -160,16 +172,23 @@ func viewRegister(cfg *config.Metrics) error {
 		Measure:     runningPRsCount,
 		Aggregation: view.LastValue(),
 	}
+	prDeletedCountView = &view.View{
+		Description: prDeletedCount.Description(),
+		Measure:     prDeletedCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{namespaceTag, reasonTag},
+	}
 
 	return view.Register(
 		prDurationView,
 		prCountView,
 		runningPRsCountView,
+		prDeletedCountView,
 	)
 }
 
 func viewUnregister() {
-	view.Unregister(prDurationView, prCountView, runningPRsCountView)
+	view.Unregister(prDurationView, prCountView, runningPRsCountView, prDeletedCountView)
 }
 
 // MetricsOnStore returns a function that checks if metrics are configured for a config.Store, and registers it if so
@@ -288,6 +307,29 @@ func (r *Recorder) RunningPipelineRuns(lister listers.PipelineRunLister) error {
 	return nil
 }
 
+// DeletedCount logs the deletion of a finished PipelineRun by the controller
+// for the given reason
+// returns an error if its failed to log the metrics
+func (r *Recorder) DeletedCount(ctx context.Context, pr *v1beta1.PipelineRun, reason string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", pr.Name)
+	}
+
+	ctx, err := tag.New(
+		ctx,
+		tag.Insert(namespaceTag, pr.Namespace),
+		tag.Insert(reasonTag, reason))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, prDeletedCount.M(1))
+
+	return nil
+}
+
 // ReportRunningPipelineRuns invokes RunningPipelineRuns on our configured PeriodSeconds
 // until the context is cancelled.
 func (r *Recorder) ReportRunningPipelineRuns(ctx context.Context, lister listers.PipelineRunLister) {
@@ -377,8 +377,30 @@ func TestRecordRunningPipelineRunsCount(t *testing.T) {
 
 }
 
+func TestRecordPipelineRunDeletedCount(t *testing.T) {
+	for _, reason := range []string{DeletionReasonTTLExpired, DeletionReasonMaxCompletedExceeded} {
+		t.Run(reason, func(t *testing.T) {
+			unregisterMetrics()
+
+			ctx := getConfigContext()
+			metrics, err := NewRecorder(ctx)
+			if err != nil {
+				t.Fatalf("NewRecorder: %v", err)
+			}
+
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1", Namespace: "ns"},
+			}
+			if err := metrics.DeletedCount(ctx, pr, reason); err != nil {
+				t.Errorf("DeletedCount: %v", err)
+			}
+			metricstest.CheckCountData(t, "pipelinerun_deleted_count", map[string]string{"namespace": "ns", "reason": reason}, 1)
+		})
+	}
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count")
+	metricstest.Unregister("pipelinerun_duration_seconds", "pipelinerun_count", "running_pipelineruns_count", "pipelinerun_deleted_count")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
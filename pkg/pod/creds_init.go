@@ -101,6 +101,9 @@ func credsInit(ctx context.Context, serviceAccountName, namespace string, kubecl
 				args = append(args, sa...)
 			}
 		}
+		// The Docker credential helpers, credential store and registry mirrors don't read
+		// the secret, which is only mounted for the credentials matched above.
+		args = append(args, dockercreds.HelperAnnotations(secret.Annotations)...)
 
 		if matched {
 			// While secret names can use RFC1123 DNS subdomain name rules, the volume mount
@@ -122,6 +125,10 @@ func credsInit(ctx context.Context, serviceAccountName, namespace string, kubecl
 		}
 	}
 
+	// The Docker credential helpers, credential store and registry mirrors can also be
+	// configured with annotations of the service account, without a secret.
+	args = append(args, dockercreds.HelperAnnotations(sa.Annotations)...)
+
 	if len(args) == 0 {
 		// There are no creds to initialize.
 		return nil, nil, nil, nil
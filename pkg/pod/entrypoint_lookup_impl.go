@@ -20,6 +20,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/containerd/containerd/platforms"
 	"github.com/google/go-containerregistry/pkg/authn/k8schain"
@@ -28,27 +29,79 @@ import (
 	"github.com/google/go-containerregistry/pkg/v1/remote"
 	lru "github.com/hashicorp/golang-lru"
 	specs "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"knative.dev/pkg/configmap"
 )
 
 const cacheSize = 1024
 
 type entrypointCache struct {
 	kubeclient kubernetes.Interface
-	lru        *lru.Cache // cache of digest->map[string][]string commands
+	cache      imageCache // cache of digest->map[string][]string commands
+}
+
+// imageCache stores the data of the images looked up in registries, by digest.
+type imageCache interface {
+	get(digest name.Digest) (*imageData, bool)
+	add(ctx context.Context, digest name.Digest, id *imageData)
+}
+
+// lruImageCache is an imageCache kept in the memory of the controller.
+type lruImageCache struct {
+	lru *lru.Cache
+}
+
+func (c *lruImageCache) get(digest name.Digest) (*imageData, bool) {
+	id, ok := c.lru.Get(digest.String())
+	if !ok {
+		return nil, false
+	}
+	return id.(*imageData), true
+}
+
+func (c *lruImageCache) add(_ context.Context, digest name.Digest, id *imageData) {
+	c.lru.Add(digest.String(), id)
 }
 
 // NewEntrypointCache returns a new entrypoint cache implementation that uses
 // K8s credentials to pull image metadata from a container image registry.
 func NewEntrypointCache(kubeclient kubernetes.Interface) (EntrypointCache, error) {
+	if err := registerLookupViews(); err != nil {
+		return nil, err
+	}
 	lru, err := lru.New(cacheSize)
 	if err != nil {
 		return nil, err
 	}
 	return &entrypointCache{
 		kubeclient: kubeclient,
-		lru:        lru,
+		cache:      &lruImageCache{lru: lru},
+	}, nil
+}
+
+// NewPersistentEntrypointCache returns a new entrypoint cache implementation
+// that shares the image metadata pulled from container image registries with
+// the other controller replicas, and across restarts, through the ConfigMap
+// configured in opts in the given namespace. The cache observes the ConfigMap
+// through cmw so that the entries removed from it are invalidated.
+func NewPersistentEntrypointCache(kubeclient kubernetes.Interface, cmw configmap.Watcher, namespace string, opts pipeline.EntrypointCacheOptions) (EntrypointCache, error) {
+	if err := registerLookupViews(); err != nil {
+		return nil, err
+	}
+	cache := newConfigMapImageCache(kubeclient, namespace, opts)
+	if dw, ok := cmw.(configmap.DefaultingWatcher); ok {
+		dw.WatchWithDefault(corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: opts.ConfigMapName, Namespace: namespace},
+		}, cache.observe)
+	} else {
+		cmw.Watch(opts.ConfigMapName, cache.observe)
+	}
+	return &entrypointCache{
+		kubeclient: kubeclient,
+		cache:      cache,
 	}, nil
 }
 
@@ -60,8 +113,9 @@ func NewEntrypointCache(kubeclient kubernetes.Interface) (EntrypointCache, error
 func (e *entrypointCache) get(ctx context.Context, ref name.Reference, namespace, serviceAccountName string, imagePullSecrets []corev1.LocalObjectReference, hasArgs bool) (*imageData, error) {
 	// If image is specified by digest, check the local cache.
 	if digest, ok := ref.(name.Digest); ok {
-		if id, ok := e.lru.Get(digest.String()); ok {
-			return id.(*imageData), nil
+		if id, ok := e.cache.get(digest); ok {
+			recordCacheLookup(ctx)
+			return id, nil
 		}
 	}
 
@@ -79,19 +133,37 @@ func (e *entrypointCache) get(ctx context.Context, ref name.Reference, namespace
 		return nil, fmt.Errorf("error creating k8schain: %v", err)
 	}
 
+	start := time.Now()
 	desc, err := remote.Get(ref, remote.WithAuthFromKeychain(kc))
 	if err != nil {
+		recordRegistryLookup(ctx, start, err)
 		return nil, err
 	}
 
 	// Check the cache for this ref@digest, in case we've seen it before.
 	// This saves looking up each constinuent image's commands if we've seen
 	// the multi-platform image before.
-	refByDigest := ref.Context().Digest(desc.Digest.String()).String()
-	if id, ok := e.lru.Get(refByDigest); ok {
-		return id.(*imageData), nil
+	refByDigest := ref.Context().Digest(desc.Digest.String())
+	if id, ok := e.cache.get(refByDigest); ok {
+		recordRegistryLookup(ctx, start, nil)
+		return id, nil
+	}
+
+	id, err := lookupImageData(desc, hasArgs)
+	recordRegistryLookup(ctx, start, err)
+	if err != nil {
+		return nil, err
 	}
 
+	// Cache the digest->commands for future lookup.
+	e.cache.add(ctx, refByDigest, id)
+
+	return id, nil
+}
+
+// lookupImageData looks up the commands of the image or the images of the
+// index described by desc.
+func lookupImageData(desc *remote.Descriptor, hasArgs bool) (*imageData, error) {
 	id := &imageData{
 		digest:   desc.Digest,
 		commands: map[string][]string{},
@@ -119,10 +191,6 @@ func (e *entrypointCache) get(ctx context.Context, ref name.Reference, namespace
 	default:
 		return nil, errors.New("unsupported media type for image reference")
 	}
-
-	// Cache the digest->commands for future lookup.
-	e.lru.Add(refByDigest, id)
-
 	return id, nil
 }
 
@@ -0,0 +1,111 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"knative.dev/pkg/metrics"
+)
+
+const (
+	lookupSourceCache    = "cache"
+	lookupSourceRegistry = "registry"
+	lookupStatusSuccess  = "success"
+	lookupStatusFailed   = "failed"
+)
+
+var (
+	lookupSourceTag = tag.MustNewKey("source")
+	lookupStatusTag = tag.MustNewKey("status")
+
+	imageLookupCount = stats.Int64("entrypoint_image_lookup_count",
+		"number of image metadata lookups to resolve the entrypoint of steps",
+		stats.UnitDimensionless)
+
+	registryLookupDuration = stats.Float64("entrypoint_registry_lookup_duration_seconds",
+		"duration of image metadata lookups in container image registries",
+		stats.UnitSeconds)
+
+	cachePersistFailureCount = stats.Int64("entrypoint_cache_persist_failure_count",
+		"number of image metadata lookups which failed to be stored in the entrypoint cache ConfigMap",
+		stats.UnitDimensionless)
+
+	imageLookupCountView = &view.View{
+		Description: imageLookupCount.Description(),
+		Measure:     imageLookupCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{lookupSourceTag, lookupStatusTag},
+	}
+	registryLookupDurationView = &view.View{
+		Description: registryLookupDuration.Description(),
+		Measure:     registryLookupDuration,
+		Aggregation: view.Distribution(0.1, 0.5, 1, 2.5, 5, 10, 30),
+		TagKeys:     []tag.Key{lookupStatusTag},
+	}
+	cachePersistFailureCountView = &view.View{
+		Description: cachePersistFailureCount.Description(),
+		Measure:     cachePersistFailureCount,
+		Aggregation: view.Count(),
+	}
+
+	registerLookupViewsOnce sync.Once
+	errRegisterLookupViews  error
+)
+
+// registerLookupViews registers the views of the image lookup metrics once.
+func registerLookupViews() error {
+	registerLookupViewsOnce.Do(func() {
+		errRegisterLookupViews = view.Register(imageLookupCountView, registryLookupDurationView, cachePersistFailureCountView)
+	})
+	return errRegisterLookupViews
+}
+
+// recordCacheLookup records an image lookup answered by the cache.
+func recordCacheLookup(ctx context.Context) {
+	ctx, err := tag.New(ctx, tag.Insert(lookupSourceTag, lookupSourceCache), tag.Insert(lookupStatusTag, lookupStatusSuccess))
+	if err != nil {
+		return
+	}
+	metrics.Record(ctx, imageLookupCount.M(1))
+}
+
+// recordRegistryLookup records an image lookup in a registry started at start,
+// which failed if err is not nil.
+func recordRegistryLookup(ctx context.Context, start time.Time, err error) {
+	status := lookupStatusSuccess
+	if err != nil {
+		status = lookupStatusFailed
+	}
+	ctx, tagErr := tag.New(ctx, tag.Insert(lookupSourceTag, lookupSourceRegistry), tag.Insert(lookupStatusTag, status))
+	if tagErr != nil {
+		return
+	}
+	metrics.Record(ctx, imageLookupCount.M(1))
+	metrics.Record(ctx, registryLookupDuration.M(time.Since(start).Seconds()))
+}
+
+// recordCachePersistFailure records a failure to store image metadata in the
+// entrypoint cache ConfigMap.
+func recordCachePersistFailure(ctx context.Context) {
+	metrics.Record(ctx, cachePersistFailureCount.M(1))
+}
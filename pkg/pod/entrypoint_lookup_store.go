@@ -0,0 +1,201 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"knative.dev/pkg/logging"
+)
+
+// maxImageCacheDataSize bounds the size of the data of the cache ConfigMap,
+// below the 1MiB limit of ConfigMaps to leave room for its metadata.
+const maxImageCacheDataSize = 900 * 1024
+
+// configMapImageCache is an imageCache shared across controller replicas and
+// restarts through a ConfigMap. The ConfigMap holds one entry per image, keyed
+// by the image digest with ":" replaced by "." (e.g. "sha256.abc..."), so
+// removing an entry from the ConfigMap invalidates that digest.
+type configMapImageCache struct {
+	kubeclient kubernetes.Interface
+	namespace  string
+	name       string
+	ttl        time.Duration
+	maxEntries int
+	now        func() time.Time
+
+	mu      sync.RWMutex
+	entries map[string]imageCacheEntry
+}
+
+// imageCacheEntry is the data of an image as stored in the ConfigMap.
+type imageCacheEntry struct {
+	Commands map[string][]string `json:"commands"`
+	LookedUp time.Time           `json:"lookedUp"`
+}
+
+func newConfigMapImageCache(kubeclient kubernetes.Interface, namespace string, opts pipeline.EntrypointCacheOptions) *configMapImageCache {
+	return &configMapImageCache{
+		kubeclient: kubeclient,
+		namespace:  namespace,
+		name:       opts.ConfigMapName,
+		ttl:        opts.TTL,
+		maxEntries: opts.MaxEntries,
+		now:        time.Now,
+		entries:    map[string]imageCacheEntry{},
+	}
+}
+
+// imageCacheKey returns the ConfigMap key of the given digest.
+func imageCacheKey(digest string) string {
+	return strings.Replace(digest, ":", ".", 1)
+}
+
+func (c *configMapImageCache) expired(e imageCacheEntry) bool {
+	return c.ttl > 0 && c.now().Sub(e.LookedUp) > c.ttl
+}
+
+func (c *configMapImageCache) get(digest name.Digest) (*imageData, bool) {
+	c.mu.RLock()
+	e, ok := c.entries[imageCacheKey(digest.DigestStr())]
+	c.mu.RUnlock()
+	if !ok || c.expired(e) {
+		return nil, false
+	}
+	h, err := v1.NewHash(digest.DigestStr())
+	if err != nil {
+		return nil, false
+	}
+	return &imageData{digest: h, commands: e.Commands}, true
+}
+
+func (c *configMapImageCache) add(ctx context.Context, digest name.Digest, id *imageData) {
+	key := imageCacheKey(digest.DigestStr())
+	e := imageCacheEntry{Commands: id.commands, LookedUp: c.now()}
+	c.mu.Lock()
+	c.entries[key] = e
+	c.mu.Unlock()
+
+	// Failing to share the entry only means that other replicas will look up
+	// the image again, so it must not fail the TaskRun.
+	if err := c.persist(ctx, key, e); err != nil {
+		recordCachePersistFailure(ctx)
+		logging.FromContext(ctx).Warnf("Failed to store the metadata of image %s in ConfigMap %s/%s: %v", digest, c.namespace, c.name, err)
+	}
+}
+
+// persist stores the entry in the ConfigMap, creating it if needed, and evicts
+// the expired entries as well as the oldest entries above the maximum.
+func (c *configMapImageCache) persist(ctx context.Context, key string, e imageCacheEntry) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	conflict := func(err error) bool {
+		return k8serrors.IsConflict(err) || k8serrors.IsAlreadyExists(err)
+	}
+	return retry.OnError(retry.DefaultRetry, conflict, func() error {
+		cm, err := c.kubeclient.CoreV1().ConfigMaps(c.namespace).Get(ctx, c.name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			_, err = c.kubeclient.CoreV1().ConfigMaps(c.namespace).Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: c.name, Namespace: c.namespace},
+				Data:       map[string]string{key: string(b)},
+			}, metav1.CreateOptions{})
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		cm = cm.DeepCopy()
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[key] = string(b)
+		c.evict(cm.Data)
+		_, err = c.kubeclient.CoreV1().ConfigMaps(c.namespace).Update(ctx, cm, metav1.UpdateOptions{})
+		return err
+	})
+}
+
+// evict removes the expired and invalid entries from data, then the oldest
+// entries until there are at most maxEntries left and the data fits in the
+// ConfigMap.
+func (c *configMapImageCache) evict(data map[string]string) {
+	entries := parseImageCacheEntries(data)
+	size := 0
+	for key, value := range data {
+		if e, ok := entries[key]; !ok || c.expired(e) {
+			delete(data, key)
+			delete(entries, key)
+			continue
+		}
+		size += len(key) + len(value)
+	}
+	tooMany := func() bool { return c.maxEntries > 0 && len(data) > c.maxEntries }
+	if !tooMany() && size <= maxImageCacheDataSize {
+		return
+	}
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return entries[keys[i]].LookedUp.Before(entries[keys[j]].LookedUp)
+	})
+	for _, key := range keys {
+		if !tooMany() && size <= maxImageCacheDataSize {
+			return
+		}
+		size -= len(key) + len(data[key])
+		delete(data, key)
+	}
+}
+
+// observe replaces the entries of the cache with the content of the ConfigMap.
+func (c *configMapImageCache) observe(cm *corev1.ConfigMap) {
+	entries := parseImageCacheEntries(cm.Data)
+	c.mu.Lock()
+	c.entries = entries
+	c.mu.Unlock()
+}
+
+// parseImageCacheEntries parses the entries of the ConfigMap data, ignoring the
+// ones that can't be parsed.
+func parseImageCacheEntries(data map[string]string) map[string]imageCacheEntry {
+	entries := make(map[string]imageCacheEntry, len(data))
+	for key, value := range data {
+		var e imageCacheEntry
+		if err := json.Unmarshal([]byte(value), &e); err != nil {
+			continue
+		}
+		entries[key] = e
+	}
+	return entries
+}
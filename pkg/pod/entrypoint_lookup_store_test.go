@@ -0,0 +1,293 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.opencensus.io/stats/view"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakeclient "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/metrics/metricstest" // Required to setup metrics env for testing
+	_ "knative.dev/pkg/metrics/testing"
+)
+
+const (
+	cacheNamespace     = "tekton-pipelines"
+	cacheConfigMapName = "entrypoint-cache"
+)
+
+func TestPersistentEntrypointCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := httptest.NewServer(registry.New())
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tag, err := name.NewTag(u.Host + "/cached/image:latest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	img := mustRandomImage(t)
+	if err := remote.Write(tag, img); err != nil {
+		t.Fatalf("remote.Write() = %v", err)
+	}
+	imgDigest, err := img.Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	digestRef := tag.Context().Digest(imgDigest.String())
+
+	client := fakeclient.NewSimpleClientset(&corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: nameSpace},
+	})
+	opts := pipeline.EntrypointCacheOptions{ConfigMapName: cacheConfigMapName, TTL: time.Hour, MaxEntries: 10}
+
+	// The first replica looks up the image in the registry and shares it.
+	cache, err := NewPersistentEntrypointCache(client, &configmap.ManualWatcher{Namespace: cacheNamespace}, cacheNamespace, opts)
+	if err != nil {
+		t.Fatalf("NewPersistentEntrypointCache() = %v", err)
+	}
+	want, err := cache.get(ctx, tag, nameSpace, "", nil, false)
+	if err != nil {
+		t.Fatalf("get() = %v", err)
+	}
+	cm, err := client.CoreV1().ConfigMaps(cacheNamespace).Get(ctx, cacheConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected the image metadata to be stored in a ConfigMap: %v", err)
+	}
+	key := imageCacheKey(imgDigest.String())
+	if _, ok := cm.Data[key]; !ok {
+		t.Fatalf("expected ConfigMap entry %q, got %v", key, cm.Data)
+	}
+
+	// Another replica uses the shared metadata without reaching the registry.
+	s.Close()
+	watcher := &configmap.ManualWatcher{Namespace: cacheNamespace}
+	replica, err := NewPersistentEntrypointCache(client, watcher, cacheNamespace, opts)
+	if err != nil {
+		t.Fatalf("NewPersistentEntrypointCache() = %v", err)
+	}
+	watcher.OnChange(cm)
+	got, err := replica.get(ctx, digestRef, nameSpace, "", nil, false)
+	if err != nil {
+		t.Fatalf("get() = %v", err)
+	}
+	if d := cmp.Diff(want.commands, got.commands); d != "" {
+		t.Errorf("commands %s", diff.PrintWantGot(d))
+	}
+	if got.digest != imgDigest {
+		t.Errorf("got digest %s, want %s", got.digest, imgDigest)
+	}
+
+	// Removing the entry from the ConfigMap invalidates it.
+	invalidated := cm.DeepCopy()
+	delete(invalidated.Data, key)
+	watcher.OnChange(invalidated)
+	if _, err := replica.get(ctx, digestRef, nameSpace, "", nil, false); err == nil {
+		t.Error("expected the invalidated image to be looked up in the registry")
+	}
+
+	metricstest.CheckStatsReported(t, "entrypoint_image_lookup_count", "entrypoint_registry_lookup_duration_seconds")
+	rows, err := view.RetrieveData("entrypoint_image_lookup_count")
+	if err != nil {
+		t.Fatalf("view.RetrieveData() = %v", err)
+	}
+	lookups := map[string]int64{}
+	for _, row := range rows {
+		var source, status string
+		for _, tag := range row.Tags {
+			switch tag.Key {
+			case lookupSourceTag:
+				source = tag.Value
+			case lookupStatusTag:
+				status = tag.Value
+			}
+		}
+		lookups[source+"/"+status] = row.Data.(*view.CountData).Value
+	}
+	if lookups["cache/success"] != 1 || lookups["registry/success"] == 0 || lookups["registry/failed"] == 0 {
+		t.Errorf("expected cache hits, registry lookups and failed registry lookups to be recorded, got %v", lookups)
+	}
+}
+
+func TestConfigMapImageCacheTTL(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	c := newConfigMapImageCache(fakeclient.NewSimpleClientset(), cacheNamespace, pipeline.EntrypointCacheOptions{
+		ConfigMapName: cacheConfigMapName,
+		TTL:           time.Hour,
+	})
+	c.now = func() time.Time { return now }
+
+	digest, err := name.NewDigest("example.com/image@sha256:" + sha("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.add(context.Background(), digest, &imageData{commands: map[string][]string{"linux/amd64": {"run"}}})
+	if _, ok := c.get(digest); !ok {
+		t.Error("expected a cache hit")
+	}
+	now = now.Add(2 * time.Hour)
+	if _, ok := c.get(digest); ok {
+		t.Error("expected the expired entry to be a cache miss")
+	}
+}
+
+func TestConfigMapImageCacheEviction(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	entry := func(age time.Duration) string {
+		b, err := json.Marshal(imageCacheEntry{Commands: map[string][]string{"linux/amd64": {"run"}}, LookedUp: now.Add(-age)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	client := fakeclient.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: cacheConfigMapName, Namespace: cacheNamespace},
+		Data: map[string]string{
+			imageCacheKey("sha256:" + sha("expired")): entry(2 * time.Hour),
+			imageCacheKey("sha256:" + sha("old")):     entry(30 * time.Minute),
+			imageCacheKey("sha256:" + sha("recent")):  entry(time.Minute),
+			"invalid":                                 "not json",
+		},
+	})
+	c := newConfigMapImageCache(client, cacheNamespace, pipeline.EntrypointCacheOptions{
+		ConfigMapName: cacheConfigMapName,
+		TTL:           time.Hour,
+		MaxEntries:    2,
+	})
+	c.now = func() time.Time { return now }
+
+	digest, err := name.NewDigest("example.com/image@sha256:" + sha("new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.add(context.Background(), digest, &imageData{commands: map[string][]string{"linux/amd64": {"run"}}})
+
+	cm, err := client.CoreV1().ConfigMaps(cacheNamespace).Get(context.Background(), cacheConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for key := range cm.Data {
+		got = append(got, key)
+	}
+	want := []string{imageCacheKey("sha256:" + sha("new")), imageCacheKey("sha256:" + sha("recent"))}
+	if d := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+		t.Errorf("ConfigMap entries %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestConfigMapImageCacheSizeEviction(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	// Each entry takes a little more than a third of the maximum size of the data.
+	commands := map[string][]string{"linux/amd64": {strings.Repeat("a", maxImageCacheDataSize/3)}}
+	entry := func(age time.Duration) string {
+		b, err := json.Marshal(imageCacheEntry{Commands: commands, LookedUp: now.Add(-age)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+	client := fakeclient.NewSimpleClientset(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: cacheConfigMapName, Namespace: cacheNamespace},
+		Data: map[string]string{
+			imageCacheKey("sha256:" + sha("old")):    entry(30 * time.Minute),
+			imageCacheKey("sha256:" + sha("recent")): entry(time.Minute),
+		},
+	})
+	c := newConfigMapImageCache(client, cacheNamespace, pipeline.EntrypointCacheOptions{
+		ConfigMapName: cacheConfigMapName,
+		TTL:           time.Hour,
+		MaxEntries:    10,
+	})
+	c.now = func() time.Time { return now }
+
+	digest, err := name.NewDigest("example.com/image@sha256:" + sha("new"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.add(context.Background(), digest, &imageData{commands: commands})
+
+	cm, err := client.CoreV1().ConfigMaps(cacheNamespace).Get(context.Background(), cacheConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for key := range cm.Data {
+		got = append(got, key)
+	}
+	want := []string{imageCacheKey("sha256:" + sha("new")), imageCacheKey("sha256:" + sha("recent"))}
+	if d := cmp.Diff(want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); d != "" {
+		t.Errorf("ConfigMap entries %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestConfigMapImageCachePersistFailure(t *testing.T) {
+	if err := registerLookupViews(); err != nil {
+		t.Fatal(err)
+	}
+	client := fakeclient.NewSimpleClientset()
+	client.PrependReactor("create", "configmaps", func(ktesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+	c := newConfigMapImageCache(client, cacheNamespace, pipeline.EntrypointCacheOptions{
+		ConfigMapName: cacheConfigMapName,
+		TTL:           time.Hour,
+	})
+
+	digest, err := name.NewDigest("example.com/image@sha256:" + sha("a"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.add(context.Background(), digest, &imageData{commands: map[string][]string{"linux/amd64": {"run"}}})
+	// The entry is still cached in memory.
+	if _, ok := c.get(digest); !ok {
+		t.Error("expected a cache hit")
+	}
+	metricstest.CheckCountData(t, "entrypoint_cache_persist_failure_count", map[string]string{}, 1)
+}
+
+// sha returns a valid sha256 hex string made of s repeated.
+func sha(s string) string {
+	out := ""
+	for len(out) < 64 {
+		out += fmt.Sprintf("%x", s)
+	}
+	return out[:64]
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	"github.com/tektoncd/pipeline/pkg/names"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/kubernetes"
@@ -195,6 +196,9 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	if err != nil {
 		return nil, err
 	}
+	if alphaAPIEnabled && taskRun.Spec.ComputeResources != nil {
+		setTaskLevelComputeResources(stepContainers, taskRun.Spec.ComputeResources)
+	}
 	volumes = append(volumes, binVolume)
 	if !readyImmediately {
 		volumes = append(volumes, downwardVolume)
@@ -351,6 +355,10 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 		},
 	}
 
+	if stepLogs := config.FromContextOrDefaults(ctx).StepLogs; stepLogs.Enabled() {
+		uploadStepLogs(stepLogs, newPod)
+	}
+
 	for _, f := range transformers {
 		newPod, err = f(newPod)
 		if err != nil {
@@ -361,6 +369,61 @@ func (b *Builder) Build(ctx context.Context, taskRun *v1beta1.TaskRun, taskSpec
 	return newPod, nil
 }
 
+// setTaskLevelComputeResources distributes the task-level compute resources over the step
+// containers so that the sum of their requests equals the task-level requests.
+// For each resource of the task-level requirements:
+// - The first step is assigned the whole request. A resource with a limit but no request
+// is requested up to its limit, as Kubernetes does for a single container.
+// - The other steps are assigned an explicit zero request, which prevents Kubernetes from
+// defaulting their requests to their limits.
+// - Every step is assigned the task-level limit, if any, since Steps run sequentially.
+// The task-level requirements replace the step-level requirements of the same resource.
+// Resources missing from the task-level requirements keep their step-level values.
+func setTaskLevelComputeResources(stepContainers []corev1.Container, computeResources *corev1.ResourceRequirements) {
+	requests := TaskLevelRequests(computeResources)
+	for i := range stepContainers {
+		resources := &stepContainers[i].Resources
+		// The resource lists may be shared with the TaskSpec.
+		resources.Requests = resources.Requests.DeepCopy()
+		resources.Limits = resources.Limits.DeepCopy()
+		if len(requests) > 0 && resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		for name, q := range requests {
+			// The task-level requirements replace the step-level ones.
+			delete(resources.Limits, name)
+			if i == 0 {
+				resources.Requests[name] = q.DeepCopy()
+			} else {
+				resources.Requests[name] = resource.Quantity{Format: q.Format}
+			}
+		}
+		if len(computeResources.Limits) > 0 && resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		for name, q := range computeResources.Limits {
+			resources.Limits[name] = q.DeepCopy()
+		}
+	}
+}
+
+// TaskLevelRequests returns the requests the step containers of a TaskRun
+// share when it specifies task-level compute resources: the task-level requests,
+// plus the task-level limit of any resource without a request.
+func TaskLevelRequests(computeResources *corev1.ResourceRequirements) corev1.ResourceList {
+	if computeResources == nil {
+		return nil
+	}
+	requests := corev1.ResourceList{}
+	for name, q := range computeResources.Limits {
+		requests[name] = q.DeepCopy()
+	}
+	for name, q := range computeResources.Requests {
+		requests[name] = q.DeepCopy()
+	}
+	return requests
+}
+
 // makeLabels constructs the labels we will propagate from TaskRuns to Pods.
 func makeLabels(s *v1beta1.TaskRun) map[string]string {
 	labels := make(map[string]string, len(s.ObjectMeta.Labels)+1)
@@ -2006,6 +2006,100 @@ debug-fail-continue-heredoc-randomly-generated-mz4c7
 	}
 }
 
+func TestPodBuildWithTaskLevelComputeResources(t *testing.T) {
+	taskSpec := v1beta1.TaskSpec{
+		Steps: []v1beta1.Step{{
+			Name:    "first",
+			Image:   "image",
+			Command: []string{"cmd"},
+		}, {
+			Name:    "second",
+			Image:   "image",
+			Command: []string{"cmd"},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+			},
+		}},
+		Sidecars: []v1beta1.Sidecar{{
+			Name:  "sidecar",
+			Image: "image",
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+			},
+		}},
+	}
+	computeResources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}
+
+	for _, tc := range []struct {
+		desc            string
+		enableAPIFields string
+		want            []corev1.ResourceRequirements
+	}{{
+		desc:            "alpha",
+		enableAPIFields: "alpha",
+		want: []corev1.ResourceRequirements{{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}, {
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("0"), corev1.ResourceMemory: resource.MustParse("0")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("3"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}, {
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+		}},
+	}, {
+		desc:            "stable",
+		enableAPIFields: "stable",
+		want: []corev1.ResourceRequirements{{}, {
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+			Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		}, {
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+		}},
+	}} {
+		t.Run(tc.desc, func(t *testing.T) {
+			names.TestingSeed()
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+					Data:       map[string]string{"enable-api-fields": tc.enableAPIFields},
+				},
+			)
+			kubeclient := fakek8s.NewSimpleClientset(
+				&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "default", Namespace: "default"}},
+			)
+			tr := &v1beta1.TaskRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-name", Namespace: "default"},
+				Spec:       v1beta1.TaskRunSpec{ComputeResources: computeResources},
+			}
+			builder := Builder{
+				Images:          images,
+				KubeClient:      kubeclient,
+				EntrypointCache: fakeCache{},
+			}
+
+			got, err := builder.Build(store.ToContext(context.Background()), tr, taskSpec)
+			if err != nil {
+				t.Fatalf("builder.Build: %v", err)
+			}
+			var gotResources []corev1.ResourceRequirements
+			for _, c := range got.Spec.Containers {
+				gotResources = append(gotResources, c.Resources)
+			}
+			if d := cmp.Diff(tc.want, gotResources, resourceQuantityCmp, cmpopts.EquateEmpty()); d != "" {
+				t.Errorf("Diff %s", diff.PrintWantGot(d))
+			}
+			if q := taskSpec.Steps[1].Resources.Limits[corev1.ResourceCPU]; q.Cmp(resource.MustParse("4")) != 0 {
+				t.Errorf("the Task steps must not be modified, got CPU limit %s", q.String())
+			}
+		})
+	}
+}
+
 func TestMakeLabels(t *testing.T) {
 	taskRunName := "task-run-name"
 	want := map[string]string{
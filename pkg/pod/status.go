@@ -25,6 +25,7 @@ import (
 
 	"github.com/hashicorp/go-multierror"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/entrypoint"
 	"github.com/tektoncd/pipeline/pkg/termination"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
@@ -49,6 +50,10 @@ const (
 	// a ResourceQuota in the namespace
 	ReasonExceededResourceQuota = "ExceededResourceQuota"
 
+	// ReasonExceededLimitRange indicates that the TaskRun failed to create a pod because
+	// its task-level compute resources do not fit within a LimitRange in the namespace
+	ReasonExceededLimitRange = "ExceededLimitRange"
+
 	// ReasonExceededNodeResources indicates that the TaskRun's pod has failed to start due
 	// to resource constraints on the node
 	ReasonExceededNodeResources = "ExceededNodeResources"
@@ -132,7 +137,7 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 	}
 
 	var merr *multierror.Error
-	if err := setTaskRunStatusBasedOnStepStatus(logger, stepStatuses, &tr); err != nil {
+	if err := setTaskRunStatusBasedOnStepStatus(logger, stepStatuses, containerResources(pod), &tr); err != nil {
 		merr = multierror.Append(merr, err)
 	}
 
@@ -140,14 +145,19 @@ func MakeTaskRunStatus(logger *zap.SugaredLogger, tr v1beta1.TaskRun, pod *corev
 
 	trs.TaskRunResults = removeDuplicateResults(trs.TaskRunResults)
 
+	if complete {
+		trs.ResourceUsage = MakeResourceUsage(trs, pod)
+	}
+
 	return *trs, merr.ErrorOrNil()
 }
 
-func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, tr *v1beta1.TaskRun) *multierror.Error {
+func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses []corev1.ContainerStatus, resources map[string]corev1.ResourceRequirements, tr *v1beta1.TaskRun) *multierror.Error {
 	trs := &tr.Status
 	var merr *multierror.Error
 
 	for _, s := range stepStatuses {
+		var logLocation string
 		if s.State.Terminated != nil && len(s.State.Terminated.Message) != 0 {
 			msg := s.State.Terminated.Message
 
@@ -166,6 +176,7 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 					logger.Errorf("error extracting the exit code of step %q in taskrun %q: %v", s.Name, tr.Name, err)
 					merr = multierror.Append(merr, err)
 				}
+				logLocation = extractLogLocationFromResults(results)
 				taskResults, pipelineResourceResults, filteredResults := filterResultsAndResources(results)
 				if tr.IsSuccessful() {
 					trs.TaskRunResults = append(trs.TaskRunResults, taskResults...)
@@ -186,12 +197,17 @@ func setTaskRunStatusBasedOnStepStatus(logger *zap.SugaredLogger, stepStatuses [
 				}
 			}
 		}
-		trs.Steps = append(trs.Steps, v1beta1.StepState{
+		stepState := v1beta1.StepState{
 			ContainerState: *s.State.DeepCopy(),
 			Name:           trimStepPrefix(s.Name),
 			ContainerName:  s.Name,
 			ImageID:        s.ImageID,
-		})
+			LogLocation:    logLocation,
+		}
+		if r, ok := resources[s.Name]; ok && (len(r.Requests) > 0 || len(r.Limits) > 0) {
+			stepState.Resources = r.DeepCopy()
+		}
+		trs.Steps = append(trs.Steps, stepState)
 	}
 
 	return merr
@@ -304,6 +320,15 @@ func extractExitCodeFromResults(results []v1beta1.PipelineResourceResult) (*int3
 	return nil, nil
 }
 
+func extractLogLocationFromResults(results []v1beta1.PipelineResourceResult) string {
+	for _, result := range results {
+		if result.Key == entrypoint.LogLocationKey && result.ResultType == v1beta1.InternalTektonResultType {
+			return result.Value
+		}
+	}
+	return ""
+}
+
 func updateCompletedTaskRunStatus(logger *zap.SugaredLogger, trs *v1beta1.TaskRunStatus, pod *corev1.Pod) {
 	if DidTaskRunFail(pod) {
 		msg := getFailureMessage(logger, pod)
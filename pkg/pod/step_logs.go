@@ -0,0 +1,112 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"net/url"
+	"path"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/internal/objectstore"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// StepLogsAccessKeyIDEnvVar is the env var holding the access key ID used by the
+	// entrypoint to upload the logs of the step to an S3-compatible storage.
+	StepLogsAccessKeyIDEnvVar = "TEKTON_STEP_LOGS_ACCESS_KEY_ID"
+	// StepLogsSecretAccessKeyEnvVar is the env var holding the secret access key used by
+	// the entrypoint to upload the logs of the step to an S3-compatible storage.
+	StepLogsSecretAccessKeyEnvVar = "TEKTON_STEP_LOGS_SECRET_ACCESS_KEY"
+	// StepLogsSessionTokenEnvVar is the env var holding the session token used by the
+	// entrypoint to upload the logs of the step to an S3-compatible storage.
+	StepLogsSessionTokenEnvVar = "TEKTON_STEP_LOGS_SESSION_TOKEN"
+	// StepLogsTokenEnvVar is the env var holding the bearer token used by the entrypoint
+	// to upload the logs of the step to an HTTP endpoint.
+	StepLogsTokenEnvVar = "TEKTON_STEP_LOGS_TOKEN"
+
+	// StepLogsTokenKey is the key of the bearer token in the secret holding the
+	// credentials used to upload the logs of the steps to an HTTP endpoint.
+	StepLogsTokenKey = "token"
+)
+
+// uploadStepLogs makes the entrypoint of the steps of the pod upload their logs to
+// the location configured in config-step-logs, under <namespace>/<pod>/<step>.log.
+// The credentials are read from the secret configured in config-step-logs, in the
+// namespace of the pod, when it exists.
+func uploadStepLogs(cfg *config.StepLogs, pod *corev1.Pod) {
+	location, err := url.Parse(cfg.Location)
+	if err != nil {
+		// The location is validated when config-step-logs is loaded.
+		return
+	}
+	for i, c := range pod.Spec.Containers {
+		if !IsContainerStep(c.Name) {
+			continue
+		}
+		u := *location
+		u.Path = path.Join("/", u.Path, pod.Namespace, pod.Name, trimStepPrefix(c.Name)+".log")
+		args := []string{"-step_logs_url", u.String()}
+		if location.Scheme == "s3" {
+			args = append(args, "-step_logs_region", cfg.Region)
+			if cfg.Endpoint != "" {
+				args = append(args, "-step_logs_endpoint", cfg.Endpoint)
+			}
+		}
+		pod.Spec.Containers[i].Args = insertEntrypointArgs(c.Args, args...)
+		if cfg.SecretName != "" {
+			pod.Spec.Containers[i].Env = append(pod.Spec.Containers[i].Env, stepLogsCredentialsEnv(cfg.SecretName, location.Scheme)...)
+		}
+	}
+}
+
+// insertEntrypointArgs adds the arguments for the entrypoint before the "--"
+// separating them from the arguments of the step command.
+func insertEntrypointArgs(args []string, entrypointArgs ...string) []string {
+	for i, a := range args {
+		if a == "--" {
+			return append(append(append([]string{}, args[:i]...), entrypointArgs...), args[i:]...)
+		}
+	}
+	return append(args, entrypointArgs...)
+}
+
+func stepLogsCredentialsEnv(secretName, scheme string) []corev1.EnvVar {
+	keys := [][2]string{{StepLogsTokenEnvVar, StepLogsTokenKey}}
+	if scheme == "s3" {
+		keys = [][2]string{
+			{StepLogsAccessKeyIDEnvVar, objectstore.AccessKeyIDKey},
+			{StepLogsSecretAccessKeyEnvVar, objectstore.SecretAccessKeyKey},
+			{StepLogsSessionTokenEnvVar, objectstore.SessionTokenKey},
+		}
+	}
+	optional := true
+	var env []corev1.EnvVar
+	for _, k := range keys {
+		env = append(env, corev1.EnvVar{
+			Name: k[0],
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+					Key:                  k[1],
+					Optional:             &optional,
+				},
+			},
+		})
+	}
+	return env
+}
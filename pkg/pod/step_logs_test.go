@@ -0,0 +1,113 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestUploadStepLogs(t *testing.T) {
+	optional := true
+	secretEnv := func(name, key string) corev1.EnvVar {
+		return corev1.EnvVar{
+			Name: name,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "step-logs-credentials"},
+					Key:                  key,
+					Optional:             &optional,
+				},
+			},
+		}
+	}
+
+	for _, c := range []struct {
+		desc     string
+		cfg      *config.StepLogs
+		wantArgs []string
+		wantEnv  []corev1.EnvVar
+	}{{
+		desc: "s3 without credentials",
+		cfg:  &config.StepLogs{Location: "s3://tekton-logs/steps", Region: "us-east-1"},
+		wantArgs: []string{"-post_file", "/tekton/run/0/out",
+			"-step_logs_url", "s3://tekton-logs/steps/foo/taskrun-pod/one.log",
+			"-step_logs_region", "us-east-1",
+			"--", "echo", "hello"},
+	}, {
+		desc: "s3 with endpoint and credentials",
+		cfg: &config.StepLogs{
+			Location:   "s3://tekton-logs",
+			Endpoint:   "http://minio.minio.svc:9000",
+			Region:     "eu-west-1",
+			SecretName: "step-logs-credentials",
+		},
+		wantArgs: []string{"-post_file", "/tekton/run/0/out",
+			"-step_logs_url", "s3://tekton-logs/foo/taskrun-pod/one.log",
+			"-step_logs_region", "eu-west-1",
+			"-step_logs_endpoint", "http://minio.minio.svc:9000",
+			"--", "echo", "hello"},
+		wantEnv: []corev1.EnvVar{
+			secretEnv(StepLogsAccessKeyIDEnvVar, "access-key-id"),
+			secretEnv(StepLogsSecretAccessKeyEnvVar, "secret-access-key"),
+			secretEnv(StepLogsSessionTokenEnvVar, "session-token"),
+		},
+	}, {
+		desc: "https with credentials",
+		cfg: &config.StepLogs{
+			Location:   "https://logs.example.com/upload",
+			Region:     "us-east-1",
+			SecretName: "step-logs-credentials",
+		},
+		wantArgs: []string{"-post_file", "/tekton/run/0/out",
+			"-step_logs_url", "https://logs.example.com/upload/foo/taskrun-pod/one.log",
+			"--", "echo", "hello"},
+		wantEnv: []corev1.EnvVar{
+			secretEnv(StepLogsTokenEnvVar, "token"),
+		},
+	}} {
+		t.Run(c.desc, func(t *testing.T) {
+			sidecar := corev1.Container{Name: "sidecar-proxy", Args: []string{"--", "proxy"}}
+			pod := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "taskrun-pod", Namespace: "foo"},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{
+						Name: "step-one",
+						Args: []string{"-post_file", "/tekton/run/0/out", "--", "echo", "hello"},
+					}, sidecar},
+				},
+			}
+
+			uploadStepLogs(c.cfg, pod)
+
+			if d := cmp.Diff(c.wantArgs, pod.Spec.Containers[0].Args); d != "" {
+				t.Errorf("Step args %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(c.wantEnv, pod.Spec.Containers[0].Env); d != "" {
+				t.Errorf("Step env %s", diff.PrintWantGot(d))
+			}
+			if d := cmp.Diff(sidecar, pod.Spec.Containers[1]); d != "" {
+				t.Errorf("Sidecar should not be modified %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
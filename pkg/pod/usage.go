@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"math"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// containerResources returns the resource requirements of the Pod's containers,
+// keyed by container name. The Pod spec holds the requirements after overrides
+// and LimitRange defaults have been applied by the Pod builder.
+func containerResources(pod *corev1.Pod) map[string]corev1.ResourceRequirements {
+	resources := make(map[string]corev1.ResourceRequirements, len(pod.Spec.Containers))
+	for _, c := range pod.Spec.Containers {
+		resources[c.Name] = c.Resources
+	}
+	return resources
+}
+
+// effectivePodRequests returns the resources reserved by the scheduler for the
+// Pod: the larger of the sum of the container requests and of each init container
+// request, plus the Pod overhead.
+func effectivePodRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, c := range pod.Spec.Containers {
+		for name, q := range c.Resources.Requests {
+			sum := requests[name]
+			sum.Add(q)
+			requests[name] = sum
+		}
+	}
+	for _, c := range pod.Spec.InitContainers {
+		for name, q := range c.Resources.Requests {
+			if current, ok := requests[name]; !ok || q.Cmp(current) > 0 {
+				requests[name] = q.DeepCopy()
+			}
+		}
+	}
+	for name, q := range pod.Spec.Overhead {
+		sum := requests[name]
+		sum.Add(q)
+		requests[name] = sum
+	}
+	return requests
+}
+
+// MakeResourceUsage computes the resources reserved by the Pod of a completed
+// TaskRun, including a TaskRun which timed out or was cancelled while its Pod ran.
+// The resources are reserved from the Pod start, or the TaskRun start if the Pod
+// never started, until the TaskRun completion. It returns nil if the Pod does not
+// request any CPU or memory.
+func MakeResourceUsage(trs *v1beta1.TaskRunStatus, pod *corev1.Pod) *v1beta1.ResourceUsage {
+	if trs.CompletionTime == nil {
+		return nil
+	}
+	requests := effectivePodRequests(pod)
+	cpu, hasCPU := requests[corev1.ResourceCPU]
+	memory, hasMemory := requests[corev1.ResourceMemory]
+	if !hasCPU && !hasMemory {
+		return nil
+	}
+
+	start := pod.Status.StartTime
+	if start == nil {
+		start = trs.StartTime
+	}
+	if start == nil {
+		return nil
+	}
+	duration := trs.CompletionTime.Sub(start.Time)
+	if duration < 0 {
+		duration = 0
+	}
+
+	seconds := duration.Seconds()
+	return &v1beta1.ResourceUsage{
+		Requests:        requests,
+		Duration:        &metav1.Duration{Duration: duration},
+		CPUSeconds:      milliQuantity(float64(cpu.MilliValue()) / 1000 * seconds),
+		MemoryGBSeconds: milliQuantity(float64(memory.Value()) / 1e9 * seconds),
+	}
+}
+
+// milliQuantity returns v as a decimal Quantity rounded to the nearest thousandth.
+func milliQuantity(v float64) *resource.Quantity {
+	return resource.NewMilliQuantity(int64(math.Round(v*1000)), resource.DecimalSI)
+}
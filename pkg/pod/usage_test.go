@@ -0,0 +1,213 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestEffectivePodRequests(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		spec corev1.PodSpec
+		want corev1.ResourceList
+	}{{
+		name: "no requests",
+		spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-a"}}},
+		want: corev1.ResourceList{},
+	}, {
+		name: "containers are summed",
+		spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:      "step-a",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("1Gi")}},
+		}, {
+			Name:      "step-b",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+		}}},
+		want: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1500m"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}, {
+		name: "largest init container wins",
+		spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{{
+				Name:      "prepare",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("10Mi")}},
+			}},
+			Containers: []corev1.Container{{
+				Name:      "step-a",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1"), corev1.ResourceMemory: resource.MustParse("1Gi")}},
+			}},
+		},
+		want: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("1Gi")},
+	}, {
+		name: "overhead is added",
+		spec: corev1.PodSpec{
+			Containers: []corev1.Container{{
+				Name:      "step-a",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1")}},
+			}},
+			Overhead: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+		},
+		want: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1250m")},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := effectivePodRequests(&corev1.Pod{Spec: tc.spec})
+			if len(got) != len(tc.want) {
+				t.Fatalf("got requests %v, want %v", got, tc.want)
+			}
+			for name, want := range tc.want {
+				if q := got[name]; q.Cmp(want) != 0 {
+					t.Errorf("got %s request %s, want %s", name, q.String(), want.String())
+				}
+			}
+		})
+	}
+}
+
+func TestMakeTaskRunStatusResourceUsage(t *testing.T) {
+	podStart := time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC)
+	stepResources := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("2G")},
+		Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4G")},
+	}
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "foo"},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{
+			Name:      "step-build",
+			Resources: stepResources,
+		}, {
+			Name:      "step-push",
+			Resources: stepResources,
+		}}},
+		Status: corev1.PodStatus{
+			Phase:     corev1.PodSucceeded,
+			StartTime: &metav1.Time{Time: podStart},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name:  "step-build",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}, {
+				Name:  "step-push",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}},
+			}},
+		},
+	}
+	tr := v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "task-run", Namespace: "foo"},
+		Status: v1beta1.TaskRunStatus{
+			Status: duckv1beta1.Status{Conditions: []apis.Condition{{Type: apis.ConditionSucceeded, Status: corev1.ConditionUnknown}}},
+			TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				StartTime: &metav1.Time{Time: podStart.Add(-time.Minute)},
+			},
+		},
+	}
+
+	got, err := MakeTaskRunStatus(logtesting.TestLogger(t), tr, pod)
+	if err != nil {
+		t.Fatalf("MakeTaskRunStatus() = %v", err)
+	}
+
+	for _, step := range got.Steps {
+		if d := cmp.Diff(&stepResources, step.Resources); d != "" {
+			t.Errorf("step %s resources %s", step.Name, diff.PrintWantGot(d))
+		}
+	}
+
+	usage := got.ResourceUsage
+	if usage == nil {
+		t.Fatal("expected the resource usage of a completed TaskRun to be set")
+	}
+	wantDuration := got.CompletionTime.Sub(podStart)
+	if usage.Duration == nil || usage.Duration.Duration != wantDuration {
+		t.Errorf("got duration %v, want %v", usage.Duration, wantDuration)
+	}
+	// 1 CPU and 4 GB are requested in total.
+	wantCPUSeconds := milliQuantity(wantDuration.Seconds())
+	if usage.CPUSeconds == nil || usage.CPUSeconds.Cmp(*wantCPUSeconds) != 0 {
+		t.Errorf("got %v CPU-seconds, want %s", usage.CPUSeconds, wantCPUSeconds)
+	}
+	wantMemoryGBSeconds := milliQuantity(4 * wantDuration.Seconds())
+	if usage.MemoryGBSeconds == nil || usage.MemoryGBSeconds.Cmp(*wantMemoryGBSeconds) != 0 {
+		t.Errorf("got %v memory-GB-seconds, want %s", usage.MemoryGBSeconds, wantMemoryGBSeconds)
+	}
+}
+
+func TestMakeResourceUsage(t *testing.T) {
+	start := metav1.NewTime(time.Date(2022, time.June, 1, 10, 0, 0, 0, time.UTC))
+	completion := metav1.NewTime(start.Add(90 * time.Second))
+	requests := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("500M")}
+	podWithRequests := func(startTime *metav1.Time) *corev1.Pod {
+		return &corev1.Pod{
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name:      "step-a",
+				Resources: corev1.ResourceRequirements{Requests: requests},
+			}}},
+			Status: corev1.PodStatus{StartTime: startTime},
+		}
+	}
+
+	for _, tc := range []struct {
+		name string
+		trs  *v1beta1.TaskRunStatus
+		pod  *corev1.Pod
+		want *v1beta1.ResourceUsage
+	}{{
+		name: "not completed",
+		trs:  &v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{StartTime: &start}},
+		pod:  podWithRequests(&start),
+	}, {
+		name: "no requests",
+		trs:  &v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{StartTime: &start, CompletionTime: &completion}},
+		pod:  &corev1.Pod{Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "step-a"}}}},
+	}, {
+		name: "pod start time",
+		trs:  &v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{StartTime: &metav1.Time{Time: start.Add(-time.Hour)}, CompletionTime: &completion}},
+		pod:  podWithRequests(&start),
+		want: &v1beta1.ResourceUsage{
+			Requests:        requests,
+			Duration:        &metav1.Duration{Duration: 90 * time.Second},
+			CPUSeconds:      resource.NewMilliQuantity(180000, resource.DecimalSI),
+			MemoryGBSeconds: resource.NewMilliQuantity(45000, resource.DecimalSI),
+		},
+	}, {
+		name: "pod never started",
+		trs:  &v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{StartTime: &start, CompletionTime: &completion}},
+		pod:  podWithRequests(nil),
+		want: &v1beta1.ResourceUsage{
+			Requests:        requests,
+			Duration:        &metav1.Duration{Duration: 90 * time.Second},
+			CPUSeconds:      resource.NewMilliQuantity(180000, resource.DecimalSI),
+			MemoryGBSeconds: resource.NewMilliQuantity(45000, resource.DecimalSI),
+		},
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := MakeResourceUsage(tc.trs, tc.pod)
+			if d := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b resource.Quantity) bool { return a.Cmp(b) == 0 })); d != "" {
+				t.Errorf("MakeResourceUsage() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
@@ -87,6 +87,22 @@ func (h *Handler) Download(ctx context.Context) (*Resource, error) {
 		Statuses: status,
 		Comments: comments,
 	}
+
+	// Review comments on lines of files and check runs are only supported on GitHub.
+	// Failing to list them doesn't fail the download, since the credentials may not
+	// be allowed to read them, e.g. check runs without a GitHub App.
+	if h.client.Driver == scm.DriverGithub {
+		h.logger.Info("finding review comments")
+		if r.ReviewComments, err = h.listReviewComments(ctx); err != nil {
+			h.logger.Warnf("finding review comments for pr %d: %v", h.prNum, err)
+		}
+
+		h.logger.Info("finding check runs")
+		if r.CheckRuns, err = h.listCheckRuns(ctx, pr.Sha); err != nil {
+			h.logger.Warnf("finding check runs for pr %d: %v", h.prNum, err)
+		}
+	}
+
 	populateManifest(r)
 	return r, nil
 }
@@ -102,9 +118,21 @@ func populateManifest(r *Resource) {
 		comments[strconv.Itoa(c.ID)] = true
 	}
 
+	reviewComments := make(Manifest)
+	for _, c := range r.ReviewComments {
+		reviewComments[strconv.Itoa(c.ID)] = true
+	}
+
+	checkRuns := make(Manifest)
+	for _, c := range r.CheckRuns {
+		checkRuns[strconv.FormatInt(c.ID, 10)] = true
+	}
+
 	r.Manifests = map[string]Manifest{
-		"labels":   labels,
-		"comments": comments,
+		"labels":          labels,
+		"comments":        comments,
+		"review-comments": reviewComments,
+		"checks":          checkRuns,
 	}
 }
 
@@ -127,6 +155,14 @@ func (h *Handler) Upload(ctx context.Context, r *Resource) error {
 		merr = multierror.Append(merr, err)
 	}
 
+	if err := h.uploadReviewComments(ctx, r.Manifests["review-comments"], r.ReviewComments, r.PR.Sha); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+
+	if err := h.uploadCheckRuns(ctx, r.Manifests["checks"], r.CheckRuns, r.PR.Sha); err != nil {
+		merr = multierror.Append(merr, err)
+	}
+
 	return merr
 }
 
@@ -317,3 +353,175 @@ func (h *Handler) uploadStatuses(ctx context.Context, statuses []*scm.Status, sh
 
 	return merr
 }
+
+// uploadReviewComments creates the new review comments, and deletes the review
+// comments that were removed from the resource, as for comments. New review
+// comments identical to an existing one are not created again, so that uploading
+// the same resource twice doesn't duplicate them.
+func (h *Handler) uploadReviewComments(ctx context.Context, manifest Manifest, comments []*scm.ReviewComment, sha string) error {
+	if len(comments) == 0 && len(manifest) == 0 {
+		h.logger.Info("Skipping review comments, nothing to set.")
+		return nil
+	}
+	if h.client.Driver != scm.DriverGithub {
+		return fmt.Errorf("review comments are not supported for %s", h.client.Driver)
+	}
+	if err := validateReviewComments(comments); err != nil {
+		return err
+	}
+
+	current, err := h.listReviewComments(ctx)
+	if err != nil {
+		return fmt.Errorf("listing review comments for pr %d: %w", h.prNum, err)
+	}
+
+	existing := map[int]bool{}
+	for _, c := range comments {
+		if c.ID != 0 {
+			existing[c.ID] = true
+		}
+	}
+
+	var merr error
+	for _, c := range current {
+		// Only delete the review comments that existed when the resource was
+		// initialized, the others were created since.
+		if existing[c.ID] || !manifest[strconv.Itoa(c.ID)] {
+			continue
+		}
+		h.logger.Infof("Deleting review comment %d for PR %d", c.ID, h.prNum)
+		if err := h.deleteReviewComment(ctx, c.ID); err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("deleting review comment %d: %w", c.ID, err))
+		}
+	}
+
+	for _, c := range comments {
+		if c.ID != 0 || hasReviewComment(current, c) {
+			continue
+		}
+		h.logger.Infof("Creating review comment on %s:%d for PR %d", c.Path, c.Line, h.prNum)
+		if err := h.createReviewComment(ctx, c, sha); err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("creating review comment on %s:%d: %w", c.Path, c.Line, err))
+		}
+	}
+	return merr
+}
+
+func validateReviewComments(comments []*scm.ReviewComment) error {
+	var merr error
+	for _, c := range comments {
+		if c.ID != 0 {
+			continue
+		}
+		if c.Path == "" || c.Line <= 0 {
+			merr = multierror.Append(merr, fmt.Errorf("invalid review comment: \"Path\" and \"Line\" should be set: %v", *c))
+		}
+	}
+	return merr
+}
+
+func hasReviewComment(comments []*scm.ReviewComment, c *scm.ReviewComment) bool {
+	for _, ec := range comments {
+		if ec.Path == c.Path && ec.Line == c.Line && ec.Body == c.Body {
+			return true
+		}
+	}
+	return false
+}
+
+// uploadCheckRuns creates the check runs added or modified by the Task. A check
+// run that existed when the resource was initialized, e.g. of another GitHub App,
+// is only created again if it differs from the check run it was downloaded from.
+// A new check run is only created if it differs from the latest check run with
+// the same name on the head commit of the PR. Annotations can't be removed from
+// a check run, so a changed check run is replaced by a new one rather than
+// updated.
+func (h *Handler) uploadCheckRuns(ctx context.Context, manifest Manifest, checkRuns []*CheckRun, sha string) error {
+	if len(checkRuns) == 0 {
+		h.logger.Info("Skipping check runs, nothing to set.")
+		return nil
+	}
+	if h.client.Driver != scm.DriverGithub {
+		return fmt.Errorf("check runs are not supported for %s", h.client.Driver)
+	}
+	if err := validateCheckRuns(checkRuns); err != nil {
+		return err
+	}
+
+	h.logger.Infof("Looking for existing check runs on %s", sha)
+	current, err := h.listCheckRuns(ctx, sha)
+	if err != nil {
+		return fmt.Errorf("listing check runs on %s: %w", sha, err)
+	}
+	byName := map[string]*CheckRun{}
+	byID := map[int64]*CheckRun{}
+	for _, c := range current {
+		byName[c.Name] = c
+		byID[c.ID] = c
+	}
+
+	var merr error
+	for _, c := range checkRuns {
+		ec, ok := byName[c.Name]
+		if c.ID != 0 && manifest[strconv.FormatInt(c.ID, 10)] {
+			// The check run was downloaded, compare it with its original, which
+			// may no longer be the latest one with its name.
+			if ec, ok = byID[c.ID]; !ok {
+				if ec, err = h.getCheckRun(ctx, c.ID); err != nil {
+					merr = multierror.Append(merr, fmt.Errorf("getting check run %d: %w", c.ID, err))
+					continue
+				}
+				ok = true
+			}
+		}
+		if ok && equalCheckRuns(ec, c) {
+			h.logger.Infof("Skipping check run %s because it already matches", c.Name)
+			continue
+		}
+		h.logger.Infof("Creating check run %s on %s", c.Name, sha)
+		if err := h.createCheckRun(ctx, c, sha); err != nil {
+			merr = multierror.Append(merr, fmt.Errorf("creating check run %q: %w", c.Name, err))
+		}
+	}
+	return merr
+}
+
+func validateCheckRuns(checkRuns []*CheckRun) error {
+	var merr error
+	for _, c := range checkRuns {
+		if c.Name == "" {
+			merr = multierror.Append(merr, fmt.Errorf("invalid check run: \"Name\" should not be empty: %v", *c))
+		}
+		if (c.Text != "" || len(c.Annotations) > 0) && (c.Title == "" || c.Summary == "") {
+			merr = multierror.Append(merr, fmt.Errorf("invalid check run: \"Title\" and \"Summary\" should be set with \"Text\" or \"Annotations\": %v", *c))
+		}
+	}
+	return merr
+}
+
+// equalCheckRuns returns true if the check runs have the same content, regardless
+// of their IDs.
+func equalCheckRuns(a, b *CheckRun) bool {
+	if a.Name != b.Name || checkRunStatus(a) != checkRunStatus(b) || a.Conclusion != b.Conclusion || a.DetailsURL != b.DetailsURL ||
+		a.Title != b.Title || a.Summary != b.Summary || a.Text != b.Text || len(a.Annotations) != len(b.Annotations) {
+		return false
+	}
+	for i := range a.Annotations {
+		if *a.Annotations[i] != *b.Annotations[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkRunStatus returns the status of the check run, defaulted the way GitHub does.
+func checkRunStatus(c *CheckRun) string {
+	switch {
+	case c.Status != "":
+		return c.Status
+	case c.Conclusion != "":
+		return "completed"
+	default:
+		return "queued"
+	}
+}
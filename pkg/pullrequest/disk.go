@@ -41,10 +41,14 @@ import (
 // /workspace/<resource>/status/<status>.json
 // /workspace/<resource>/comments/
 // /workspace/<resource>/comments/<comment>.json
+// /workspace/<resource>/review-comments/
+// /workspace/<resource>/review-comments/<comment>.json
+// /workspace/<resource>/checks/
+// /workspace/<resource>/checks/<check>.json
 // /workspace/<resource>/head.json
 // /workspace/<resource>/base.json
 
-// Filenames for labels and statuses are URL encoded for safety.
+// Filenames for labels, statuses and checks are URL encoded for safety.
 
 const (
 	manifestPath = ".MANIFEST"
@@ -55,20 +59,55 @@ type Resource struct {
 	PR       *scm.PullRequest
 	Statuses []*scm.Status
 	Comments []*scm.Comment
+	// ReviewComments are the comments on lines of the files changed by the PR.
+	ReviewComments []*scm.ReviewComment
+	// CheckRuns are the latest check runs of the head commit of the PR.
+	CheckRuns []*CheckRun
 
 	// Manifests contain data about the resource when it was written to disk.
 	Manifests map[string]Manifest
 }
 
+// CheckRun is a check run on the head commit of the PR, such as the report of a
+// linter. Check runs are identified by their name.
+type CheckRun struct {
+	ID   int64
+	Name string
+	// Status is one of queued, in_progress or completed.
+	Status string
+	// Conclusion is required when the check run is completed, one of
+	// action_required, cancelled, failure, neutral, success, skipped or timed_out.
+	Conclusion string
+	DetailsURL string
+	Title      string
+	Summary    string
+	Text       string
+
+	Annotations []*CheckRunAnnotation
+}
+
+// CheckRunAnnotation is an annotation of a check run on lines of a file.
+type CheckRunAnnotation struct {
+	Path      string
+	StartLine int
+	EndLine   int
+	// Level is one of notice, warning or failure.
+	Level   string
+	Title   string
+	Message string
+}
+
 // ToDisk converts a PullRequest object to an on-disk representation at the
 // specified path. When written, the underlying Manifests
 func ToDisk(r *Resource, path string) error {
 	labelsPath := filepath.Join(path, "labels")
 	commentsPath := filepath.Join(path, "comments")
 	statusesPath := filepath.Join(path, "status")
+	reviewCommentsPath := filepath.Join(path, "review-comments")
+	checksPath := filepath.Join(path, "checks")
 
 	// Setup subdirs
-	for _, p := range []string{labelsPath, commentsPath, statusesPath} {
+	for _, p := range []string{labelsPath, commentsPath, statusesPath, reviewCommentsPath, checksPath} {
 		if err := os.MkdirAll(p, 0755); err != nil {
 			return err
 		}
@@ -92,6 +131,14 @@ func ToDisk(r *Resource, path string) error {
 		return err
 	}
 
+	if err := reviewCommentsToDisk(reviewCommentsPath, r.ReviewComments); err != nil {
+		return err
+	}
+
+	if err := checkRunsToDisk(checksPath, r.CheckRuns); err != nil {
+		return err
+	}
+
 	// Now refs
 	if err := refToDisk("head", path, r.PR.Head); err != nil {
 		return err
@@ -141,6 +188,34 @@ func statusToDisk(path string, statuses []*scm.Status) error {
 	return nil
 }
 
+func reviewCommentsToDisk(path string, comments []*scm.ReviewComment) error {
+	manifest := Manifest{}
+	for _, c := range comments {
+		id := strconv.Itoa(c.ID)
+		if err := toDisk(filepath.Join(path, id+".json"), c, 0600); err != nil {
+			return err
+		}
+		manifest[id] = true
+	}
+	// As for comments, the manifest keeps track of the review comments that
+	// existed when the resource was initialized.
+	return manifestToDisk(manifest, filepath.Join(path, manifestPath))
+}
+
+func checkRunsToDisk(path string, checkRuns []*CheckRun) error {
+	manifest := Manifest{}
+	for _, c := range checkRuns {
+		checkPath := filepath.Join(path, url.QueryEscape(c.Name)+".json")
+		if err := toDisk(checkPath, c, 0600); err != nil {
+			return err
+		}
+		manifest[strconv.FormatInt(c.ID, 10)] = true
+	}
+	// The manifest keeps track of the check runs that existed when the resource
+	// was initialized, which are only uploaded again if they were modified.
+	return manifestToDisk(manifest, filepath.Join(path, manifestPath))
+}
+
 func refToDisk(name, path string, r scm.PullRequestBranch) error {
 	b, err := json.Marshal(r)
 	if err != nil {
@@ -199,6 +274,20 @@ func FromDisk(path string, disableJSONComments bool) (*Resource, error) {
 		return nil, err
 	}
 
+	reviewCommentsPath := filepath.Join(path, "review-comments")
+	r.ReviewComments, manifest, err = reviewCommentsFromDisk(reviewCommentsPath)
+	if err != nil {
+		return nil, err
+	}
+	r.Manifests["review-comments"] = manifest
+
+	checksPath := filepath.Join(path, "checks")
+	r.CheckRuns, manifest, err = checkRunsFromDisk(checksPath)
+	if err != nil {
+		return nil, err
+	}
+	r.Manifests["checks"] = manifest
+
 	r.PR.Base, err = refFromDisk(path, "base.json")
 	if err != nil {
 		return nil, err
@@ -335,6 +424,82 @@ func statusesFromDisk(path string) ([]*scm.Status, error) {
 	return statuses, nil
 }
 
+func reviewCommentsFromDisk(path string) ([]*scm.ReviewComment, Manifest, error) {
+	fis, err := ioutil.ReadDir(path)
+	if isNotExistError(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	comments := []*scm.ReviewComment{}
+	for _, fi := range fis {
+		if fi.Name() == manifestPath {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(path, fi.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		// Review comments need a path and a line, so they are always JSON.
+		comment := scm.ReviewComment{}
+		if err := json.Unmarshal(b, &comment); err != nil {
+			return nil, nil, fmt.Errorf("error parsing review comment file %q: %w", fi.Name(), err)
+		}
+		comments = append(comments, &comment)
+	}
+
+	// Review comments can be written by a Task for a resource that was not
+	// downloaded with them, in which case there is no manifest.
+	manifest, err := manifestFromDisk(filepath.Join(path, manifestPath))
+	if isNotExistError(err) {
+		return comments, Manifest{}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return comments, manifest, nil
+}
+
+func checkRunsFromDisk(path string) ([]*CheckRun, Manifest, error) {
+	fis, err := ioutil.ReadDir(path)
+	if isNotExistError(err) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	checkRuns := []*CheckRun{}
+	for _, fi := range fis {
+		if fi.Name() == manifestPath {
+			continue
+		}
+		b, err := ioutil.ReadFile(filepath.Join(path, fi.Name()))
+		if err != nil {
+			return nil, nil, err
+		}
+		checkRun := CheckRun{}
+		if err := json.Unmarshal(b, &checkRun); err != nil {
+			return nil, nil, fmt.Errorf("error parsing check file %q: %w", fi.Name(), err)
+		}
+		checkRuns = append(checkRuns, &checkRun)
+	}
+
+	// Check runs can be written by a Task for a resource that was not
+	// downloaded with them, in which case there is no manifest.
+	manifest, err := manifestFromDisk(filepath.Join(path, manifestPath))
+	if isNotExistError(err) {
+		return checkRuns, Manifest{}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return checkRuns, manifest, nil
+}
+
 func refFromDisk(path, name string) (scm.PullRequestBranch, error) {
 	b, err := ioutil.ReadFile(filepath.Join(path, name))
 	if err != nil {
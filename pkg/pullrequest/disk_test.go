@@ -777,3 +777,62 @@ func TestFromDiskPRShaWithNullHeadAndBase(t *testing.T) {
 	}
 
 }
+
+func TestReviewCommentsAndCheckRunsDisk(t *testing.T) {
+	d := t.TempDir()
+	rsrc := &Resource{
+		PR: &scm.PullRequest{Number: 1, Sha: "sha1"},
+		ReviewComments: []*scm.ReviewComment{
+			{ID: 1, Body: "nit", Path: "main.go", Line: 3},
+			{ID: 2, Body: "typo", Path: "README.md", Line: 10},
+		},
+		CheckRuns: []*CheckRun{{
+			ID:         3,
+			Name:       "lint/go",
+			Status:     "completed",
+			Conclusion: "failure",
+			Title:      "1 issue",
+			Summary:    "Found 1 issue",
+			Annotations: []*CheckRunAnnotation{{
+				Path:      "main.go",
+				StartLine: 3,
+				EndLine:   3,
+				Level:     "warning",
+				Message:   "unused variable",
+			}},
+		}},
+	}
+	if err := ToDisk(rsrc, d); err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range []string{"review-comments/1.json", "review-comments/2.json", "checks/lint%2Fgo.json"} {
+		if _, err := os.Stat(filepath.Join(d, f)); err != nil {
+			t.Errorf("expected file %s to exist", f)
+		}
+	}
+
+	// A review comment added by a Task, which has no ID yet.
+	b, err := json.Marshal(&scm.ReviewComment{Body: "unused variable", Path: "main.go", Line: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(d, "review-comments", "new.json"), b, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := FromDisk(d, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantReviewComments := append(rsrc.ReviewComments, &scm.ReviewComment{Body: "unused variable", Path: "main.go", Line: 4})
+	sortReviewComments := cmpopts.SortSlices(func(x, y *scm.ReviewComment) bool { return x.Line < y.Line })
+	if d := cmp.Diff(wantReviewComments, got.ReviewComments, sortReviewComments); d != "" {
+		t.Errorf("ReviewComments %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(rsrc.CheckRuns, got.CheckRuns); d != "" {
+		t.Errorf("CheckRuns %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(Manifest{"1": true, "2": true}, got.Manifests["review-comments"]); d != "" {
+		t.Errorf("review-comments manifest %s", diff.PrintWantGot(d))
+	}
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// This file contains the requests to the GitHub API for the review comments on
+// lines of files and the check runs, which go-scm doesn't support. They are sent
+// with the SCM client, which holds the base URL and the credentials.
+
+// maxAnnotationsPerRequest is the maximum number of annotations GitHub accepts
+// in a single request creating or updating a check run.
+const maxAnnotationsPerRequest = 50
+
+type githubUser struct {
+	ID        int    `json:"id"`
+	Login     string `json:"login"`
+	AvatarURL string `json:"avatar_url"`
+}
+
+type githubReviewComment struct {
+	ID        int        `json:"id"`
+	Body      string     `json:"body"`
+	Path      string     `json:"path"`
+	Line      int        `json:"line"`
+	CommitID  string     `json:"commit_id"`
+	HTMLURL   string     `json:"html_url"`
+	User      githubUser `json:"user"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+type githubReviewCommentInput struct {
+	Body     string `json:"body"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Side     string `json:"side"`
+	CommitID string `json:"commit_id"`
+}
+
+type githubCheckRuns struct {
+	CheckRuns []*githubCheckRun `json:"check_runs"`
+}
+
+type githubCheckRun struct {
+	ID         int64                 `json:"id,omitempty"`
+	Name       string                `json:"name,omitempty"`
+	HeadSHA    string                `json:"head_sha,omitempty"`
+	Status     string                `json:"status,omitempty"`
+	Conclusion string                `json:"conclusion,omitempty"`
+	DetailsURL string                `json:"details_url,omitempty"`
+	Output     *githubCheckRunOutput `json:"output,omitempty"`
+}
+
+type githubCheckRunOutput struct {
+	Title            string                      `json:"title"`
+	Summary          string                      `json:"summary"`
+	Text             string                      `json:"text,omitempty"`
+	AnnotationsCount int                         `json:"annotations_count,omitempty"`
+	Annotations      []*githubCheckRunAnnotation `json:"annotations,omitempty"`
+}
+
+type githubCheckRunAnnotation struct {
+	Path            string `json:"path"`
+	StartLine       int    `json:"start_line"`
+	EndLine         int    `json:"end_line"`
+	AnnotationLevel string `json:"annotation_level"`
+	Title           string `json:"title,omitempty"`
+	Message         string `json:"message"`
+}
+
+func (h *Handler) listReviewComments(ctx context.Context) ([]*scm.ReviewComment, error) {
+	out := []*githubReviewComment{}
+	if err := h.githubList(ctx, fmt.Sprintf("repos/%s/pulls/%d/comments?per_page=100", h.repo, h.prNum), func(body io.Reader) error {
+		page := []*githubReviewComment{}
+		if err := json.NewDecoder(body).Decode(&page); err != nil {
+			return err
+		}
+		out = append(out, page...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	comments := make([]*scm.ReviewComment, 0, len(out))
+	for _, c := range out {
+		comments = append(comments, &scm.ReviewComment{
+			ID:   c.ID,
+			Body: c.Body,
+			Path: c.Path,
+			Sha:  c.CommitID,
+			Line: c.Line,
+			Link: c.HTMLURL,
+			Author: scm.User{
+				ID:     c.User.ID,
+				Login:  c.User.Login,
+				Avatar: c.User.AvatarURL,
+			},
+			Created: c.CreatedAt,
+			Updated: c.UpdatedAt,
+		})
+	}
+	return comments, nil
+}
+
+func (h *Handler) createReviewComment(ctx context.Context, c *scm.ReviewComment, sha string) error {
+	if c.Sha != "" {
+		sha = c.Sha
+	}
+	in := &githubReviewCommentInput{
+		Body:     c.Body,
+		Path:     c.Path,
+		Line:     c.Line,
+		Side:     "RIGHT",
+		CommitID: sha,
+	}
+	return h.githubDo(ctx, http.MethodPost, fmt.Sprintf("repos/%s/pulls/%d/comments", h.repo, h.prNum), in, nil)
+}
+
+func (h *Handler) deleteReviewComment(ctx context.Context, id int) error {
+	return h.githubDo(ctx, http.MethodDelete, fmt.Sprintf("repos/%s/pulls/comments/%d", h.repo, id), nil, nil)
+}
+
+// listCheckRuns returns the latest check run of each name on the commit, with
+// their annotations.
+func (h *Handler) listCheckRuns(ctx context.Context, sha string) ([]*CheckRun, error) {
+	out := []*githubCheckRun{}
+	if err := h.githubList(ctx, fmt.Sprintf("repos/%s/commits/%s/check-runs?per_page=100", h.repo, sha), func(body io.Reader) error {
+		page := &githubCheckRuns{}
+		if err := json.NewDecoder(body).Decode(page); err != nil {
+			return err
+		}
+		out = append(out, page.CheckRuns...)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	checkRuns := make([]*CheckRun, 0, len(out))
+	for _, c := range out {
+		checkRun, err := h.toCheckRun(ctx, c)
+		if err != nil {
+			return nil, err
+		}
+		checkRuns = append(checkRuns, checkRun)
+	}
+	return checkRuns, nil
+}
+
+// getCheckRun returns the check run with the given ID, with its annotations.
+func (h *Handler) getCheckRun(ctx context.Context, id int64) (*CheckRun, error) {
+	out := &githubCheckRun{}
+	if err := h.githubDo(ctx, http.MethodGet, fmt.Sprintf("repos/%s/check-runs/%d", h.repo, id), nil, out); err != nil {
+		return nil, err
+	}
+	return h.toCheckRun(ctx, out)
+}
+
+// toCheckRun converts the check run returned by GitHub, retrieving its annotations.
+func (h *Handler) toCheckRun(ctx context.Context, c *githubCheckRun) (*CheckRun, error) {
+	checkRun := &CheckRun{
+		ID:         c.ID,
+		Name:       c.Name,
+		Status:     c.Status,
+		Conclusion: c.Conclusion,
+		DetailsURL: c.DetailsURL,
+	}
+	if c.Output == nil {
+		return checkRun, nil
+	}
+	checkRun.Title = c.Output.Title
+	checkRun.Summary = c.Output.Summary
+	checkRun.Text = c.Output.Text
+	if c.Output.AnnotationsCount == 0 {
+		return checkRun, nil
+	}
+	if err := h.githubList(ctx, fmt.Sprintf("repos/%s/check-runs/%d/annotations?per_page=100", h.repo, c.ID), func(body io.Reader) error {
+		annotations := []*githubCheckRunAnnotation{}
+		if err := json.NewDecoder(body).Decode(&annotations); err != nil {
+			return err
+		}
+		for _, a := range annotations {
+			checkRun.Annotations = append(checkRun.Annotations, &CheckRunAnnotation{
+				Path:      a.Path,
+				StartLine: a.StartLine,
+				EndLine:   a.EndLine,
+				Level:     a.AnnotationLevel,
+				Title:     a.Title,
+				Message:   a.Message,
+			})
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return checkRun, nil
+}
+
+// createCheckRun creates the check run on the commit. GitHub limits the number of
+// annotations per request, the remaining annotations are added by updating the
+// check run.
+func (h *Handler) createCheckRun(ctx context.Context, c *CheckRun, sha string) error {
+	in := &githubCheckRun{
+		Name:       c.Name,
+		HeadSHA:    sha,
+		Status:     c.Status,
+		Conclusion: c.Conclusion,
+		DetailsURL: c.DetailsURL,
+	}
+	annotations := make([]*githubCheckRunAnnotation, 0, len(c.Annotations))
+	for _, a := range c.Annotations {
+		annotations = append(annotations, &githubCheckRunAnnotation{
+			Path:            a.Path,
+			StartLine:       a.StartLine,
+			EndLine:         a.EndLine,
+			AnnotationLevel: a.Level,
+			Title:           a.Title,
+			Message:         a.Message,
+		})
+	}
+	output := func(annotations []*githubCheckRunAnnotation) *githubCheckRunOutput {
+		if c.Title == "" && c.Summary == "" && c.Text == "" && len(annotations) == 0 {
+			return nil
+		}
+		return &githubCheckRunOutput{
+			Title:       c.Title,
+			Summary:     c.Summary,
+			Text:        c.Text,
+			Annotations: annotations,
+		}
+	}
+
+	batch := annotations
+	if len(batch) > maxAnnotationsPerRequest {
+		batch = batch[:maxAnnotationsPerRequest]
+	}
+	in.Output = output(batch)
+	out := &githubCheckRun{}
+	if err := h.githubDo(ctx, http.MethodPost, fmt.Sprintf("repos/%s/check-runs", h.repo), in, out); err != nil {
+		return err
+	}
+
+	for i := len(batch); i < len(annotations); i += maxAnnotationsPerRequest {
+		end := i + maxAnnotationsPerRequest
+		if end > len(annotations) {
+			end = len(annotations)
+		}
+		update := &githubCheckRun{Output: output(annotations[i:end])}
+		if err := h.githubDo(ctx, http.MethodPatch, fmt.Sprintf("repos/%s/check-runs/%d", h.repo, out.ID), update, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// githubDo sends the request to the GitHub API, and unmarshals the response
+// into out when it is set.
+func (h *Handler) githubDo(ctx context.Context, method, path string, in, out interface{}) error {
+	_, err := h.githubSend(ctx, method, path, in, func(body io.Reader) error {
+		if out == nil {
+			return nil
+		}
+		return json.NewDecoder(body).Decode(out)
+	})
+	return err
+}
+
+// githubList gets every page of the list at path, which must already have a
+// query, following the next page of the Link header of the responses. The
+// body of each page is passed to decode.
+func (h *Handler) githubList(ctx context.Context, path string, decode func(io.Reader) error) error {
+	for page := 1; page > 0; {
+		res, err := h.githubSend(ctx, http.MethodGet, fmt.Sprintf("%s&page=%d", path, page), nil, decode)
+		if err != nil {
+			return err
+		}
+		if res.Page.Next <= page {
+			return nil
+		}
+		page = res.Page.Next
+	}
+	return nil
+}
+
+// githubSend sends the request to the GitHub API, and passes the body of the
+// response to decode.
+func (h *Handler) githubSend(ctx context.Context, method, path string, in interface{}, decode func(io.Reader) error) (*scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+		Header: http.Header{"Accept": []string{"application/vnd.github.v3+json"}},
+	}
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		req.Body = bytes.NewReader(b)
+	}
+	res, err := h.client.Do(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	if res.Status < 200 || res.Status > 299 {
+		body, _ := ioutil.ReadAll(res.Body)
+		return nil, fmt.Errorf("%s %s: %s: %s", method, path, http.StatusText(res.Status), string(body))
+	}
+	return res, decode(res.Body)
+}
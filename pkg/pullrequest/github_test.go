@@ -0,0 +1,481 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pullrequest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/tektoncd/pipeline/test/diff"
+	"go.uber.org/zap/zaptest"
+)
+
+// fakeGitHub is a fake GitHub API serving the PR foo/bar#1 with the head commit
+// sha1, and recording the review comments and check runs created by the Handler.
+type fakeGitHub struct {
+	mu             sync.Mutex
+	nextID         int
+	reviewComments []*githubReviewComment
+	checkRuns      []*githubCheckRun
+	annotations    map[int64][]*githubCheckRunAnnotation
+	// forbidden are the paths answered with a 403, as when the token isn't allowed
+	// to access them.
+	forbidden map[string]bool
+	// pageSize is the number of items of each page of the lists, when set.
+	pageSize int
+	// requests are the requests modifying the PR, as "<method> <path>".
+	requests []string
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *Handler) {
+	t.Helper()
+	f := &fakeGitHub{
+		nextID:      100,
+		annotations: map[int64][]*githubCheckRunAnnotation{},
+	}
+	server := httptest.NewServer(f)
+	t.Cleanup(server.Close)
+
+	h, err := NewSCMHandler(zaptest.NewLogger(t).Sugar(), server.URL+"/foo/bar/pull/1", "github", "token", false)
+	if err != nil {
+		t.Fatalf("NewSCMHandler() = %v", err)
+	}
+	return f, h
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/api/v3/repos/foo/bar/")
+	if r.Method != http.MethodGet {
+		f.requests = append(f.requests, r.Method+" "+path)
+	}
+	if f.forbidden[path] {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	var out interface{}
+	switch {
+	case r.Method == http.MethodGet && path == "pulls/1":
+		out = map[string]interface{}{"number": 1, "head": map[string]string{"sha": "sha1"}}
+	case r.Method == http.MethodGet && (path == "statuses/sha1" || path == "issues/1/comments" || path == "issues/1/labels"):
+		out = []interface{}{}
+	case r.Method == http.MethodGet && path == "pulls/1/comments":
+		start, end := f.page(w, r, len(f.reviewComments))
+		out = f.reviewComments[start:end]
+	case r.Method == http.MethodPost && path == "pulls/1/comments":
+		in := &githubReviewCommentInput{}
+		if err := json.NewDecoder(r.Body).Decode(in); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		c := &githubReviewComment{ID: f.nextID, Body: in.Body, Path: in.Path, Line: in.Line, CommitID: in.CommitID}
+		f.nextID++
+		f.reviewComments = append(f.reviewComments, c)
+		out = c
+	case r.Method == http.MethodDelete && strings.HasPrefix(path, "pulls/comments/"):
+		id, _ := strconv.Atoi(strings.TrimPrefix(path, "pulls/comments/"))
+		for i, c := range f.reviewComments {
+			if c.ID == id {
+				f.reviewComments = append(f.reviewComments[:i], f.reviewComments[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		return
+	case r.Method == http.MethodGet && path == "commits/sha1/check-runs":
+		// Only the latest check run of each name is returned.
+		latest := []*githubCheckRun{}
+		seen := map[string]bool{}
+		for i := len(f.checkRuns) - 1; i >= 0; i-- {
+			if c := f.checkRuns[i]; !seen[c.Name] {
+				seen[c.Name] = true
+				latest = append(latest, c)
+			}
+		}
+		start, end := f.page(w, r, len(latest))
+		out = &githubCheckRuns{CheckRuns: latest[start:end]}
+	case r.Method == http.MethodPost && path == "check-runs":
+		in := &githubCheckRun{}
+		if err := json.NewDecoder(r.Body).Decode(in); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		in.ID = int64(f.nextID)
+		f.nextID++
+		f.addAnnotations(in)
+		f.checkRuns = append(f.checkRuns, in)
+		out = in
+	case r.Method == http.MethodPatch && strings.HasPrefix(path, "check-runs/"):
+		id, _ := strconv.ParseInt(strings.TrimPrefix(path, "check-runs/"), 10, 64)
+		in := &githubCheckRun{}
+		if err := json.NewDecoder(r.Body).Decode(in); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		in.ID = id
+		f.addAnnotations(in)
+		out = in
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "check-runs/") && strings.HasSuffix(path, "/annotations"):
+		id, _ := strconv.ParseInt(strings.TrimSuffix(strings.TrimPrefix(path, "check-runs/"), "/annotations"), 10, 64)
+		start, end := f.page(w, r, len(f.annotations[id]))
+		out = f.annotations[id][start:end]
+	case r.Method == http.MethodGet && strings.HasPrefix(path, "check-runs/"):
+		id, _ := strconv.ParseInt(strings.TrimPrefix(path, "check-runs/"), 10, 64)
+		for _, c := range f.checkRuns {
+			if c.ID == id {
+				out = c
+			}
+		}
+		if out == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+// page returns the bounds of the requested page of a list of n items, and links
+// to the next page in the Link header of the response, as GitHub does.
+func (f *fakeGitHub) page(w http.ResponseWriter, r *http.Request, n int) (int, int) {
+	if f.pageSize == 0 {
+		return 0, n
+	}
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	start, end := (page-1)*f.pageSize, page*f.pageSize
+	if start > n {
+		start = n
+	}
+	if end >= n {
+		return start, n
+	}
+	next := *r.URL
+	q := next.Query()
+	q.Set("page", strconv.Itoa(page+1))
+	next.RawQuery = q.Encode()
+	w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.RequestURI()))
+	return start, end
+}
+
+// addAnnotations records the annotations sent for the check run, which are
+// appended to its existing annotations, as GitHub does.
+func (f *fakeGitHub) addAnnotations(in *githubCheckRun) {
+	if in.Output == nil {
+		return
+	}
+	f.annotations[in.ID] = append(f.annotations[in.ID], in.Output.Annotations...)
+	for _, c := range f.checkRuns {
+		if c.ID == in.ID {
+			c.Output.AnnotationsCount = len(f.annotations[in.ID])
+		}
+	}
+	in.Output.AnnotationsCount = len(f.annotations[in.ID])
+	in.Output.Annotations = nil
+}
+
+func TestGitHubDownload(t *testing.T) {
+	f, h := newFakeGitHub(t)
+	f.reviewComments = []*githubReviewComment{{ID: 1, Body: "nit", Path: "main.go", Line: 3, CommitID: "sha1"}}
+	f.checkRuns = []*githubCheckRun{{
+		ID:         2,
+		Name:       "lint",
+		Status:     "completed",
+		Conclusion: "failure",
+		Output:     &githubCheckRunOutput{Title: "1 issue", Summary: "Found 1 issue", AnnotationsCount: 1},
+	}}
+	f.annotations[2] = []*githubCheckRunAnnotation{{Path: "main.go", StartLine: 3, EndLine: 3, AnnotationLevel: "warning", Message: "unused variable"}}
+
+	got, err := h.Download(context.Background())
+	if err != nil {
+		t.Fatalf("Download() = %v", err)
+	}
+
+	wantReviewComments := []*scm.ReviewComment{{ID: 1, Body: "nit", Path: "main.go", Line: 3, Sha: "sha1"}}
+	if d := cmp.Diff(wantReviewComments, got.ReviewComments); d != "" {
+		t.Errorf("ReviewComments %s", diff.PrintWantGot(d))
+	}
+	wantCheckRuns := []*CheckRun{{
+		ID:         2,
+		Name:       "lint",
+		Status:     "completed",
+		Conclusion: "failure",
+		Title:      "1 issue",
+		Summary:    "Found 1 issue",
+		Annotations: []*CheckRunAnnotation{{
+			Path:      "main.go",
+			StartLine: 3,
+			EndLine:   3,
+			Level:     "warning",
+			Message:   "unused variable",
+		}},
+	}}
+	if d := cmp.Diff(wantCheckRuns, got.CheckRuns); d != "" {
+		t.Errorf("CheckRuns %s", diff.PrintWantGot(d))
+	}
+	if d := cmp.Diff(Manifest{"1": true}, got.Manifests["review-comments"]); d != "" {
+		t.Errorf("review-comments manifest %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestGitHubDownloadPages tests that every page of the review comments, the check runs
+// and their annotations is downloaded.
+func TestGitHubDownloadPages(t *testing.T) {
+	f, h := newFakeGitHub(t)
+	f.pageSize = 2
+	for i := 1; i <= 3; i++ {
+		f.reviewComments = append(f.reviewComments, &githubReviewComment{ID: i, Body: "nit", Path: "main.go", Line: i, CommitID: "sha1"})
+	}
+	for i := 4; i <= 6; i++ {
+		f.checkRuns = append(f.checkRuns, &githubCheckRun{ID: int64(i), Name: fmt.Sprintf("check-%d", i), Status: "completed", Conclusion: "success"})
+	}
+	f.checkRuns[0].Output = &githubCheckRunOutput{Title: "3 issues", Summary: "Found 3 issues", AnnotationsCount: 3}
+	for i := 1; i <= 3; i++ {
+		f.annotations[4] = append(f.annotations[4], &githubCheckRunAnnotation{Path: "main.go", StartLine: i, EndLine: i, AnnotationLevel: "warning", Message: "issue"})
+	}
+
+	got, err := h.Download(context.Background())
+	if err != nil {
+		t.Fatalf("Download() = %v", err)
+	}
+	if len(got.ReviewComments) != 3 {
+		t.Errorf("Expected 3 review comments, got %d", len(got.ReviewComments))
+	}
+	if len(got.CheckRuns) != 3 {
+		t.Fatalf("Expected 3 check runs, got %d", len(got.CheckRuns))
+	}
+	for _, c := range got.CheckRuns {
+		if c.ID == 4 && len(c.Annotations) != 3 {
+			t.Errorf("Expected 3 annotations for check run %d, got %d", c.ID, len(c.Annotations))
+		}
+	}
+	if d := cmp.Diff(Manifest{"4": true, "5": true, "6": true}, got.Manifests["checks"]); d != "" {
+		t.Errorf("checks manifest %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestGitHubDownloadForbidden tests that the PR is downloaded when the review comments and
+// the check runs can't be listed.
+func TestGitHubDownloadForbidden(t *testing.T) {
+	f, h := newFakeGitHub(t)
+	f.forbidden = map[string]bool{"pulls/1/comments": true, "commits/sha1/check-runs": true}
+
+	got, err := h.Download(context.Background())
+	if err != nil {
+		t.Fatalf("Download() = %v", err)
+	}
+	if got.PR.Number != 1 {
+		t.Errorf("PR.Number = %d, want 1", got.PR.Number)
+	}
+	if len(got.ReviewComments) != 0 || len(got.CheckRuns) != 0 {
+		t.Errorf("Expected no review comments nor check runs, got %v and %v", got.ReviewComments, got.CheckRuns)
+	}
+}
+
+func TestGitHubUploadReviewComments(t *testing.T) {
+	ctx := context.Background()
+	f, h := newFakeGitHub(t)
+	f.reviewComments = []*githubReviewComment{
+		// Deleted from the resource.
+		{ID: 1, Body: "removed", Path: "main.go", Line: 1, CommitID: "sha1"},
+		// Kept in the resource.
+		{ID: 2, Body: "kept", Path: "main.go", Line: 2, CommitID: "sha1"},
+		// Created after the resource was initialized.
+		{ID: 3, Body: "untracked", Path: "main.go", Line: 3, CommitID: "sha1"},
+	}
+
+	r := &Resource{
+		PR: &scm.PullRequest{Sha: "sha1"},
+		ReviewComments: []*scm.ReviewComment{
+			{ID: 2, Body: "kept", Path: "main.go", Line: 2},
+			{Body: "unused variable", Path: "util.go", Line: 10},
+		},
+		Manifests: map[string]Manifest{"review-comments": {"1": true, "2": true}},
+	}
+	if err := h.Upload(ctx, r); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	// Uploading the resource again doesn't duplicate the new review comment.
+	if err := h.Upload(ctx, r); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+
+	want := []*githubReviewComment{
+		{ID: 2, Body: "kept", Path: "main.go", Line: 2, CommitID: "sha1"},
+		{ID: 3, Body: "untracked", Path: "main.go", Line: 3, CommitID: "sha1"},
+		{ID: 100, Body: "unused variable", Path: "util.go", Line: 10, CommitID: "sha1"},
+	}
+	if d := cmp.Diff(want, f.reviewComments); d != "" {
+		t.Errorf("review comments %s", diff.PrintWantGot(d))
+	}
+	wantRequests := []string{"DELETE pulls/comments/1", "POST pulls/1/comments"}
+	if d := cmp.Diff(wantRequests, f.requests); d != "" {
+		t.Errorf("requests %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestGitHubUploadCheckRuns(t *testing.T) {
+	ctx := context.Background()
+	f, h := newFakeGitHub(t)
+	f.checkRuns = []*githubCheckRun{{
+		ID:         1,
+		Name:       "unchanged",
+		Status:     "completed",
+		Conclusion: "success",
+		Output:     &githubCheckRunOutput{Title: "No issues", Summary: "Found no issues"},
+	}}
+
+	annotations := []*CheckRunAnnotation{}
+	for i := 1; i <= 60; i++ {
+		annotations = append(annotations, &CheckRunAnnotation{Path: "main.go", StartLine: i, EndLine: i, Level: "warning", Message: fmt.Sprintf("issue %d", i)})
+	}
+	r := &Resource{
+		PR: &scm.PullRequest{Sha: "sha1"},
+		CheckRuns: []*CheckRun{{
+			Name:    "unchanged",
+			Title:   "No issues",
+			Summary: "Found no issues",
+			// The status is defaulted by GitHub.
+			Conclusion: "success",
+		}, {
+			Name:        "lint",
+			Conclusion:  "failure",
+			Title:       "60 issues",
+			Summary:     "Found 60 issues",
+			Annotations: annotations,
+		}},
+	}
+	if err := h.Upload(ctx, r); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	// Uploading the resource again doesn't create the check runs again.
+	if err := h.Upload(ctx, r); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+
+	// The annotations beyond the first 50 are added by updating the check run.
+	wantRequests := []string{"POST check-runs", "PATCH check-runs/100"}
+	if d := cmp.Diff(wantRequests, f.requests); d != "" {
+		t.Errorf("requests %s", diff.PrintWantGot(d))
+	}
+	if got := len(f.annotations[100]); got != 60 {
+		t.Errorf("check run has %d annotations, want 60", got)
+	}
+
+	// A changed check run replaces the latest one.
+	r.CheckRuns[1].Annotations = annotations[:1]
+	if err := h.Upload(ctx, r); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+	wantRequests = append(wantRequests, "POST check-runs")
+	if d := cmp.Diff(wantRequests, f.requests); d != "" {
+		t.Errorf("requests %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestGitHubUploadDownloadedCheckRuns tests that the downloaded check runs, e.g. of other
+// GitHub Apps, are only created again when the Task modified them, even if another check
+// run with the same name was created since.
+func TestGitHubUploadDownloadedCheckRuns(t *testing.T) {
+	f, h := newFakeGitHub(t)
+	f.checkRuns = []*githubCheckRun{{
+		ID:         1,
+		Name:       "ci",
+		Status:     "completed",
+		Conclusion: "failure",
+	}, {
+		ID:         2,
+		Name:       "lint",
+		Status:     "completed",
+		Conclusion: "failure",
+	}, {
+		// Created by another GitHub App after the resource was initialized.
+		ID:         3,
+		Name:       "ci",
+		Status:     "completed",
+		Conclusion: "success",
+	}}
+
+	r := &Resource{
+		PR: &scm.PullRequest{Sha: "sha1"},
+		CheckRuns: []*CheckRun{
+			{ID: 1, Name: "ci", Status: "completed", Conclusion: "failure"},
+			// Modified by the Task.
+			{ID: 2, Name: "lint", Status: "completed", Conclusion: "success"},
+		},
+		Manifests: map[string]Manifest{"checks": {"1": true, "2": true}},
+	}
+	if err := h.Upload(context.Background(), r); err != nil {
+		t.Fatalf("Upload() = %v", err)
+	}
+
+	wantRequests := []string{"POST check-runs"}
+	if d := cmp.Diff(wantRequests, f.requests); d != "" {
+		t.Errorf("requests %s", diff.PrintWantGot(d))
+	}
+	if got := f.checkRuns[len(f.checkRuns)-1]; got.Name != "lint" || got.Conclusion != "success" {
+		t.Errorf("Expected the modified lint check run to be created, got %+v", got)
+	}
+}
+
+func TestUpload_InvalidCheckRun(t *testing.T) {
+	_, h := newFakeGitHub(t)
+	r := &Resource{
+		PR: &scm.PullRequest{Sha: "sha1"},
+		CheckRuns: []*CheckRun{{
+			Name:        "lint",
+			Annotations: []*CheckRunAnnotation{{Path: "main.go", StartLine: 1, EndLine: 1, Level: "warning", Message: "issue"}},
+		}},
+	}
+	if err := h.Upload(context.Background(), r); err == nil {
+		t.Error("Upload() expected an error for a check run with annotations and without a title and summary")
+	}
+}
+
+func TestUpload_ReviewCommentsAndCheckRunsNotSupported(t *testing.T) {
+	client, _ := fake.NewDefault()
+	h := NewHandler(zaptest.NewLogger(t).Sugar(), client, repo, prNum)
+	for _, r := range []*Resource{{
+		PR:             &scm.PullRequest{Sha: "sha1"},
+		ReviewComments: []*scm.ReviewComment{{Body: "nit", Path: "main.go", Line: 1}},
+	}, {
+		PR:        &scm.PullRequest{Sha: "sha1"},
+		CheckRuns: []*CheckRun{{Name: "lint", Conclusion: "success"}},
+	}} {
+		if err := h.Upload(context.Background(), r); err == nil {
+			t.Errorf("Upload() expected an error for %+v", r)
+		}
+	}
+}
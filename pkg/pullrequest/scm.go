@@ -27,8 +27,11 @@ import (
 
 	"crypto/tls"
 
+	"github.com/jenkins-x/go-scm/scm/driver/bitbucket"
+	"github.com/jenkins-x/go-scm/scm/driver/gitea"
 	"github.com/jenkins-x/go-scm/scm/driver/github"
 	"github.com/jenkins-x/go-scm/scm/driver/gitlab"
+	"github.com/jenkins-x/go-scm/scm/driver/stash"
 	"go.uber.org/zap"
 )
 
@@ -54,6 +57,12 @@ func NewSCMHandler(logger *zap.SugaredLogger, raw, provider, token string, skipT
 		handler, err = githubHandlerFromURL(u, token, skipTLSVerify, logger)
 	case "gitlab":
 		handler, err = gitlabHandlerFromURL(u, token, skipTLSVerify, logger)
+	case "gitea":
+		handler, err = giteaHandlerFromURL(u, token, skipTLSVerify, logger)
+	case "bitbucket-cloud":
+		handler, err = bitbucketCloudHandlerFromURL(u, token, skipTLSVerify, logger)
+	case "bitbucket-server":
+		handler, err = bitbucketServerHandlerFromURL(u, token, skipTLSVerify, logger)
 	default:
 		return nil, fmt.Errorf("unsupported pr url: %s", raw)
 	}
@@ -96,14 +105,7 @@ func githubHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *
 	}
 	ownerRepo := fmt.Sprintf("%s/%s", owner, repo)
 
-	// Make sure to keep the default transport. This has builtin features like
-	// recognizing proxy settings that are useful to us.
-	t := http.DefaultTransport.(*http.Transport).Clone()
-	// gosec complains that we're setting the InsecureSkipVerify option to bypass
-	// security checks. As long as this is generally set to false (which is the
-	// case by default), this should be fine.
-	// #nosec G402
-	t.TLSClientConfig = &tls.Config{InsecureSkipVerify: skipTLSVerify}
+	t := newTransport(skipTLSVerify)
 
 	if token != "" {
 		ts := oauth2.StaticTokenSource(
@@ -157,9 +159,7 @@ func gitlabHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *
 		}
 	}
 
-	t := http.DefaultTransport.(*http.Transport).Clone()
-	// #nosec G402
-	t.TLSClientConfig = &tls.Config{InsecureSkipVerify: skipTLSVerify}
+	t := newTransport(skipTLSVerify)
 
 	if token != "" {
 		client.Client = &http.Client{
@@ -189,12 +189,206 @@ func (g *gitlabClient) RoundTrip(r *http.Request) (*http.Response, error) {
 	return g.transport.RoundTrip(r)
 }
 
+func giteaHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *zap.SugaredLogger) (*Handler, error) {
+	// Gitea can be served under a sub-path, so look for the PR from right to left:
+	// http(s)://[hostname]/[sub-path]/[owner]/[repo]/pulls/[number]
+	split := strings.Split(u.Path, "/")
+	i := lastIndex(split, "pulls")
+	if i < 3 || i+1 >= len(split) {
+		return nil, fmt.Errorf("invalid gitea url: %s", u)
+	}
+	owner, repo, pr := split[i-2], split[i-1], split[i+1]
+	prNumber, err := strconv.Atoi(pr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PR number: %s", pr)
+	}
+	logger = logger.With(
+		zap.String("owner", owner),
+		zap.String("repo", repo),
+		zap.String("pr", pr),
+	)
+
+	// The Gitea SDK used by the driver creates its own HTTP client, which
+	// always verifies the certificates of the server.
+	if skipTLSVerify {
+		return nil, fmt.Errorf("skipping TLS verification is not supported for gitea: %s", u)
+	}
+	// The Gitea SDK reads the version of the server when the client is created,
+	// and sends the token in the Authorization header of every request.
+	client, err := gitea.NewWithToken(fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, strings.Join(split[:i-2], "/")), token)
+	if err != nil {
+		return nil, fmt.Errorf("error creating client: %w", err)
+	}
+	// Some requests of the driver go through the SCM client instead of the SDK.
+	t := newTransport(skipTLSVerify)
+	if token != "" {
+		client.Client = &http.Client{
+			Transport: &giteaClient{
+				token:     token,
+				transport: t,
+			},
+		}
+	} else {
+		client.Client = &http.Client{
+			Transport: t,
+		}
+	}
+
+	return NewHandler(logger, client, fmt.Sprintf("%s/%s", owner, repo), prNumber), nil
+}
+
+func bitbucketCloudHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *zap.SugaredLogger) (*Handler, error) {
+	// https://bitbucket.org/[workspace]/[repo]/pull-requests/[number]
+	split := strings.Split(u.Path, "/")
+	if len(split) < 5 || split[3] != "pull-requests" {
+		return nil, fmt.Errorf("invalid bitbucket cloud url: %s", u)
+	}
+	workspace, repo, pr := split[1], split[2], split[4]
+	prNumber, err := strconv.Atoi(pr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PR number: %s", pr)
+	}
+	logger = logger.With(
+		zap.String("workspace", workspace),
+		zap.String("repo", repo),
+		zap.String("pr", pr),
+	)
+
+	// As for GitHub, the scheme of the incoming URL is kept to allow for
+	// easier proxy interception of requests in tests.
+	var prefix string
+	if u.Host == "bitbucket.org" {
+		prefix = fmt.Sprintf("%s://api.bitbucket.org", u.Scheme)
+	} else {
+		prefix = fmt.Sprintf("%s://%s", u.Scheme, u.Host)
+	}
+	client, err := bitbucket.New(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("error creating client: %w", err)
+	}
+	client.Client = &http.Client{
+		Transport: bitbucketTransport(token, newTransport(skipTLSVerify)),
+	}
+
+	return NewHandler(logger, client, fmt.Sprintf("%s/%s", workspace, repo), prNumber), nil
+}
+
+func bitbucketServerHandlerFromURL(u *url.URL, token string, skipTLSVerify bool, logger *zap.SugaredLogger) (*Handler, error) {
+	// Bitbucket Server can be served under a context path, and the repositories
+	// belong to projects or to users:
+	// http(s)://[hostname]/[context-path]/projects/[key]/repos/[repo]/pull-requests/[number]
+	// http(s)://[hostname]/[context-path]/users/[user]/repos/[repo]/pull-requests/[number]
+	split := strings.Split(u.Path, "/")
+	i := lastIndex(split, "pull-requests")
+	if i < 5 || i+1 >= len(split) || split[i-2] != "repos" || (split[i-4] != "projects" && split[i-4] != "users") {
+		return nil, fmt.Errorf("invalid bitbucket server url: %s", u)
+	}
+	project, repo, pr := split[i-3], split[i-1], split[i+1]
+	if split[i-4] == "users" {
+		// The personal project of a user is keyed by its name prefixed with a ~.
+		project = "~" + project
+	}
+	prNumber, err := strconv.Atoi(pr)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing PR number: %s", pr)
+	}
+	logger = logger.With(
+		zap.String("project", project),
+		zap.String("repo", repo),
+		zap.String("pr", pr),
+	)
+
+	client, err := stash.New(fmt.Sprintf("%s://%s%s", u.Scheme, u.Host, strings.Join(split[:i-4], "/")))
+	if err != nil {
+		return nil, fmt.Errorf("error creating client: %w", err)
+	}
+	client.Client = &http.Client{
+		Transport: bitbucketTransport(token, newTransport(skipTLSVerify)),
+	}
+
+	return NewHandler(logger, client, fmt.Sprintf("%s/%s", project, repo), prNumber), nil
+}
+
+// newTransport returns a copy of the default transport. Make sure to keep the
+// default transport, it has builtin features like recognizing proxy settings
+// that are useful to us.
+func newTransport(skipTLSVerify bool) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	// gosec complains that we're setting the InsecureSkipVerify option to bypass
+	// security checks. As long as this is generally set to false (which is the
+	// case by default), this should be fine.
+	// #nosec G402
+	t.TLSClientConfig = &tls.Config{InsecureSkipVerify: skipTLSVerify}
+	return t
+}
+
+// bitbucketTransport authenticates the requests to Bitbucket Cloud and Server.
+// Tokens of the form <username>:<password> are app passwords, or passwords,
+// sent with basic auth, other tokens are access tokens sent as bearer tokens.
+func bitbucketTransport(token string, t *http.Transport) http.RoundTripper {
+	if token == "" {
+		return t
+	}
+	if split := strings.SplitN(token, ":", 2); len(split) == 2 {
+		return &basicAuthClient{
+			username:  split[0],
+			password:  split[1],
+			transport: t,
+		}
+	}
+	return &oauth2.Transport{
+		Source: oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+		Base:   t,
+	}
+}
+
+// giteaClient wraps a normal http client, adding support for token auth.
+type giteaClient struct {
+	token     string
+	transport http.RoundTripper
+}
+
+// RoundTrip handles authentication for the giteaClient
+func (g *giteaClient) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.Header.Set("Authorization", "token "+g.token)
+	return g.transport.RoundTrip(r)
+}
+
+// basicAuthClient wraps a normal http client, adding support for basic auth.
+type basicAuthClient struct {
+	username  string
+	password  string
+	transport http.RoundTripper
+}
+
+// RoundTrip handles authentication for the basicAuthClient
+func (c *basicAuthClient) RoundTrip(r *http.Request) (*http.Response, error) {
+	r.SetBasicAuth(c.username, c.password)
+	return c.transport.RoundTrip(r)
+}
+
+// lastIndex returns the index of the last occurrence of s in split, or -1.
+func lastIndex(split []string, s string) int {
+	for i := len(split) - 1; i >= 0; i-- {
+		if split[i] == s {
+			return i
+		}
+	}
+	return -1
+}
+
 func guessProvider(u *url.URL) (string, error) {
 	switch {
 	case strings.Contains(u.Hostname(), "github"):
 		return "github", nil
 	case strings.Contains(u.Hostname(), "gitlab"):
 		return "gitlab", nil
+	case u.Hostname() == "bitbucket.org":
+		return "bitbucket-cloud", nil
+	case strings.Contains(u.Hostname(), "bitbucket"):
+		return "bitbucket-server", nil
+	case strings.Contains(u.Hostname(), "gitea"):
+		return "gitea", nil
 	}
 	return "", fmt.Errorf("unable to guess scm provider from url: %s", u)
 }
@@ -17,12 +17,16 @@ limitations under the License.
 package pullrequest
 
 import (
+	"context"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"strconv"
 	"testing"
 
 	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest"
 	"go.uber.org/zap/zaptest/observer"
 )
 
@@ -72,6 +76,45 @@ func TestNewSCMHandler(t *testing.T) {
 			wantNum:       3,
 			wantErr:       false,
 		},
+		{
+			name:          "bitbucket cloud",
+			raw:           "https://bitbucket.org/foo/bar/pull-requests/4",
+			skipTLSVerify: false,
+			wantBaseURL:   "https://api.bitbucket.org/",
+			wantRepo:      "foo/bar",
+			wantNum:       4,
+			wantErr:       false,
+		},
+		{
+			name:          "bitbucket server",
+			raw:           "https://bitbucket.tekton.dev/projects/FOO/repos/bar/pull-requests/5/overview",
+			skipTLSVerify: true,
+			wantBaseURL:   "https://bitbucket.tekton.dev/",
+			wantRepo:      "FOO/bar",
+			wantNum:       5,
+			wantErr:       false,
+		},
+		{
+			name:          "bitbucket server personal repository with context path",
+			raw:           "https://bitbucket.tekton.dev/bitbucket/users/foo/repos/bar/pull-requests/6",
+			skipTLSVerify: false,
+			wantBaseURL:   "https://bitbucket.tekton.dev/bitbucket/",
+			wantRepo:      "~foo/bar",
+			wantNum:       6,
+			wantErr:       false,
+		},
+		{
+			name:          "invalid bitbucket server",
+			raw:           "https://bitbucket.tekton.dev/foo/bar/pull-requests/5",
+			skipTLSVerify: false,
+			wantErr:       true,
+		},
+		{
+			name:          "gitea with insecure-skip-tls-verify",
+			raw:           "https://gitea.tekton.dev/foo/bar/pulls/7",
+			skipTLSVerify: true,
+			wantErr:       true,
+		},
 		{
 			name:          "unsupported",
 			raw:           "https://unsupported.com/foo/baz/merge_requests/3",
@@ -90,6 +133,9 @@ func TestNewSCMHandler(t *testing.T) {
 				}
 				return
 			}
+			if tt.wantErr {
+				t.Fatalf("NewSCMHandler() expected error, got none")
+			}
 			if got.prNum != tt.wantNum {
 				t.Errorf("NewSCMHandler() [pr num] = %v, want %v", got, tt.wantNum)
 			}
@@ -138,6 +184,21 @@ func TestGuessProvider(t *testing.T) {
 			url:  "https://gitlab.foo.com/foo/bar",
 			want: "gitlab",
 		},
+		{
+			name: "bitbucket cloud",
+			url:  "https://bitbucket.org/foo/bar",
+			want: "bitbucket-cloud",
+		},
+		{
+			name: "bitbucket server",
+			url:  "https://bitbucket.foo.com/projects/FOO/repos/bar",
+			want: "bitbucket-server",
+		},
+		{
+			name: "gitea",
+			url:  "https://gitea.foo.com/foo/bar",
+			want: "gitea",
+		},
 		{
 			name:    "err",
 			url:     "https://foo.com/foo/bar",
@@ -166,3 +227,123 @@ func TestGuessProvider(t *testing.T) {
 		})
 	}
 }
+
+// giteaPR is a pull request returned by the Gitea API, the driver requires
+// its repositories, author and timestamps to be set.
+const giteaPR = `{
+	"number": 1,
+	"title": "Add a feature",
+	"user": {"login": "foo"},
+	"head": {"ref": "feature", "sha": "sha1", "repo": {"name": "bar", "full_name": "foo/bar", "owner": {"login": "foo"}}},
+	"base": {"ref": "main", "sha": "sha2", "repo": {"name": "bar", "full_name": "foo/bar", "owner": {"login": "foo"}}},
+	"created_at": "2022-08-01T00:00:00Z",
+	"updated_at": "2022-08-01T00:00:00Z"
+}`
+
+// bitbucketCloudPR is a pull request returned by the Bitbucket Cloud API, the
+// driver follows its diffstat link.
+const bitbucketCloudPR = `{
+	"id": 1,
+	"title": "Add a feature",
+	"source": {"commit": {"hash": "sha1"}, "branch": {"name": "feature"}},
+	"destination": {"commit": {"hash": "sha2"}, "branch": {"name": "main"}},
+	"links": {"diffstat": {"href": "2.0/repositories/foo/bar/pullrequests/1/diffstat"}}
+}`
+
+func TestSCMHandlerAuth(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		path     string
+		token    string
+		// responses of the fake SCM server, keyed by request path.
+		responses map[string]string
+		wantAuth  string
+	}{
+		{
+			name:     "gitea",
+			provider: "gitea",
+			path:     "/foo/bar/pulls/1",
+			token:    "gitea-token",
+			responses: map[string]string{
+				"/api/v1/version":                             `{"version":"1.17.0"}`,
+				"/api/v1/repos/foo/bar/pulls/1":               giteaPR,
+				"/api/v1/repos/foo/bar/commits/sha1/statuses": `[]`,
+				"/api/v1/repos/foo/bar/issues/1/comments":     `[]`,
+				"/api/v1/repos/foo/bar/issues/1/labels":       `[]`,
+			},
+			wantAuth: "token gitea-token",
+		},
+		{
+			name:     "bitbucket cloud access token",
+			provider: "bitbucket-cloud",
+			path:     "/foo/bar/pull-requests/1",
+			token:    "access-token",
+			responses: map[string]string{
+				"/2.0/repositories/foo/bar/pullrequests/1":          bitbucketCloudPR,
+				"/2.0/repositories/foo/bar/commit/sha1/statuses":    `{"values":[]}`,
+				"/2.0/repositories/foo/bar/pullrequests/1/comments": `{"values":[]}`,
+				"/2.0/repositories/foo/bar/pullrequests/1/diffstat": `{"values":[]}`,
+			},
+			wantAuth: "Bearer access-token",
+		},
+		{
+			name:     "bitbucket cloud app password",
+			provider: "bitbucket-cloud",
+			path:     "/foo/bar/pull-requests/1",
+			token:    "user:app-password",
+			responses: map[string]string{
+				"/2.0/repositories/foo/bar/pullrequests/1":          bitbucketCloudPR,
+				"/2.0/repositories/foo/bar/commit/sha1/statuses":    `{"values":[]}`,
+				"/2.0/repositories/foo/bar/pullrequests/1/comments": `{"values":[]}`,
+				"/2.0/repositories/foo/bar/pullrequests/1/diffstat": `{"values":[]}`,
+			},
+			wantAuth: "Basic dXNlcjphcHAtcGFzc3dvcmQ=",
+		},
+		{
+			name:     "bitbucket server",
+			provider: "bitbucket-server",
+			path:     "/projects/FOO/repos/bar/pull-requests/1",
+			token:    "http-access-token",
+			responses: map[string]string{
+				"/rest/api/1.0/projects/FOO/repos/bar/pull-requests/1":            `{"id":1,"fromRef":{"latestCommit":"sha1"}}`,
+				"/rest/build-status/1.0/commits/sha1":                             `{"values":[],"isLastPage":true}`,
+				"/rest/api/1.0/projects/FOO/repos/bar/pull-requests/1/activities": `{"values":[],"isLastPage":true}`,
+			},
+			wantAuth: "Bearer http-access-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if auth := r.Header.Get("Authorization"); auth != tt.wantAuth {
+					t.Errorf("%s %s: Authorization header = %q, want %q", r.Method, r.URL.Path, auth, tt.wantAuth)
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+				body, ok := tt.responses[r.URL.Path]
+				if !ok {
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				w.Header().Set("Content-Type", "application/json")
+				fmt.Fprint(w, body)
+			}))
+			defer server.Close()
+
+			logger := zaptest.NewLogger(t).Sugar()
+			h, err := NewSCMHandler(logger, server.URL+tt.path, tt.provider, tt.token, false)
+			if err != nil {
+				t.Fatalf("NewSCMHandler() = %v", err)
+			}
+			r, err := h.Download(context.Background())
+			if err != nil {
+				t.Fatalf("Download() = %v", err)
+			}
+			if r.PR.Number != 1 || r.PR.Sha != "sha1" {
+				t.Errorf("Download() PR number %d and sha %q, want 1 and %q", r.PR.Number, r.PR.Sha, "sha1")
+			}
+		})
+	}
+}
@@ -120,6 +120,30 @@ func GetCandidateTasks(g *Graph, doneTasks ...string) (sets.String, error) {
 	return d, nil
 }
 
+// DependsOn returns true if the Task with the given key can only run after the Task with the
+// ancestor key, that is if the ancestor can be reached by following the previous Task Nodes.
+func DependsOn(g *Graph, key string, ancestor string) bool {
+	n, ok := g.Nodes[key]
+	if !ok {
+		return false
+	}
+	visited := sets.NewString()
+	toVisit := append([]*Node{}, n.Prev...)
+	for len(toVisit) > 0 {
+		prev := toVisit[0]
+		toVisit = toVisit[1:]
+		if prev.Task.HashKey() == ancestor {
+			return true
+		}
+		if visited.Has(prev.Task.HashKey()) {
+			continue
+		}
+		visited.Insert(prev.Task.HashKey())
+		toVisit = append(toVisit, prev.Prev...)
+	}
+	return false
+}
+
 func linkPipelineTasks(prev *Node, next *Node) error {
 	// Check for self cycle
 	if prev.Task.HashKey() == next.Task.HashKey() {
@@ -376,6 +376,85 @@ func TestBuild_TaskParamsFromTaskResults(t *testing.T) {
 	assertSameDAG(t, expectedDAG, g)
 }
 
+func TestBuild_WorkspaceFrom(t *testing.T) {
+	a := v1beta1.PipelineTask{
+		Name:       "a",
+		Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{Name: "source", Workspace: "shared"}},
+	}
+	b := v1beta1.PipelineTask{Name: "b"}
+	xConsumesA := v1beta1.PipelineTask{
+		Name: "x",
+		Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+			Name:      "source",
+			Workspace: "shared",
+			From:      []string{"a"},
+		}},
+	}
+	yConsumesXRunsAfterB := v1beta1.PipelineTask{
+		Name:     "y",
+		RunAfter: []string{"b"},
+		Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+			Name:      "output",
+			Workspace: "shared",
+			From:      []string{"x"},
+		}},
+	}
+
+	//   a
+	//   |
+	//   x  b
+	//    \ /
+	//     y
+	nodeA := &dag.Node{Task: a}
+	nodeB := &dag.Node{Task: b}
+	nodeX := &dag.Node{Task: xConsumesA}
+	nodeY := &dag.Node{Task: yConsumesXRunsAfterB}
+
+	nodeA.Next = []*dag.Node{nodeX}
+	nodeB.Next = []*dag.Node{nodeY}
+	nodeX.Next = []*dag.Node{nodeY}
+	nodeX.Prev = []*dag.Node{nodeA}
+	nodeY.Prev = []*dag.Node{nodeX, nodeB}
+
+	expectedDAG := &dag.Graph{
+		Nodes: map[string]*dag.Node{
+			"a": nodeA,
+			"b": nodeB,
+			"x": nodeX,
+			"y": nodeY,
+		},
+	}
+	tasks := v1beta1.PipelineTaskList{a, b, xConsumesA, yConsumesXRunsAfterB}
+	g, err := dag.Build(tasks, tasks.Deps())
+	if err != nil {
+		t.Fatalf("didn't expect error creating valid Pipeline but got %v", err)
+	}
+	assertSameDAG(t, expectedDAG, g)
+}
+
+func TestDependsOn(t *testing.T) {
+	g := testGraph(t)
+	for _, tc := range []struct {
+		key      string
+		ancestor string
+		want     bool
+	}{
+		{key: "x", ancestor: "a", want: true},
+		{key: "w", ancestor: "a", want: true},
+		{key: "w", ancestor: "b", want: true},
+		{key: "z", ancestor: "a", want: true},
+		{key: "a", ancestor: "x", want: false},
+		{key: "z", ancestor: "y", want: false},
+		{key: "x", ancestor: "b", want: false},
+		{key: "a", ancestor: "a", want: false},
+		{key: "missing", ancestor: "a", want: false},
+	} {
+		if got := dag.DependsOn(g, tc.key, tc.ancestor); got != tc.want {
+			t.Errorf("DependsOn(%q, %q) = %t, want %t", tc.key, tc.ancestor, got, tc.want)
+		}
+	}
+}
+
 func TestBuild_InvalidDAG(t *testing.T) {
 	a := v1beta1.PipelineTask{Name: "a"}
 	xDependsOnA := v1beta1.PipelineTask{
@@ -33,6 +33,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"knative.dev/pkg/kmeta"
 	"knative.dev/pkg/logging"
 )
@@ -43,44 +44,78 @@ const (
 	ReasonCouldntCreateAffinityAssistantStatefulSet = "CouldntCreateAffinityAssistantStatefulSet"
 
 	featureFlagDisableAffinityAssistantKey = "disable-affinity-assistant"
+	featureFlagCoscheduleKey               = "coschedule"
 )
 
 // createAffinityAssistants creates an Affinity Assistant StatefulSet for every workspace in the PipelineRun that
 // use a PersistentVolumeClaim volume. This is done to achieve Node Affinity for all TaskRuns that
 // share the workspace volume and make it possible for the tasks to execute parallel while sharing volume.
+// When coscheduling PipelineRuns, a single Affinity Assistant StatefulSet mounting all the PersistentVolumeClaims
+// is created for the PipelineRun instead, so that a TaskRun can bind several of them. This mode is recorded in
+// an annotation of the PipelineRun, propagated to its TaskRuns.
 func (c *Reconciler) createAffinityAssistants(ctx context.Context, wb []v1beta1.WorkspaceBinding, pr *v1beta1.PipelineRun, namespace string) error {
-	logger := logging.FromContext(ctx)
-	cfg := config.FromContextOrDefaults(ctx)
+	ownerReference := *kmeta.NewControllerRef(pr)
+	if c.isCoschedulingPipelineRuns(ctx) {
+		claimNames := sets.NewString()
+		for _, w := range wb {
+			if usesPersistentVolumeClaim(w) {
+				claimNames.Insert(getClaimName(w, ownerReference))
+			}
+		}
+		if claimNames.Len() == 0 {
+			return nil
+		}
+		if pr.Annotations == nil {
+			pr.Annotations = map[string]string{}
+		}
+		pr.Annotations[workspace.AnnotationCoschedule] = config.CoschedulePipelineRuns
+		return c.createAffinityAssistant(ctx, getAffinityAssistantName("", pr.Name), pr, claimNames.List(), namespace)
+	}
 
 	var errs []error
 	for _, w := range wb {
-		if w.PersistentVolumeClaim != nil || w.VolumeClaimTemplate != nil {
+		if usesPersistentVolumeClaim(w) {
 			affinityAssistantName := getAffinityAssistantName(w.Name, pr.Name)
-			_, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Get(ctx, affinityAssistantName, metav1.GetOptions{})
-			claimName := getClaimName(w, *kmeta.NewControllerRef(pr))
-			switch {
-			case apierrors.IsNotFound(err):
-				affinityAssistantStatefulSet := affinityAssistantStatefulSet(affinityAssistantName, pr, claimName, c.Images.NopImage, cfg.Defaults.DefaultAAPodTemplate)
-				_, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Create(ctx, affinityAssistantStatefulSet, metav1.CreateOptions{})
-				if err != nil {
-					errs = append(errs, fmt.Errorf("failed to create StatefulSet %s: %s", affinityAssistantName, err))
-				}
-				if err == nil {
-					logger.Infof("Created StatefulSet %s in namespace %s", affinityAssistantName, namespace)
-				}
-			case err != nil:
-				errs = append(errs, fmt.Errorf("failed to retrieve StatefulSet %s: %s", affinityAssistantName, err))
+			if err := c.createAffinityAssistant(ctx, affinityAssistantName, pr, []string{getClaimName(w, ownerReference)}, namespace); err != nil {
+				errs = append(errs, err)
 			}
 		}
 	}
 	return errorutils.NewAggregate(errs)
 }
 
+// createAffinityAssistant creates the Affinity Assistant StatefulSet with the given name, mounting the given
+// PersistentVolumeClaims, unless it already exists.
+func (c *Reconciler) createAffinityAssistant(ctx context.Context, affinityAssistantName string, pr *v1beta1.PipelineRun, claimNames []string, namespace string) error {
+	logger := logging.FromContext(ctx)
+	cfg := config.FromContextOrDefaults(ctx)
+
+	_, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Get(ctx, affinityAssistantName, metav1.GetOptions{})
+	switch {
+	case apierrors.IsNotFound(err):
+		affinityAssistantStatefulSet := affinityAssistantStatefulSet(affinityAssistantName, pr, claimNames, c.Images.NopImage, cfg.Defaults.DefaultAAPodTemplate)
+		if _, err := c.KubeClientSet.AppsV1().StatefulSets(namespace).Create(ctx, affinityAssistantStatefulSet, metav1.CreateOptions{}); err != nil {
+			return fmt.Errorf("failed to create StatefulSet %s: %s", affinityAssistantName, err)
+		}
+		logger.Infof("Created StatefulSet %s in namespace %s", affinityAssistantName, namespace)
+	case err != nil:
+		return fmt.Errorf("failed to retrieve StatefulSet %s: %s", affinityAssistantName, err)
+	}
+	return nil
+}
+
+// usesPersistentVolumeClaim returns true if the workspace binding is backed by a PersistentVolumeClaim.
+func usesPersistentVolumeClaim(w v1beta1.WorkspaceBinding) bool {
+	return w.PersistentVolumeClaim != nil || w.VolumeClaimTemplate != nil || w.Cache != nil
+}
+
 func getClaimName(w v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference) string {
 	if w.PersistentVolumeClaim != nil {
 		return w.PersistentVolumeClaim.ClaimName
 	} else if w.VolumeClaimTemplate != nil {
 		return volumeclaim.GetPersistentVolumeClaimName(w.VolumeClaimTemplate, w, ownerReference)
+	} else if w.Cache != nil {
+		return volumeclaim.GetCachePersistentVolumeClaimName(w.Cache)
 	}
 
 	return ""
@@ -93,18 +128,47 @@ func (c *Reconciler) cleanupAffinityAssistants(ctx context.Context, pr *v1beta1.
 		return nil
 	}
 
-	var errs []error
+	affinityAssistantStsNames := sets.NewString()
 	for _, w := range pr.Spec.Workspaces {
-		if w.PersistentVolumeClaim != nil || w.VolumeClaimTemplate != nil {
-			affinityAssistantStsName := getAffinityAssistantName(w.Name, pr.Name)
-			if err := c.KubeClientSet.AppsV1().StatefulSets(pr.Namespace).Delete(ctx, affinityAssistantStsName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
-				errs = append(errs, fmt.Errorf("failed to delete StatefulSet %s: %s", affinityAssistantStsName, err))
+		if usesPersistentVolumeClaim(w) {
+			if isCoschedulingPipelineRun(pr) {
+				affinityAssistantStsNames.Insert(getAffinityAssistantName("", pr.Name))
+			} else {
+				affinityAssistantStsNames.Insert(getAffinityAssistantName(w.Name, pr.Name))
 			}
 		}
 	}
+
+	var errs []error
+	for _, affinityAssistantStsName := range affinityAssistantStsNames.List() {
+		if err := c.KubeClientSet.AppsV1().StatefulSets(pr.Namespace).Delete(ctx, affinityAssistantStsName, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete StatefulSet %s: %s", affinityAssistantStsName, err))
+		}
+	}
 	return errorutils.NewAggregate(errs)
 }
 
+// getTaskRunAffinityAssistantName returns the name of the Affinity Assistant the TaskRuns and Runs of a
+// PipelineTask binding the given PersistentVolumeClaim workspace of the PipelineRun should be scheduled with,
+// or an empty string if they don't need an Affinity Assistant.
+func (c *Reconciler) getTaskRunAffinityAssistantName(ctx context.Context, pr *v1beta1.PipelineRun, pipelinePVCWorkspaceName string) string {
+	if c.isAffinityAssistantDisabled(ctx) {
+		return ""
+	}
+	if isCoschedulingPipelineRun(pr) {
+		for _, w := range pr.Spec.Workspaces {
+			if usesPersistentVolumeClaim(w) {
+				return getAffinityAssistantName("", pr.Name)
+			}
+		}
+		return ""
+	}
+	if pipelinePVCWorkspaceName == "" {
+		return ""
+	}
+	return getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
+}
+
 func getAffinityAssistantName(pipelineWorkspaceName string, pipelineRunName string) string {
 	hashBytes := sha256.Sum256([]byte(pipelineWorkspaceName + pipelineRunName))
 	hashString := fmt.Sprintf("%x", hashBytes)
@@ -126,7 +190,7 @@ func getStatefulSetLabels(pr *v1beta1.PipelineRun, affinityAssistantName string)
 	return labels
 }
 
-func affinityAssistantStatefulSet(name string, pr *v1beta1.PipelineRun, claimName string, affinityAssistantImage string, defaultAATpl *pod.AffinityAssistantTemplate) *appsv1.StatefulSet {
+func affinityAssistantStatefulSet(name string, pr *v1beta1.PipelineRun, claimNames []string, affinityAssistantImage string, defaultAATpl *pod.AffinityAssistantTemplate) *appsv1.StatefulSet {
 	// We want a singleton pod
 	replicas := int32(1)
 
@@ -169,6 +233,26 @@ func affinityAssistantStatefulSet(name string, pr *v1beta1.PipelineRun, claimNam
 		},
 	}
 
+	var volumes []corev1.Volume
+	for i, claimName := range claimNames {
+		volumes = append(volumes, corev1.Volume{
+			Name: fmt.Sprintf("workspace-%d", i),
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+
+					// A Pod mounting a PersistentVolumeClaim that has a StorageClass with
+					// volumeBindingMode: Immediate
+					// the PV is allocated on a Node first, and then the pod need to be
+					// scheduled to that node.
+					// To support those PVCs, the Affinity Assistant must also mount the
+					// same PersistentVolumeClaim - to be sure that the Affinity Assistant
+					// pod is scheduled to the same Availability Zone as the PV, when using
+					// a regional cluster. This is called VolumeScheduling.
+					ClaimName: claimName,
+				}},
+		})
+	}
+
 	return &appsv1.StatefulSet{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "StatefulSet",
@@ -200,22 +284,7 @@ func affinityAssistantStatefulSet(name string, pr *v1beta1.PipelineRun, claimNam
 							PreferredDuringSchedulingIgnoredDuringExecution: []corev1.WeightedPodAffinityTerm{repelOtherAffinityAssistantsPodAffinityTerm},
 						},
 					},
-					Volumes: []corev1.Volume{{
-						Name: "workspace",
-						VolumeSource: corev1.VolumeSource{
-							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
-
-								// A Pod mounting a PersistentVolumeClaim that has a StorageClass with
-								// volumeBindingMode: Immediate
-								// the PV is allocated on a Node first, and then the pod need to be
-								// scheduled to that node.
-								// To support those PVCs, the Affinity Assistant must also mount the
-								// same PersistentVolumeClaim - to be sure that the Affinity Assistant
-								// pod is scheduled to the same Availability Zone as the PV, when using
-								// a regional cluster. This is called VolumeScheduling.
-								ClaimName: claimName,
-							}},
-					}},
+					Volumes: volumes,
 				},
 			},
 		},
@@ -230,3 +299,17 @@ func (c *Reconciler) isAffinityAssistantDisabled(ctx context.Context) bool {
 	cfg := config.FromContextOrDefaults(ctx)
 	return cfg.FeatureFlags.DisableAffinityAssistant
 }
+
+// isCoschedulingPipelineRuns returns a bool indicating whether a single Affinity Assistant should be created
+// for each PipelineRun, scheduling all its TaskRun pods to the same Node, instead of one for each of its
+// PersistentVolumeClaim workspaces. This mode is in alpha.
+func (c *Reconciler) isCoschedulingPipelineRuns(ctx context.Context) bool {
+	cfg := config.FromContextOrDefaults(ctx)
+	return cfg.FeatureFlags.EnableAPIFields == config.AlphaAPIFields && cfg.FeatureFlags.Coschedule == config.CoschedulePipelineRuns
+}
+
+// isCoschedulingPipelineRun returns a bool indicating whether the single Affinity Assistant of the PipelineRun
+// was created for it, according to the mode recorded by createAffinityAssistants.
+func isCoschedulingPipelineRun(pr *v1beta1.PipelineRun) bool {
+	return pr.Annotations[workspace.AnnotationCoschedule] == config.CoschedulePipelineRuns
+}
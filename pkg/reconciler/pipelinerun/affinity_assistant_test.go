@@ -20,10 +20,13 @@ import (
 	"context"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/workspace"
+	"github.com/tektoncd/pipeline/test/diff"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -85,6 +88,170 @@ func TestCreateAndDeleteOfAffinityAssistant(t *testing.T) {
 	}
 }
 
+// TestCreateAndDeleteOfAffinityAssistantPerPipelineRun tests to create and delete the single Affinity Assistant
+// of a PipelineRun with several PVC workspaces when coscheduling PipelineRuns, even if the feature flag
+// changed meanwhile
+func TestCreateAndDeleteOfAffinityAssistantPerPipelineRun(t *testing.T) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"enable-api-fields":      config.AlphaAPIFields,
+			featureFlagCoscheduleKey: config.CoschedulePipelineRuns,
+		},
+	}
+	store := config.NewStore(logtesting.TestLogger(t))
+	store.OnConfigChanged(configMap)
+	ctx, cancel := context.WithCancel(store.ToContext(context.Background()))
+	defer cancel()
+
+	c := Reconciler{
+		KubeClientSet: fakek8s.NewSimpleClientset(),
+		Images:        pipeline.Images{},
+	}
+
+	testPipelineRun := &v1beta1.PipelineRun{
+		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pipelinerun-1",
+		},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name: "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "source-claim",
+				},
+			}, {
+				Name: "cache",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "cache-claim",
+				},
+			}, {
+				Name:     "scratch",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}
+
+	if err := c.createAffinityAssistants(ctx, testPipelineRun.Spec.Workspaces, testPipelineRun, testPipelineRun.Namespace); err != nil {
+		t.Errorf("unexpected error from createAffinityAssistants: %v", err)
+	}
+
+	stsList, err := c.KubeClientSet.AppsV1().StatefulSets(testPipelineRun.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing StatefulSets: %v", err)
+	}
+	if len(stsList.Items) != 1 {
+		t.Fatalf("expected a single StatefulSet for the PipelineRun, got %d", len(stsList.Items))
+	}
+	sts := stsList.Items[0]
+	if expectedName := getAffinityAssistantName("", testPipelineRun.Name); sts.Name != expectedName {
+		t.Errorf("expected StatefulSet %s, got %s", expectedName, sts.Name)
+	}
+	var claimNames []string
+	for _, v := range sts.Spec.Template.Spec.Volumes {
+		claimNames = append(claimNames, v.PersistentVolumeClaim.ClaimName)
+	}
+	if d := cmp.Diff([]string{"cache-claim", "source-claim"}, claimNames); d != "" {
+		t.Errorf("StatefulSet claims diff %s", diff.PrintWantGot(d))
+	}
+	if got := testPipelineRun.Annotations[workspace.AnnotationCoschedule]; got != config.CoschedulePipelineRuns {
+		t.Errorf("expected the PipelineRun to be annotated with coschedule mode %q, got %q", config.CoschedulePipelineRuns, got)
+	}
+
+	store.OnConfigChanged(&corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			featureFlagCoscheduleKey: config.CoscheduleWorkspaces,
+		},
+	})
+	ctx = store.ToContext(ctx)
+	if err := c.cleanupAffinityAssistants(ctx, testPipelineRun); err != nil {
+		t.Errorf("unexpected error from cleanupAffinityAssistants: %v", err)
+	}
+
+	_, err = c.KubeClientSet.AppsV1().StatefulSets(testPipelineRun.Namespace).Get(ctx, sts.Name, metav1.GetOptions{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("expected a NotFound response, got: %v", err)
+	}
+}
+
+func TestGetTaskRunAffinityAssistantName(t *testing.T) {
+	pvcPipelineRun := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1"},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name: "source",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: "source-claim",
+				},
+			}},
+		},
+	}
+	coschedulePipelineRun := pvcPipelineRun.DeepCopy()
+	coschedulePipelineRun.Annotations = map[string]string{workspace.AnnotationCoschedule: config.CoschedulePipelineRuns}
+	emptyDirPipelineRun := &v1beta1.PipelineRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "pipelinerun-1"},
+		Spec: v1beta1.PipelineRunSpec{
+			Workspaces: []v1beta1.WorkspaceBinding{{
+				Name:     "source",
+				EmptyDir: &corev1.EmptyDirVolumeSource{},
+			}},
+		},
+	}
+	for _, tc := range []struct {
+		description              string
+		featureFlags             map[string]string
+		pr                       *v1beta1.PipelineRun
+		pipelinePVCWorkspaceName string
+		expected                 string
+	}{{
+		description:              "assistant per workspace for a task binding a PVC",
+		pr:                       pvcPipelineRun,
+		pipelinePVCWorkspaceName: "source",
+		expected:                 getAffinityAssistantName("source", "pipelinerun-1"),
+	}, {
+		description: "no assistant per workspace for a task without PVC",
+		pr:          pvcPipelineRun,
+		expected:    "",
+	}, {
+		description: "assistant per pipelinerun for a task without PVC",
+		pr:          coschedulePipelineRun,
+		expected:    getAffinityAssistantName("", "pipelinerun-1"),
+	}, {
+		description:  "assistant per pipelinerun recorded on the pipelinerun when the flag changed",
+		featureFlags: map[string]string{featureFlagCoscheduleKey: config.CoscheduleWorkspaces},
+		pr:           coschedulePipelineRun,
+		expected:     getAffinityAssistantName("", "pipelinerun-1"),
+	}, {
+		description: "no assistant per pipelinerun for a pipelinerun without PVC",
+		pr:          emptyDirPipelineRun,
+		expected:    "",
+	}, {
+		description: "no assistant when disabled",
+		featureFlags: map[string]string{
+			featureFlagDisableAffinityAssistantKey: "true",
+		},
+		pr:                       coschedulePipelineRun,
+		pipelinePVCWorkspaceName: "source",
+		expected:                 "",
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			c := Reconciler{
+				KubeClientSet: fakek8s.NewSimpleClientset(),
+				Images:        pipeline.Images{},
+			}
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       tc.featureFlags,
+			})
+			if got := c.getTaskRunAffinityAssistantName(store.ToContext(context.Background()), tc.pr, tc.pipelinePVCWorkspaceName); got != tc.expected {
+				t.Errorf("expected affinity assistant %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
 func TestPipelineRunPodTemplatesArePropagatedToAffinityAssistant(t *testing.T) {
 	prWithCustomPodTemplate := &v1beta1.PipelineRun{
 		TypeMeta: metav1.TypeMeta{Kind: "PipelineRun"},
@@ -109,7 +276,7 @@ func TestPipelineRunPodTemplatesArePropagatedToAffinityAssistant(t *testing.T) {
 		},
 	}
 
-	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, "mypvc", "nginx", nil)
+	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, []string{"mypvc"}, "nginx", nil)
 
 	if len(stsWithTolerationsAndNodeSelector.Spec.Template.Spec.Tolerations) != 1 {
 		t.Errorf("expected Tolerations in the StatefulSet")
@@ -147,7 +314,7 @@ func TestDefaultPodTemplatesArePropagatedToAffinityAssistant(t *testing.T) {
 		}},
 	}
 
-	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, "mypvc", "nginx", defaultTpl)
+	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, []string{"mypvc"}, "nginx", defaultTpl)
 
 	if len(stsWithTolerationsAndNodeSelector.Spec.Template.Spec.Tolerations) != 1 {
 		t.Errorf("expected Tolerations in the StatefulSet")
@@ -193,7 +360,7 @@ func TestMergedPodTemplatesArePropagatedToAffinityAssistant(t *testing.T) {
 		}},
 	}
 
-	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, "mypvc", "nginx", defaultTpl)
+	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, []string{"mypvc"}, "nginx", defaultTpl)
 
 	if len(stsWithTolerationsAndNodeSelector.Spec.Template.Spec.Tolerations) != 1 {
 		t.Errorf("expected Tolerations from spec in the StatefulSet")
@@ -230,7 +397,7 @@ func TestOnlySelectPodTemplateFieldsArePropagatedToAffinityAssistant(t *testing.
 		},
 	}
 
-	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, "mypvc", "nginx", nil)
+	stsWithTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithCustomPodTemplate, []string{"mypvc"}, "nginx", nil)
 
 	if len(stsWithTolerationsAndNodeSelector.Spec.Template.Spec.Tolerations) != 1 {
 		t.Errorf("expected Tolerations from spec in the StatefulSet")
@@ -250,7 +417,7 @@ func TestThatTheAffinityAssistantIsWithoutNodeSelectorAndTolerations(t *testing.
 		Spec: v1beta1.PipelineRunSpec{},
 	}
 
-	stsWithoutTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithoutCustomPodTemplate, "mypvc", "nginx", nil)
+	stsWithoutTolerationsAndNodeSelector := affinityAssistantStatefulSet("test-assistant", prWithoutCustomPodTemplate, []string{"mypvc"}, "nginx", nil)
 
 	if len(stsWithoutTolerationsAndNodeSelector.Spec.Template.Spec.Tolerations) != 0 {
 		t.Errorf("unexpected Tolerations in the StatefulSet")
@@ -367,3 +534,40 @@ func TestDisableAffinityAssistant(t *testing.T) {
 		})
 	}
 }
+
+func TestIsCoschedulingPipelineRuns(t *testing.T) {
+	for _, tc := range []struct {
+		description string
+		data        map[string]string
+		expected    bool
+	}{{
+		description: "Default behaviour: A missing coschedule flag should result in false",
+		data:        map[string]string{},
+		expected:    false,
+	}, {
+		description: "Setting coschedule to pipelineruns without alpha API fields should result in false",
+		data: map[string]string{
+			featureFlagCoscheduleKey: config.CoschedulePipelineRuns,
+		},
+		expected: false,
+	}, {
+		description: "Setting coschedule to pipelineruns with alpha API fields should result in true",
+		data: map[string]string{
+			"enable-api-fields":      config.AlphaAPIFields,
+			featureFlagCoscheduleKey: config.CoschedulePipelineRuns,
+		},
+		expected: true,
+	}} {
+		t.Run(tc.description, func(t *testing.T) {
+			c := Reconciler{}
+			store := config.NewStore(logtesting.TestLogger(t))
+			store.OnConfigChanged(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Name: config.GetFeatureFlagsConfigName(), Namespace: system.Namespace()},
+				Data:       tc.data,
+			})
+			if result := c.isCoschedulingPipelineRuns(store.ToContext(context.Background())); result != tc.expected {
+				t.Errorf("Expected %t Received %t", tc.expected, result)
+			}
+		})
+	}
+}
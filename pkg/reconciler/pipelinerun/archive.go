@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/archive"
+)
+
+// archivePipelineRun writes the record of the finished PipelineRun to the storage configured
+// in config-archive, unless archiving is disabled or the record was already archived.
+// The outcome is recorded in the annotations of the PipelineRun.
+func (c *Reconciler) archivePipelineRun(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	cfg := config.FromContextOrDefaults(ctx).Archive
+	if !cfg.Enabled() || archive.IsArchived(pr) {
+		return nil
+	}
+	a, err := archive.New(ctx, c.KubeClientSet, cfg)
+	if err != nil {
+		return err
+	}
+	return archive.Run(ctx, a, pr, v1beta1.SchemeGroupVersion.WithKind("PipelineRun"))
+}
+
+// deletionBlockedByArchive returns true if the finished PipelineRun can't be deleted yet
+// because its record still has to be archived.
+func deletionBlockedByArchive(ctx context.Context, pr *v1beta1.PipelineRun) bool {
+	return config.FromContextOrDefaults(ctx).Archive.Enabled() && !archive.IsArchived(pr)
+}
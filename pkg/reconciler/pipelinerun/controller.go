@@ -18,6 +18,7 @@ package pipelinerun
 
 import (
 	"context"
+	"time"
 
 	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
@@ -31,17 +32,29 @@ import (
 	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	resolutionclient "github.com/tektoncd/resolution/pkg/client/injection/client"
 	resolutioninformer "github.com/tektoncd/resolution/pkg/client/injection/informers/resolution/v1alpha1/resolutionrequest"
 	resolution "github.com/tektoncd/resolution/pkg/resource"
+	"go.uber.org/zap"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
+	pvcinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim"
+	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
 )
 
+// cachePruneInterval is how often the PVCs of the cache workspaces of every namespace are pruned,
+// in addition to each time a run uses a cache.
+const cachePruneInterval = 10 * time.Minute
+
+// TracerProviderName is the name of the service reported by the PipelineRun reconciler spans
+const TracerProviderName = "pipelinerun-reconciler"
+
 // NewController instantiates a new controller.Impl from knative.dev/pkg/controller
 func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -53,22 +66,25 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		pipelineRunInformer := pipelineruninformer.Get(ctx)
 		resourceInformer := resourceinformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
-		configStore := config.NewStore(logger.Named("config-store"), pipelinerunmetrics.MetricsOnStore(logger))
+		tracerProvider := tracing.New(TracerProviderName, logger)
+		configStore := config.NewStore(logger.Named("config-store"), pipelinerunmetrics.MetricsOnStore(logger), tracerProvider.OnStore())
 		configStore.WatchConfigs(cmw)
 
 		c := &Reconciler{
-			KubeClientSet:       kubeclientset,
-			PipelineClientSet:   pipelineclientset,
-			Images:              opts.Images,
-			Clock:               clock,
-			pipelineRunLister:   pipelineRunInformer.Lister(),
-			taskRunLister:       taskRunInformer.Lister(),
-			runLister:           runInformer.Lister(),
-			resourceLister:      resourceInformer.Lister(),
-			cloudEventClient:    cloudeventclient.Get(ctx),
-			metrics:             pipelinerunmetrics.Get(ctx),
-			pvcHandler:          volumeclaim.NewPVCHandler(kubeclientset, logger),
+			KubeClientSet:     kubeclientset,
+			PipelineClientSet: pipelineclientset,
+			Images:            opts.Images,
+			Clock:             clock,
+			pipelineRunLister: pipelineRunInformer.Lister(),
+			taskRunLister:     taskRunInformer.Lister(),
+			runLister:         runInformer.Lister(),
+			resourceLister:    resourceInformer.Lister(),
+			cloudEventClient:  cloudeventclient.Get(ctx),
+			metrics:           pipelinerunmetrics.Get(ctx),
+			pvcHandler: volumeclaim.NewPVCHandler(kubeclientset, pvcinformer.Get(ctx).Lister(), filteredpodinformer.Get(ctx, v1beta1.ManagedByLabelKey).Lister(),
+				pipelineRunInformer.Lister(), taskRunInformer.Lister(), logger),
 			resolutionRequester: resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			tracerProvider:      tracerProvider,
 		}
 		impl := pipelinerunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
@@ -92,6 +108,41 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 			Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 		})
 
+		go pruneCachesPeriodically(ctx, c.pvcHandler, configStore, leaderOwns(impl), logger)
+
 		return impl
 	}
 }
+
+// cachePruneKey is the key of the namespaces whose cache PVCs are pruned by a controller
+// replica: each namespace is pruned by the replica which is the leader for this key.
+const cachePruneKey = "cache-prune"
+
+// leaderOwns returns whether the controller replica is the leader for pruning the cache
+// PVCs of a namespace, so that a single replica prunes each namespace.
+func leaderOwns(impl *controller.Impl) func(namespace string) bool {
+	leader, ok := impl.Reconciler.(interface {
+		IsLeaderFor(types.NamespacedName) bool
+	})
+	return func(namespace string) bool {
+		return !ok || leader.IsLeaderFor(types.NamespacedName{Namespace: namespace, Name: cachePruneKey})
+	}
+}
+
+// pruneCachesPeriodically prunes the PVCs of the cache workspaces every cachePruneInterval
+// until the context is done, so that the expired caches are deleted even when no run uses
+// a cache in their namespace.
+func pruneCachesPeriodically(ctx context.Context, pvcHandler volumeclaim.PvcHandler, configStore *config.Store, owns func(namespace string) bool, logger *zap.SugaredLogger) {
+	ticker := time.NewTicker(cachePruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := pvcHandler.PruneCachePersistentVolumeClaims(configStore.ToContext(ctx), owns); err != nil {
+				logger.Errorf("Failed to prune cache PersistentVolumeClaims: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"testing"
+
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/reconciler"
+)
+
+type leaderAwareReconciler struct {
+	reconciler.LeaderAwareFuncs
+}
+
+func (*leaderAwareReconciler) Reconcile(context.Context, string) error { return nil }
+
+// TestLeaderOwns tests that the cache PVCs of the namespaces are only pruned by the replica which is the leader
+// for them.
+func TestLeaderOwns(t *testing.T) {
+	r := &leaderAwareReconciler{}
+	owns := leaderOwns(&controller.Impl{Reconciler: r})
+	if owns("ns") {
+		t.Error("expected a replica which isn't the leader not to prune the namespace")
+	}
+	if err := r.Promote(reconciler.UniversalBucket(), nil); err != nil {
+		t.Fatalf("Promote() = %v", err)
+	}
+	if !owns("ns") {
+		t.Error("expected the leader to prune the namespace")
+	}
+}
@@ -52,8 +52,11 @@ import (
 	tresources "github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/remote"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	resolution "github.com/tektoncd/resolution/pkg/resource"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -119,6 +122,9 @@ const (
 	// ReasonResolvingPipelineRef indicates that the PipelineRun is waiting for
 	// its pipelineRef to be asynchronously resolved.
 	ReasonResolvingPipelineRef = "ResolvingPipelineRef"
+	// ReasonReadWriteOnceWorkspaceConflict indicates that a PipelineTask would start several TaskRuns
+	// in parallel on a ReadWriteOnce PersistentVolumeClaim while the Affinity Assistant is disabled.
+	ReasonReadWriteOnceWorkspaceConflict = "ReadWriteOnceWorkspaceConflict"
 )
 
 // Reconciler implements controller.Reconciler for Configuration resources.
@@ -137,6 +143,7 @@ type Reconciler struct {
 	metrics             *pipelinerunmetrics.Recorder
 	pvcHandler          volumeclaim.PvcHandler
 	resolutionRequester resolution.Requester
+	tracerProvider      trace.TracerProvider
 }
 
 var (
@@ -150,6 +157,11 @@ var (
 func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx, rootSpan := initTracing(ctx, c.tracerProvider, pr)
+	defer rootSpan.End()
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "PipelineRun:ReconcileKind")
+	defer span.End()
+	span.SetAttributes(attribute.String("pipelinerun", pr.Name), attribute.String("namespace", pr.Namespace))
 
 	// Read the initial condition
 	before := pr.Status.GetCondition(apis.ConditionSucceeded)
@@ -193,6 +205,12 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to delete StatefulSet for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
+		if pr.HasVolumeClaimTemplate() && shouldCleanupWorkspacePVCs(ctx, pr) {
+			if err := c.pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(ctx, pr.Spec.Workspaces, *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
+				logger.Errorf("Failed to delete workspace PVC for PipelineRun %s: %v", pr.Name, err)
+				return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+			}
+		}
 		if err := c.updateTaskRunsStatusDirectly(pr); err != nil {
 			logger.Errorf("Failed to update TaskRun status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
@@ -201,7 +219,14 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, pr *v1beta1.PipelineRun)
 			logger.Errorf("Failed to update Run status for PipelineRun %s: %v", pr.Name, err)
 			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
 		}
-		return c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil)
+		if err := c.archivePipelineRun(ctx, pr); err != nil {
+			logger.Errorf("Failed to archive PipelineRun %s: %v", pr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, pr, before, err)
+		}
+		if err := c.finishReconcileUpdateEmitEvents(ctx, pr, before, nil); err != nil {
+			return err
+		}
+		return c.garbageCollect(ctx, pr)
 	}
 
 	if err := propagatePipelineNameLabelToPipelineRun(pr); err != nil {
@@ -286,6 +311,8 @@ func (c *Reconciler) resolvePipelineState(
 	pipelineMeta *metav1.ObjectMeta,
 	pr *v1beta1.PipelineRun,
 	providedResources map[string]*resourcev1alpha1.PipelineResource) (resources.PipelineRunState, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "resolvePipelineState")
+	defer span.End()
 	pst := resources.PipelineRunState{}
 	// Resolve each task individually because they each could have a different reference context (remote or local).
 	for _, task := range tasks {
@@ -479,6 +506,10 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	pipelineSpec = resources.ApplyWorkspaces(ctx, pipelineSpec, pr)
 	// Update pipelinespec of pipelinerun's status field
 	pr.Status.PipelineSpec = pipelineSpec
+	// Substitute the params in the keys of the cache workspaces, from which the names of their PVCs
+	// are derived. This is used by the creation of the PVCs and of the TaskRuns below. Changes to the
+	// Spec are not updated.
+	pr.Spec.Workspaces = volumeclaim.ApplyCacheKeyParameters(pr.Spec.Workspaces, pr.Spec.Params, pipelineSpec.Params)
 
 	// pipelineState holds a list of pipeline tasks after resolving pipeline resources
 	// pipelineState also holds a taskRun for each pipeline task after the taskRun is created
@@ -549,6 +580,13 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 		}
 
 		if pr.HasVolumeClaimTemplate() {
+			if err := volumeclaim.ValidateVolumeClaimTemplates(ctx, pr.Spec.Workspaces, pr.Namespace); err != nil {
+				logger.Errorf("PipelineRun %s workspaces are not allowed: %v", pr.Name, err)
+				pr.Status.MarkFailed(volumeclaim.ReasonVolumeClaimTemplateNotAllowed,
+					"PipelineRun %s/%s workspaces are not allowed: %s",
+					pr.Namespace, pr.Name, err)
+				return controller.NewPermanentError(err)
+			}
 			// create workspace PVC from template
 			if err = c.pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, pr.Spec.Workspaces, *kmeta.NewControllerRef(pr), pr.Namespace); err != nil {
 				logger.Errorf("Failed to create PVC for PipelineRun %s: %v", pr.Name, err)
@@ -611,6 +649,7 @@ func (c *Reconciler) reconcile(ctx context.Context, pr *v1beta1.PipelineRun, get
 	}
 
 	pr.Status.SkippedTasks = pipelineRunFacts.GetSkippedTasks()
+	pr.Status.ResourceUsage = pipelineRunFacts.State.GetResourceUsage()
 	if after.Status == corev1.ConditionTrue || after.Status == corev1.ConditionFalse {
 		pr.Status.PipelineResults, err = resources.ApplyTaskResultsToPipelineResults(pipelineSpec.Results,
 			pipelineRunFacts.State.GetTaskRunsResults(), pipelineRunFacts.State.GetRunsResults())
@@ -655,6 +694,8 @@ func (c *Reconciler) processRunTimeouts(ctx context.Context, pr *v1beta1.Pipelin
 // pipeline run state, and starts them
 // after all DAG tasks are done, it's responsible for scheduling final tasks and start executing them
 func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.PipelineRun, pipelineRunFacts *resources.PipelineRunFacts, as artifacts.ArtifactStorageInterface) error {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "runNextSchedulableTask")
+	defer span.End()
 
 	logger := logging.FromContext(ctx)
 	recorder := controller.GetEventRecorder(ctx)
@@ -697,6 +738,15 @@ func (c *Reconciler) runNextSchedulableTask(ctx context.Context, pr *v1beta1.Pip
 		}
 	}
 
+	if c.isAffinityAssistantDisabled(ctx) {
+		// Without the Affinity Assistant, TaskRuns sharing a ReadWriteOnce PersistentVolumeClaim
+		// may be scheduled on different nodes and never start, so they have to run one at a time.
+		nextRpts, err = c.serializeReadWriteOnceWorkspaces(ctx, pr, pipelineRunFacts, nextRpts)
+		if err != nil {
+			return err
+		}
+	}
+
 	for _, rpt := range nextRpts {
 		if rpt == nil || rpt.Skip(pipelineRunFacts).IsSkipped || rpt.IsFinallySkipped(pipelineRunFacts).IsSkipped {
 			continue
@@ -800,6 +850,9 @@ func (c *Reconciler) createTaskRuns(ctx context.Context, rpt *resources.Resolved
 }
 
 func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, params []v1beta1.Param, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun, storageBasePath string, getTimeoutFunc getTimeoutFunc) (*v1beta1.TaskRun, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createTaskRun")
+	defer span.End()
+	span.SetAttributes(attribute.String("taskrun", taskRunName), attribute.String("pipelineTask", rpt.PipelineTask.Name))
 	logger := logging.FromContext(ctx)
 
 	tr, _ := c.taskRunLister.TaskRuns(pr.Namespace).Get(taskRunName)
@@ -832,6 +885,7 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 			PodTemplate:        taskRunSpec.TaskPodTemplate,
 			StepOverrides:      taskRunSpec.StepOverrides,
 			SidecarOverrides:   taskRunSpec.SidecarOverrides,
+			ComputeResources:   taskRunSpec.ComputeResources,
 		}}
 
 	if rpt.ResolvedTaskResources.TaskName != "" {
@@ -848,8 +902,15 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 		return nil, err
 	}
 
-	if !c.isAffinityAssistantDisabled(ctx) && pipelinePVCWorkspaceName != "" {
-		tr.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
+	if affinityAssistantName := c.getTaskRunAffinityAssistantName(ctx, pr, pipelinePVCWorkspaceName); affinityAssistantName != "" {
+		tr.Annotations[workspace.AnnotationAffinityAssistantName] = affinityAssistantName
+	}
+
+	// Propagate the trace to the TaskRun so that its spans are children of this one.
+	if spanContext, err := tracing.MarshalSpanContext(ctx); err != nil {
+		logger.Warnf("Unable to marshal span context for TaskRun %s: %v", taskRunName, err)
+	} else if spanContext != "" {
+		tr.Annotations[taskrun.SpanContextAnnotation] = spanContext
 	}
 
 	resources.WrapSteps(&tr.Spec, rpt.PipelineTask, rpt.ResolvedTaskResources.Inputs, rpt.ResolvedTaskResources.Outputs, storageBasePath)
@@ -857,6 +918,28 @@ func (c *Reconciler) createTaskRun(ctx context.Context, taskRunName string, para
 	return c.PipelineClientSet.TektonV1beta1().TaskRuns(pr.Namespace).Create(ctx, tr, metav1.CreateOptions{})
 }
 
+// shouldCleanupWorkspacePVCs returns true if the PVCs created from the volumeClaimTemplates of a completed
+// PipelineRun should be deleted, according to the "workspace-pvc-cleanup" feature flag or to the annotation
+// of the PipelineRun overriding it.
+func shouldCleanupWorkspacePVCs(ctx context.Context, pr *v1beta1.PipelineRun) bool {
+	logger := logging.FromContext(ctx)
+	cleanup := config.FromContextOrDefaults(ctx).FeatureFlags.WorkspacePVCCleanup
+	if value, ok := pr.Annotations[volumeclaim.WorkspacePVCCleanupAnnotationKey]; ok {
+		if config.IsValidWorkspacePVCCleanup(value) {
+			cleanup = value
+		} else {
+			logger.Warnf("Ignoring invalid %s annotation %q of PipelineRun %s", volumeclaim.WorkspacePVCCleanupAnnotationKey, value, pr.Name)
+		}
+	}
+	switch cleanup {
+	case config.AlwaysWorkspacePVCCleanup:
+		return true
+	case config.OnSuccessWorkspacePVCCleanup:
+		return pr.Status.GetCondition(apis.ConditionSucceeded).IsTrue()
+	}
+	return false
+}
+
 func (c *Reconciler) createRuns(ctx context.Context, rpt *resources.ResolvedPipelineTask, pr *v1beta1.PipelineRun, getTimeoutFunc getTimeoutFunc) ([]*v1alpha1.Run, error) {
 	var runs []*v1alpha1.Run
 	matrixCombinations := matrix.FanOut(rpt.PipelineTask.Matrix).ToMap()
@@ -918,8 +1001,8 @@ func (c *Reconciler) createRun(ctx context.Context, runName string, params []v1b
 
 	// Set the affinity assistant annotation in case the custom task creates TaskRuns or Pods
 	// that can take advantage of it.
-	if !c.isAffinityAssistantDisabled(ctx) && pipelinePVCWorkspaceName != "" {
-		r.Annotations[workspace.AnnotationAffinityAssistantName] = getAffinityAssistantName(pipelinePVCWorkspaceName, pr.Name)
+	if affinityAssistantName := c.getTaskRunAffinityAssistantName(ctx, pr, pipelinePVCWorkspaceName); affinityAssistantName != "" {
+		r.Annotations[workspace.AnnotationAffinityAssistantName] = affinityAssistantName
 	}
 
 	logger.Infof("Creating a new Run object %s", runName)
@@ -943,10 +1026,14 @@ func getTaskrunWorkspaces(pr *v1beta1.PipelineRun, rpt *resources.ResolvedPipeli
 		}
 
 		if b, hasBinding := pipelineRunWorkspaces[pipelineWorkspace]; hasBinding {
-			if b.PersistentVolumeClaim != nil || b.VolumeClaimTemplate != nil {
+			if b.PersistentVolumeClaim != nil || b.VolumeClaimTemplate != nil || b.Cache != nil {
 				pipelinePVCWorkspaceName = pipelineWorkspace
 			}
-			workspaces = append(workspaces, taskWorkspaceByWorkspaceVolumeSource(b, taskWorkspaceName, pipelineTaskSubPath, *kmeta.NewControllerRef(pr)))
+			binding := taskWorkspaceByWorkspaceVolumeSource(b, taskWorkspaceName, pipelineTaskSubPath, *kmeta.NewControllerRef(pr))
+			if ws.Access == v1beta1.WorkspaceAccessReadOnly {
+				binding.ReadOnly = true
+			}
+			workspaces = append(workspaces, binding)
 		} else {
 			workspaceIsOptional := false
 			if rpt.ResolvedTaskResources != nil && rpt.ResolvedTaskResources.TaskSpec != nil {
@@ -968,7 +1055,18 @@ func getTaskrunWorkspaces(pr *v1beta1.PipelineRun, rpt *resources.ResolvedPipeli
 
 // taskWorkspaceByWorkspaceVolumeSource is returning the WorkspaceBinding with the TaskRun specified name.
 // If the volume source is a volumeClaimTemplate, the template is applied and passed to TaskRun as a persistentVolumeClaim
+// If the volume source is a cache, the claim of the cache is passed to TaskRun as a persistentVolumeClaim
 func taskWorkspaceByWorkspaceVolumeSource(wb v1beta1.WorkspaceBinding, taskWorkspaceName string, pipelineTaskSubPath string, owner metav1.OwnerReference) v1beta1.WorkspaceBinding {
+	if wb.Cache != nil {
+		return v1beta1.WorkspaceBinding{
+			Name:     taskWorkspaceName,
+			SubPath:  combinedSubPath(wb.SubPath, pipelineTaskSubPath),
+			ReadOnly: wb.ReadOnly,
+			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: volumeclaim.GetCachePersistentVolumeClaimName(wb.Cache),
+			},
+		}
+	}
 	if wb.VolumeClaimTemplate == nil {
 		binding := *wb.DeepCopy()
 		binding.Name = taskWorkspaceName
@@ -978,7 +1076,8 @@ func taskWorkspaceByWorkspaceVolumeSource(wb v1beta1.WorkspaceBinding, taskWorks
 
 	// apply template
 	binding := v1beta1.WorkspaceBinding{
-		SubPath: combinedSubPath(wb.SubPath, pipelineTaskSubPath),
+		SubPath:  combinedSubPath(wb.SubPath, pipelineTaskSubPath),
+		ReadOnly: wb.ReadOnly,
 		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
 			ClaimName: volumeclaim.GetPersistentVolumeClaimName(wb.VolumeClaimTemplate, wb, owner),
 		},
@@ -1008,6 +1107,7 @@ func clearStatus(tr *v1beta1.TaskRun) {
 	tr.Status.StartTime = nil
 	tr.Status.CompletionTime = nil
 	tr.Status.PodName = ""
+	tr.Status.ResourceUsage = nil
 }
 
 func getTaskrunAnnotations(pr *v1beta1.PipelineRun) map[string]string {
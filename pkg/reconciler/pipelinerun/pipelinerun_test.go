@@ -24,6 +24,9 @@ import (
 	"fmt"
 	"net/http/httptest"
 	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
 	"strconv"
 	"testing"
 	"time"
@@ -37,6 +40,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1alpha1"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/archive"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
@@ -50,6 +54,7 @@ import (
 	"gomodules.xyz/jsonpatch/v2"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -121,7 +126,7 @@ type PipelineRunTest struct {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, tracingExists, archiveExists, stepLogsExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -138,6 +143,15 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetMetricsConfigName() {
 			metricsExists = true
 		}
+		if cm.Name == config.GetTracingConfigName() {
+			tracingExists = true
+		}
+		if cm.Name == config.GetArchiveConfigName() {
+			archiveExists = true
+		}
+		if cm.Name == config.GetStepLogsConfigName() {
+			stepLogsExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -169,6 +183,24 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !tracingExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTracingConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !archiveExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetArchiveConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !stepLogsExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetStepLogsConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getPipelineRunController returns an instance of the PipelineRun controller/reconciler that has been seeded with
@@ -1387,6 +1419,334 @@ func TestReconcile_InvalidPipelineRunNames(t *testing.T) {
 	}
 }
 
+// TestReconcileOnCompletedPipelineRunCleansUpWorkspacePVCs tests that the PVCs created from the volumeClaimTemplates
+// of a completed PipelineRun are deleted according to the workspace-pvc-cleanup feature flag and its annotation.
+func TestReconcileOnCompletedPipelineRunCleansUpWorkspacePVCs(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		featureFlag string
+		annotation  string
+		status      string
+		wantEvent   string
+		wantDelete  bool
+	}{{
+		name:       "never by default",
+		status:     "True",
+		wantEvent:  "Normal Succeeded",
+		wantDelete: false,
+	}, {
+		name:        "always on failure",
+		featureFlag: config.AlwaysWorkspacePVCCleanup,
+		status:      "False",
+		wantEvent:   "Warning Failed",
+		wantDelete:  true,
+	}, {
+		name:        "on success when succeeded",
+		featureFlag: config.OnSuccessWorkspacePVCCleanup,
+		status:      "True",
+		wantEvent:   "Normal Succeeded",
+		wantDelete:  true,
+	}, {
+		name:        "on success when failed",
+		featureFlag: config.OnSuccessWorkspacePVCCleanup,
+		status:      "False",
+		wantEvent:   "Warning Failed",
+		wantDelete:  false,
+	}, {
+		name:        "annotation overrides feature flag",
+		featureFlag: config.AlwaysWorkspacePVCCleanup,
+		annotation:  config.OnSuccessWorkspacePVCCleanup,
+		status:      "False",
+		wantEvent:   "Warning Failed",
+		wantDelete:  false,
+	}, {
+		name:       "annotation without feature flag",
+		annotation: config.AlwaysWorkspacePVCCleanup,
+		status:     "True",
+		wantEvent:  "Normal Succeeded",
+		wantDelete: true,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run-completed
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        name: myclaim
+status:
+  conditions:
+  - lastTransitionTime: null
+    message: All Tasks have completed executing
+    reason: Completed
+    status: %q
+    type: Succeeded
+`, tc.status))
+			if tc.annotation != "" {
+				pr.Annotations = map[string]string{volumeclaim.WorkspacePVCCleanupAnnotationKey: tc.annotation}
+			}
+			cms := []*corev1.ConfigMap{newFeatureFlagsConfigMap()}
+			if tc.featureFlag != "" {
+				cms[0].Data["workspace-pvc-cleanup"] = tc.featureFlag
+			}
+			ws := pr.Spec.Workspaces[0]
+			pvc := &corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{
+				Name:      volumeclaim.GetPersistentVolumeClaimName(ws.VolumeClaimTemplate, ws, *kmeta.NewControllerRef(pr)),
+				Namespace: "foo",
+			}}
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr},
+				Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   cms,
+				PVCs:         []*corev1.PersistentVolumeClaim{pvc},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			_, clients := prt.reconcileRun("foo", "test-pipeline-run-completed", []string{tc.wantEvent}, false)
+
+			deleted := false
+			for _, a := range clients.Kube.Actions() {
+				if a.GetVerb() == "delete" && a.GetResource().Resource == "persistentvolumeclaims" {
+					deleted = true
+				}
+			}
+			if deleted != tc.wantDelete {
+				t.Errorf("expected PVC deletion %t, got %t", tc.wantDelete, deleted)
+			}
+		})
+	}
+}
+
+func TestReconcileOnCompletedPipelineRunWithTTL(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		ttl         string
+		wantDelete  bool
+		wantRequeue time.Duration
+	}{{
+		name:       "without ttlSecondsAfterFinished",
+		wantDelete: false,
+	}, {
+		name:       "ttlSecondsAfterFinished expired",
+		ttl:        "ttlSecondsAfterFinished: 60",
+		wantDelete: true,
+	}, {
+		name:        "ttlSecondsAfterFinished not expired",
+		ttl:         "ttlSecondsAfterFinished: 3600",
+		wantDelete:  false,
+		wantRequeue: 30 * time.Minute,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			pr := parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run-completed
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  %s
+status:
+  startTime: %q
+  completionTime: %q
+  conditions:
+  - lastTransitionTime: null
+    message: All Tasks have completed executing
+    reason: Completed
+    status: "True"
+    type: Succeeded
+`, tc.ttl, now.Add(-time.Hour).Format(time.RFC3339), now.Add(-30*time.Minute).Format(time.RFC3339)))
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr},
+				Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			err := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipeline-run-completed")
+			if tc.wantRequeue != 0 {
+				if ok, requeue := controller.IsRequeueKey(err); !ok || requeue != tc.wantRequeue {
+					t.Errorf("expected a requeue after %s, got %v", tc.wantRequeue, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Error reconciling: %v", err)
+			}
+
+			_, err = prt.TestAssets.Clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-completed", metav1.GetOptions{})
+			if deleted := k8serrors.IsNotFound(err); deleted != tc.wantDelete {
+				t.Errorf("expected PipelineRun deletion %t, got %t", tc.wantDelete, deleted)
+			}
+		})
+	}
+}
+
+func TestReconcileOnCompletedPipelineRunArchivesRecord(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		location       string
+		ttl            string
+		wantErr        bool
+		wantDelete     bool
+		wantAnnotation string
+	}{{
+		name:           "archived",
+		location:       "file://%s",
+		wantAnnotation: archive.StatusArchived,
+	}, {
+		name:       "archived before being deleted",
+		location:   "file://%s",
+		ttl:        "ttlSecondsAfterFinished: 60",
+		wantDelete: true,
+	}, {
+		name:           "archiving failed blocks the deletion",
+		location:       "file://%s/not-a-directory",
+		ttl:            "ttlSecondsAfterFinished: 60",
+		wantErr:        true,
+		wantAnnotation: archive.StatusFailed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// The records can't be written under a regular file.
+			if err := os.WriteFile(filepath.Join(dir, "not-a-directory"), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+			pr := parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: test-pipeline-run-completed
+  namespace: foo
+  uid: "1234"
+spec:
+  pipelineRef:
+    name: test-pipeline
+  %s
+status:
+  startTime: %q
+  completionTime: %q
+  conditions:
+  - lastTransitionTime: null
+    message: All Tasks have completed executing
+    reason: Completed
+    status: "True"
+    type: Succeeded
+`, tc.ttl, now.Add(-time.Hour).Format(time.RFC3339), now.Add(-30*time.Minute).Format(time.RFC3339)))
+			d := test.Data{
+				PipelineRuns: []*v1beta1.PipelineRun{pr},
+				Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetArchiveConfigName()},
+					Data: map[string]string{
+						"location": fmt.Sprintf(tc.location, dir),
+					},
+				}},
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			err := prt.TestAssets.Controller.Reconciler.Reconcile(prt.TestAssets.Ctx, "foo/test-pipeline-run-completed")
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Reconcile() = %v, expected error %t", err, tc.wantErr)
+			}
+
+			record := filepath.Join(dir, "foo", "pipelinerun", "test-pipeline-run-completed-1234.json")
+			if _, err := os.Stat(record); (err == nil) == tc.wantErr {
+				t.Errorf("expected the record to be archived %t, got %v", !tc.wantErr, err)
+			}
+
+			reconciledRun, err := prt.TestAssets.Clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, "test-pipeline-run-completed", metav1.GetOptions{})
+			if deleted := k8serrors.IsNotFound(err); deleted != tc.wantDelete {
+				t.Fatalf("expected PipelineRun deletion %t, got %t", tc.wantDelete, deleted)
+			}
+			if tc.wantDelete {
+				return
+			}
+			if got := reconciledRun.Annotations[archive.StatusAnnotationKey]; got != tc.wantAnnotation {
+				t.Errorf("expected the archive status %q, got %q", tc.wantAnnotation, got)
+			}
+			if tc.wantAnnotation == archive.StatusArchived {
+				if got, want := reconciledRun.Annotations[archive.LocationAnnotationKey], "file://"+record; got != want {
+					t.Errorf("expected the archive location %q, got %q", want, got)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileOnCompletedPipelineRunDeletesCompletedPipelineRunsOverLimit(t *testing.T) {
+	newPipelineRun := func(name, completionTime string, status string) *v1beta1.PipelineRun {
+		return parse.MustParsePipelineRun(t, fmt.Sprintf(`
+metadata:
+  name: %s
+  namespace: foo
+  labels:
+    tekton.dev/pipeline: test-pipeline
+spec:
+  pipelineRef:
+    name: test-pipeline
+status:
+  startTime: "2021-12-01T00:00:00Z"
+  completionTime: %s
+  conditions:
+  - lastTransitionTime: null
+    status: %q
+    type: Succeeded
+`, name, completionTime, status))
+	}
+	for _, tc := range []struct {
+		name            string
+		enableAPIFields string
+		wantDeleted     []string
+	}{{
+		name:            "alpha",
+		enableAPIFields: config.AlphaAPIFields,
+		wantDeleted:     []string{"oldest", "older"},
+	}, {
+		name:            "stable",
+		enableAPIFields: config.StableAPIFields,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			prs := []*v1beta1.PipelineRun{
+				newPipelineRun("oldest", `"2021-12-01T00:00:00Z"`, "True"),
+				newPipelineRun("older", `"2021-12-02T00:00:00Z"`, "False"),
+				newPipelineRun("old", `"2021-12-03T00:00:00Z"`, "True"),
+				newPipelineRun("latest", `"2021-12-04T00:00:00Z"`, "True"),
+				newPipelineRun("running", "null", "Unknown"),
+			}
+			cms := []*corev1.ConfigMap{newDefaultsConfigMap(), newFeatureFlagsConfigMap()}
+			cms[0].Data["default-max-completed-pipelineruns-per-pipeline"] = "2"
+			cms[1].Data["enable-api-fields"] = tc.enableAPIFields
+			d := test.Data{
+				PipelineRuns: prs,
+				Pipelines:    []*v1beta1.Pipeline{simpleHelloWorldPipeline},
+				Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+				ConfigMaps:   cms,
+			}
+			prt := newPipelineRunTest(d, t)
+			defer prt.Cancel()
+
+			prt.reconcileRun("foo", "latest", []string{}, false)
+
+			var deleted []string
+			for _, pr := range prs {
+				_, err := prt.TestAssets.Clients.Pipeline.TektonV1beta1().PipelineRuns("foo").Get(prt.TestAssets.Ctx, pr.Name, metav1.GetOptions{})
+				if k8serrors.IsNotFound(err) {
+					deleted = append(deleted, pr.Name)
+				}
+			}
+			if d := cmp.Diff(tc.wantDeleted, deleted); d != "" {
+				t.Errorf("unexpected deleted PipelineRuns %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestReconcileOnCompletedPipelineRun(t *testing.T) {
 	// TestReconcileOnCompletedPipelineRun runs "Reconcile" on a PipelineRun that already reached completion
 	// and that does not have the latest status from TaskRuns yet. It checks that the TaskRun status is updated
@@ -4396,6 +4756,316 @@ spec:
 	}
 }
 
+// TestReconcileWithVolumeClaimTemplateWorkspaceNotAllowed tests that a PipelineRun with a volumeClaimTemplate
+// workspace which doesn't comply with the configured policy fails without creating the PVC.
+func TestReconcileWithVolumeClaimTemplateWorkspaceNotAllowed(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  workspaces:
+  - name: ws1
+`)}
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        name: myclaim
+      spec:
+        resources:
+          requests:
+            storage: 50Gi
+`)}
+	cms := []*corev1.ConfigMap{{
+		ObjectMeta: metav1.ObjectMeta{Name: config.GetDefaultsConfigName(), Namespace: system.Namespace()},
+		Data: map[string]string{
+			"default-volume-claim-template-policy": "maxStorage: 10Gi",
+		},
+	}}
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        []*v1beta1.Task{simpleHelloWorldTask},
+		ConfigMaps:   cms,
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning Failed PipelineRun foo/test-pipeline-run workspaces are not allowed: volumeClaimTemplate of workspace ws1 is not allowed: requested storage 50Gi exceeds the maximum of 10Gi",
+		"Warning InternalError 1 error occurred",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, true)
+
+	checkPipelineRunConditionStatusAndReason(t, reconciledRun, corev1.ConditionFalse, volumeclaim.ReasonVolumeClaimTemplateNotAllowed)
+	for _, a := range clients.Kube.Actions() {
+		if ca, ok := a.(ktesting.CreateAction); ok {
+			if _, ok := ca.GetObject().(*corev1.PersistentVolumeClaim); ok {
+				t.Errorf("expected no PVC to be created")
+			}
+		}
+	}
+}
+
+// TestReconcileWithCacheWorkspace tests that given a pipeline with a cache workspace, the PVC of the cache
+// is created without OwnerReference and is bound to the taskRuns.
+func TestReconcileWithCacheWorkspace(t *testing.T) {
+	pipelineRunName := "test-pipeline-run"
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  workspaces:
+  - name: ws1
+`)}
+
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    cache:
+      key: go-mod-1234
+      volumeClaimTemplate:
+        metadata:
+          name: gocache
+`)}
+	ts := []*v1beta1.Task{simpleHelloWorldTask}
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps:   []*corev1.ConfigMap{withEnabledAlphaAPIFields(newFeatureFlagsConfigMap())},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	reconciledRun, clients := prt.reconcileRun("foo", pipelineRunName, []string{}, false)
+
+	expectedPVCName := volumeclaim.GetCachePersistentVolumeClaimName(prs[0].Spec.Workspaces[0].Cache)
+	pvc, err := clients.Kube.CoreV1().PersistentVolumeClaims("foo").Get(prt.TestAssets.Ctx, expectedPVCName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected PVC %s to exist but instead got error when getting it: %v", expectedPVCName, err)
+	}
+	if len(pvc.OwnerReferences) != 0 {
+		t.Errorf("expected the cache PVC to outlive the PipelineRun, got OwnerReferences %v", pvc.OwnerReferences)
+	}
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+	for _, tr := range taskRuns.Items {
+		for _, ws := range tr.Spec.Workspaces {
+			if ws.PersistentVolumeClaim == nil || ws.PersistentVolumeClaim.ClaimName != expectedPVCName {
+				t.Errorf("expected taskRun workspace %s to bind PVC %s, got %v", ws.Name, expectedPVCName, ws)
+			}
+		}
+	}
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+// TestReconcileWithReadWriteOnceWorkspaceWithoutAffinityAssistant tests that given parallel tasks sharing a
+// ReadWriteOnce workspace and the Affinity Assistant disabled, only one of the taskRuns is created.
+func TestReconcileWithReadWriteOnceWorkspaceWithoutAffinityAssistant(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: hello-world-1
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  - name: hello-world-2
+    taskRef:
+      name: hello-world
+    workspaces:
+    - name: taskWorkspaceName
+      workspace: ws1
+  - name: hello-world-3
+    taskRef:
+      name: hello-world
+  workspaces:
+  - name: ws1
+`)}
+
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        name: myclaim
+      spec:
+        accessModes:
+        - ReadWriteOnce
+`)}
+	ts := []*v1beta1.Task{simpleHelloWorldTask}
+	cm := newFeatureFlagsConfigMap()
+	cm.Data["disable-affinity-assistant"] = "true"
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps:   []*corev1.ConfigMap{cm},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Normal TaskRunDeferred PipelineTask hello-world-2 waits for the ReadWriteOnce workspaces ws1",
+		"Normal Running Tasks Completed: 0",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, false)
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+	var pipelineTasks []string
+	for _, tr := range taskRuns.Items {
+		pipelineTasks = append(pipelineTasks, tr.Labels[pipeline.PipelineTaskLabelKey])
+	}
+	sort.Strings(pipelineTasks)
+	if d := cmp.Diff([]string{"hello-world-1", "hello-world-3"}, pipelineTasks); d != "" {
+		t.Errorf("unexpected TaskRuns created %s", diff.PrintWantGot(d))
+	}
+
+	if !reconciledRun.Status.GetCondition(apis.ConditionSucceeded).IsUnknown() {
+		t.Errorf("Expected PipelineRun to be running, but condition status is %s", reconciledRun.Status.GetCondition(apis.ConditionSucceeded))
+	}
+}
+
+// TestReconcileWithReadWriteOnceWorkspaceInMatrixWithoutAffinityAssistant tests that given a matrixed task using a
+// ReadWriteOnce workspace and the Affinity Assistant disabled, the pipelineRun fails.
+func TestReconcileWithReadWriteOnceWorkspaceInMatrixWithoutAffinityAssistant(t *testing.T) {
+	ps := []*v1beta1.Pipeline{parse.MustParsePipeline(t, `
+metadata:
+  name: test-pipeline
+  namespace: foo
+spec:
+  tasks:
+  - name: platforms
+    taskRef:
+      name: mytask
+    matrix:
+    - name: platform
+      value:
+      - linux
+      - mac
+    workspaces:
+    - name: source
+      workspace: ws1
+  workspaces:
+  - name: ws1
+`)}
+
+	prs := []*v1beta1.PipelineRun{parse.MustParsePipelineRun(t, `
+metadata:
+  name: test-pipeline-run
+  namespace: foo
+spec:
+  pipelineRef:
+    name: test-pipeline
+  workspaces:
+  - name: ws1
+    volumeClaimTemplate:
+      metadata:
+        name: myclaim
+      spec:
+        accessModes:
+        - ReadWriteOnce
+`)}
+	ts := []*v1beta1.Task{parse.MustParseTask(t, `
+metadata:
+  name: mytask
+  namespace: foo
+spec:
+  params:
+  - name: platform
+  workspaces:
+  - name: source
+  steps:
+  - name: echo
+    image: alpine
+    script: echo $(params.platform)
+`)}
+	cm := withEmbeddedStatus(withEnabledAlphaAPIFields(newFeatureFlagsConfigMap()), config.MinimalEmbeddedStatus)
+	cm.Data["disable-affinity-assistant"] = "true"
+
+	d := test.Data{
+		PipelineRuns: prs,
+		Pipelines:    ps,
+		Tasks:        ts,
+		ConfigMaps:   []*corev1.ConfigMap{cm},
+	}
+	prt := newPipelineRunTest(d, t)
+	defer prt.Cancel()
+
+	wantEvents := []string{
+		"Normal Started",
+		"Warning Failed PipelineTask platforms would run 2 TaskRuns in parallel on the ReadWriteOnce workspaces ws1, which requires the Affinity Assistant",
+		"Warning InternalError 1 error occurred",
+	}
+	reconciledRun, clients := prt.reconcileRun("foo", "test-pipeline-run", wantEvents, true)
+
+	taskRuns, err := clients.Pipeline.TektonV1beta1().TaskRuns("foo").List(prt.TestAssets.Ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error when listing TaskRuns: %v", err)
+	}
+	if len(taskRuns.Items) != 0 {
+		t.Errorf("expected no TaskRuns to be created, got %d", len(taskRuns.Items))
+	}
+
+	condition := reconciledRun.Status.GetCondition(apis.ConditionSucceeded)
+	if !condition.IsFalse() || condition.Reason != ReasonReadWriteOnceWorkspaceConflict {
+		t.Errorf("Expected PipelineRun to fail with reason %s, but condition is %v", ReasonReadWriteOnceWorkspaceConflict, condition)
+	}
+}
+
 // TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths tests that given a pipeline with volumeClaimTemplate workspace and
 // multiple instances of the same task, but using different subPaths in the volume - is seen as taskRuns with expected subPaths.
 func TestReconcileWithVolumeClaimTemplateWorkspaceUsingSubPaths(t *testing.T) {
@@ -7542,6 +8212,69 @@ spec:
 	}
 }
 
+// TestGetTaskrunWorkspaces_ReadOnly tests that the TaskRun workspaces are read-only when the PipelineTask binds the
+// workspace with readOnly access, or when the PipelineRun binds the workspace read-only.
+func TestGetTaskrunWorkspaces_ReadOnly(t *testing.T) {
+	pr := parse.MustParsePipelineRun(t, `
+metadata:
+  name: pipeline
+spec:
+  workspaces:
+    - name: source
+      emptyDir: {}
+    - name: read-only-claim
+      readOnly: true
+      volumeClaimTemplate:
+        metadata:
+          name: claim
+    - name: read-only-cache
+      readOnly: true
+      cache:
+        key: go-mod-1234
+        volumeClaimTemplate:
+          metadata:
+            name: gocache
+`)
+	rprt := &resources.ResolvedPipelineTask{
+		PipelineTask: &v1beta1.PipelineTask{
+			Name: "resolved-pipelinetask",
+			Workspaces: []v1beta1.WorkspacePipelineTaskBinding{{
+				Name:      "read-only-source",
+				Workspace: "source",
+				Access:    v1beta1.WorkspaceAccessReadOnly,
+			}, {
+				Name:      "read-write-source",
+				Workspace: "source",
+				Access:    v1beta1.WorkspaceAccessReadWrite,
+			}, {
+				Name:      "claim",
+				Workspace: "read-only-claim",
+			}, {
+				Name:      "cache",
+				Workspace: "read-only-cache",
+			}},
+		},
+	}
+
+	workspaces, _, err := getTaskrunWorkspaces(pr, rprt)
+	if err != nil {
+		t.Fatalf("Pipeline.getTaskrunWorkspaces() returned error for valid pipeline: %v", err)
+	}
+	got := map[string]bool{}
+	for _, ws := range workspaces {
+		got[ws.Name] = ws.ReadOnly
+	}
+	want := map[string]bool{
+		"read-only-source":  true,
+		"read-write-source": false,
+		"claim":             true,
+		"cache":             true,
+	}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("Pipeline.getTaskrunWorkspaces() read-only workspaces %s", diff.PrintWantGot(d))
+	}
+}
+
 func TestReconcile_PropagatePipelineTaskRunSpecMetadata(t *testing.T) {
 	names.TestingSeed()
 	prName := "test-pipeline-run"
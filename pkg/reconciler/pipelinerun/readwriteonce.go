@@ -0,0 +1,106 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/reconciler/pipelinerun/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// serializeReadWriteOnceWorkspaces returns the PipelineTasks of nextRpts which can be started without sharing a
+// ReadWriteOnce workspace of the PipelineRun with another running TaskRun. The other PipelineTasks are deferred
+// until the TaskRuns using their workspaces are done. The PipelineRun fails if a matrixed PipelineTask would
+// start several TaskRuns on a ReadWriteOnce workspace at once, since these can't be serialized.
+func (c *Reconciler) serializeReadWriteOnceWorkspaces(ctx context.Context, pr *v1beta1.PipelineRun, pipelineRunFacts *resources.PipelineRunFacts, nextRpts resources.PipelineRunState) (resources.PipelineRunState, error) {
+	logger := logging.FromContext(ctx)
+	recorder := controller.GetEventRecorder(ctx)
+
+	rwoWorkspaces, err := c.pvcHandler.GetReadWriteOnceWorkspaces(ctx, pr.Spec.Workspaces, pr.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the access modes of the workspaces of PipelineRun %s: %w", pr.Name, err)
+	}
+	if rwoWorkspaces.Len() == 0 {
+		return nextRpts, nil
+	}
+
+	inUse := map[string]string{}
+	for _, rpt := range pipelineRunFacts.State.GetRunningTasks() {
+		for _, ws := range readWriteOnceWorkspaces(rpt, rwoWorkspaces).List() {
+			inUse[ws] = rpt.PipelineTask.Name
+		}
+	}
+
+	var schedulable resources.PipelineRunState
+	for _, rpt := range nextRpts {
+		if rpt == nil || rpt.IsCustomTask() || rpt.Skip(pipelineRunFacts).IsSkipped || rpt.IsFinallySkipped(pipelineRunFacts).IsSkipped {
+			schedulable = append(schedulable, rpt)
+			continue
+		}
+		workspaces := readWriteOnceWorkspaces(rpt, rwoWorkspaces)
+		if workspaces.Len() == 0 {
+			schedulable = append(schedulable, rpt)
+			continue
+		}
+		if rpt.IsMatrixed() && rpt.PipelineTask.GetMatrixCombinationsCount() > 1 {
+			err := fmt.Errorf("PipelineTask %s would run %d TaskRuns in parallel on the ReadWriteOnce workspaces %s, which requires the Affinity Assistant",
+				rpt.PipelineTask.Name, rpt.PipelineTask.GetMatrixCombinationsCount(), strings.Join(workspaces.List(), ", "))
+			logger.Errorf("Failed to run PipelineRun %s: %v", pr.Name, err)
+			pr.Status.MarkFailed(ReasonReadWriteOnceWorkspaceConflict, err.Error())
+			return nil, controller.NewPermanentError(err)
+		}
+		var blockers []string
+		for _, ws := range workspaces.List() {
+			if blocker, ok := inUse[ws]; ok {
+				blockers = append(blockers, fmt.Sprintf("%s (used by %s)", ws, blocker))
+			}
+		}
+		if len(blockers) > 0 {
+			logger.Infof("Deferring PipelineTask %s of PipelineRun %s until the ReadWriteOnce workspaces %s are released", rpt.PipelineTask.Name, pr.Name, strings.Join(blockers, ", "))
+			recorder.Eventf(pr, corev1.EventTypeNormal, "TaskRunDeferred", "PipelineTask %s waits for the ReadWriteOnce workspaces %s", rpt.PipelineTask.Name, strings.Join(blockers, ", "))
+			continue
+		}
+		for _, ws := range workspaces.List() {
+			inUse[ws] = rpt.PipelineTask.Name
+		}
+		schedulable = append(schedulable, rpt)
+	}
+	return schedulable, nil
+}
+
+// readWriteOnceWorkspaces returns the names of the workspaces of the PipelineRun among rwoWorkspaces which are
+// bound by the PipelineTask.
+func readWriteOnceWorkspaces(rpt *resources.ResolvedPipelineTask, rwoWorkspaces sets.String) sets.String {
+	workspaces := sets.NewString()
+	for _, ws := range rpt.PipelineTask.Workspaces {
+		pipelineWorkspace := ws.Workspace
+		if pipelineWorkspace == "" {
+			pipelineWorkspace = ws.Name
+		}
+		if rwoWorkspaces.Has(pipelineWorkspace) {
+			workspaces.Insert(pipelineWorkspace)
+		}
+	}
+	return workspaces
+}
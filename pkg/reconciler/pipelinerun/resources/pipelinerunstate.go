@@ -189,6 +189,31 @@ func (state PipelineRunState) GetTaskRunsResults() map[string][]v1beta1.TaskRunR
 	return results
 }
 
+// GetResourceUsage returns the sum of the compute resources reserved by the TaskRuns in the state,
+// including their retried attempts. It returns nil if none of the TaskRuns reports any usage.
+func (state PipelineRunState) GetResourceUsage() *v1beta1.ResourceUsage {
+	var usage *v1beta1.ResourceUsage
+	add := func(u *v1beta1.ResourceUsage) {
+		if u == nil {
+			return
+		}
+		if usage == nil {
+			usage = &v1beta1.ResourceUsage{}
+		}
+		usage.Add(u)
+	}
+	for _, rpt := range state {
+		if rpt.IsCustomTask() || rpt.TaskRun == nil {
+			continue
+		}
+		add(rpt.TaskRun.Status.ResourceUsage)
+		for _, retry := range rpt.TaskRun.Status.RetriesStatus {
+			add(retry.ResourceUsage)
+		}
+	}
+	return usage
+}
+
 // GetRunsStatus returns a map of run name and the run.
 // Ignore a nil run in pipelineRunState, otherwise, capture run object from PipelineRun Status.
 // Update run status based on the pipelineRunState before returning it in the map.
@@ -291,6 +316,17 @@ func (t *ResolvedPipelineTask) getChildRefForTaskRun(taskRun *v1beta1.TaskRun) v
 	}
 }
 
+// GetRunningTasks returns the PipelineTasks whose TaskRuns or Runs have been created but are not done yet.
+func (state PipelineRunState) GetRunningTasks() PipelineRunState {
+	var running PipelineRunState
+	for _, t := range state {
+		if t.isRunning() {
+			running = append(running, t)
+		}
+	}
+	return running
+}
+
 // getNextTasks returns a list of tasks which should be executed next i.e.
 // a list of tasks from candidateTasks which aren't yet indicated in state to be running and
 // a list of cancelled/failed tasks from candidateTasks which haven't exhausted their retries
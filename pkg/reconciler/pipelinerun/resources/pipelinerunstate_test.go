@@ -33,6 +33,7 @@ import (
 	"github.com/tektoncd/pipeline/test/parse"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/selection"
@@ -273,6 +274,37 @@ func TestIsBeforeFirstTaskRun_WithSucceededTask(t *testing.T) {
 	}
 }
 
+func TestGetRunningTasks(t *testing.T) {
+	for _, tc := range []struct {
+		name  string
+		state PipelineRunState
+		want  []string
+	}{{
+		name:  "none-started",
+		state: noneStartedState,
+	}, {
+		name:  "one-started",
+		state: oneStartedState,
+		want:  []string{pts[0].Name},
+	}, {
+		name:  "one-finished",
+		state: oneFinishedState,
+	}, {
+		name:  "one-failed",
+		state: oneFailedState,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			var got []string
+			for _, rpt := range tc.state.GetRunningTasks() {
+				got = append(got, rpt.PipelineTask.Name)
+			}
+			if d := cmp.Diff(tc.want, got); d != "" {
+				t.Errorf("Unexpected running tasks %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
+
 func TestGetNextTasks(t *testing.T) {
 	tcs := []struct {
 		name         string
@@ -3271,3 +3303,57 @@ func TestPipelineRunState_GetChildReferences(t *testing.T) {
 		})
 	}
 }
+
+func TestPipelineRunState_GetResourceUsage(t *testing.T) {
+	usage := func(cpuSeconds, memoryGBSeconds string) *v1beta1.ResourceUsage {
+		cpu := resource.MustParse(cpuSeconds)
+		memory := resource.MustParse(memoryGBSeconds)
+		return &v1beta1.ResourceUsage{CPUSeconds: &cpu, MemoryGBSeconds: &memory}
+	}
+
+	for _, tc := range []struct {
+		name  string
+		state PipelineRunState
+		want  *v1beta1.ResourceUsage
+	}{{
+		name: "no usage",
+		state: PipelineRunState{{
+			TaskRunName:  "running-task",
+			PipelineTask: &v1beta1.PipelineTask{Name: "running-task"},
+			TaskRun:      &v1beta1.TaskRun{},
+		}, {
+			TaskRunName:  "not-started-task",
+			PipelineTask: &v1beta1.PipelineTask{Name: "not-started-task"},
+		}},
+	}, {
+		name: "sum of taskruns and retries",
+		state: PipelineRunState{{
+			TaskRunName:  "task-a",
+			PipelineTask: &v1beta1.PipelineTask{Name: "task-a"},
+			TaskRun: &v1beta1.TaskRun{Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				ResourceUsage: usage("10", "2.5"),
+			}}},
+		}, {
+			TaskRunName:  "task-b",
+			PipelineTask: &v1beta1.PipelineTask{Name: "task-b"},
+			TaskRun: &v1beta1.TaskRun{Status: v1beta1.TaskRunStatus{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+				ResourceUsage: usage("1.5", "0.5"),
+				RetriesStatus: []v1beta1.TaskRunStatus{{TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					ResourceUsage: usage("0.5", "1"),
+				}}},
+			}}},
+		}, {
+			RunName:      "custom-task",
+			CustomTask:   true,
+			PipelineTask: &v1beta1.PipelineTask{Name: "custom-task"},
+		}},
+		want: usage("12", "4"),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			got := tc.state.GetResourceUsage()
+			if d := cmp.Diff(tc.want, got, cmp.Comparer(func(a, b resource.Quantity) bool { return a.Cmp(b) == 0 })); d != "" {
+				t.Errorf("GetResourceUsage() %s", diff.PrintWantGot(d))
+			}
+		})
+	}
+}
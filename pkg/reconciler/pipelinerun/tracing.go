@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// TracerName is the name of the tracer used by the PipelineRun reconciler
+	TracerName = "PipelineRunReconciler"
+	// SpanContextAnnotation is the annotation used to persist the span context
+	// of the root span of a PipelineRun trace across reconciles.
+	SpanContextAnnotation = "tekton.dev/pipelinerunSpanContext"
+)
+
+// initTracing returns a context carrying the span context of the PipelineRun's
+// trace. The span context is read from the PipelineRun annotations if present,
+// which also allows callers to parent a PipelineRun to an external trace.
+// Otherwise a new root span is started and its context stored in the
+// annotations, which are persisted at the end of the reconcile. The returned
+// span is the parent of the spans of the reconcile and must be ended once the
+// reconcile is done; it doesn't record anything when the span context comes
+// from the annotations.
+func initTracing(ctx context.Context, tracerProvider trace.TracerProvider, pr *v1beta1.PipelineRun) (context.Context, trace.Span) {
+	logger := logging.FromContext(ctx)
+
+	if spanContext, ok := pr.Annotations[SpanContextAnnotation]; ok {
+		tctx, err := tracing.ContextWithSpanContext(ctx, spanContext)
+		if err == nil {
+			return tctx, trace.SpanFromContext(tctx)
+		}
+		logger.Warnf("Ignoring span context of PipelineRun %s: %v", pr.Name, err)
+	}
+
+	tctx, span := tracerProvider.Tracer(TracerName).Start(ctx, "PipelineRun:Reconciler")
+	span.SetAttributes(attribute.String("pipelinerun", pr.Name), attribute.String("namespace", pr.Namespace))
+
+	spanContext, err := tracing.MarshalSpanContext(tctx)
+	if err != nil {
+		logger.Warnf("Unable to marshal span context of PipelineRun %s: %v", pr.Name, err)
+		return tctx, span
+	}
+	if spanContext == "" {
+		// The tracer provider did not produce a valid span: tracing is disabled.
+		return ctx, span
+	}
+	if pr.Annotations == nil {
+		pr.Annotations = map[string]string{}
+	}
+	pr.Annotations[SpanContextAnnotation] = spanContext
+	return tctx, span
+}
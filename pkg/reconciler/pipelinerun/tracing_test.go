@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// endedSpans records the spans as they end.
+type endedSpans struct {
+	mu    sync.Mutex
+	spans []tracesdk.ReadOnlySpan
+}
+
+func (e *endedSpans) OnStart(context.Context, tracesdk.ReadWriteSpan) {}
+func (e *endedSpans) OnEnd(s tracesdk.ReadOnlySpan) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.spans = append(e.spans, s)
+}
+func (e *endedSpans) Shutdown(context.Context) error   { return nil }
+func (e *endedSpans) ForceFlush(context.Context) error { return nil }
+
+func (e *endedSpans) names() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	var names []string
+	for _, s := range e.spans {
+		names = append(names, s.Name())
+	}
+	return names
+}
+
+func TestInitTracing(t *testing.T) {
+	parentCtx, parent := tracesdk.NewTracerProvider().Tracer("test").Start(context.Background(), "parent")
+	defer parent.End()
+	parentSpanContext, err := tracing.MarshalSpanContext(parentCtx)
+	if err != nil {
+		t.Fatalf("MarshalSpanContext() = %v", err)
+	}
+
+	for _, tc := range []struct {
+		name           string
+		disabled       bool
+		annotations    map[string]string
+		wantAnnotation bool
+		wantTraceID    trace.TraceID
+		wantRootSpan   bool
+	}{{
+		name:     "tracing disabled",
+		disabled: true,
+	}, {
+		name:           "new root span",
+		wantAnnotation: true,
+		wantRootSpan:   true,
+	}, {
+		name:           "span context from annotation",
+		annotations:    map[string]string{SpanContextAnnotation: parentSpanContext},
+		wantAnnotation: true,
+		wantTraceID:    parent.SpanContext().TraceID(),
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			ended := &endedSpans{}
+			var tracerProvider trace.TracerProvider = tracesdk.NewTracerProvider(tracesdk.WithSpanProcessor(ended))
+			if tc.disabled {
+				tracerProvider = trace.NewNoopTracerProvider()
+			}
+			pr := &v1beta1.PipelineRun{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-pipelinerun", Namespace: "foo", Annotations: tc.annotations},
+			}
+			ctx, rootSpan := initTracing(context.Background(), tracerProvider, pr)
+
+			_, hasAnnotation := pr.Annotations[SpanContextAnnotation]
+			if hasAnnotation != tc.wantAnnotation {
+				t.Errorf("span context annotation present = %t, want %t", hasAnnotation, tc.wantAnnotation)
+			}
+			spanContext := trace.SpanContextFromContext(ctx)
+			if spanContext.IsValid() != tc.wantAnnotation {
+				t.Errorf("valid span context in returned context = %t, want %t", spanContext.IsValid(), tc.wantAnnotation)
+			}
+			if tc.wantTraceID.IsValid() && spanContext.TraceID() != tc.wantTraceID {
+				t.Errorf("got trace ID %s, want %s", spanContext.TraceID(), tc.wantTraceID)
+			}
+
+			// The root span covers the reconcile, so it only ends after the spans of the reconcile.
+			_, span := tracerProvider.Tracer(TracerName).Start(ctx, "PipelineRun:ReconcileKind")
+			span.End()
+			if len(ended.names()) > 1 {
+				t.Errorf("root span ended before the reconcile: %v", ended.names())
+			}
+			rootSpan.End()
+			var want []string
+			if !tc.disabled {
+				want = append(want, "PipelineRun:ReconcileKind")
+			}
+			if tc.wantRootSpan {
+				want = append(want, "PipelineRun:Reconciler")
+			}
+			got := ended.names()
+			if len(got) != len(want) {
+				t.Fatalf("got ended spans %v, want %v", got, want)
+			}
+			for i := range want {
+				if got[i] != want[i] {
+					t.Errorf("got ended spans %v, want %v", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pipelinerun
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/pipelinerunmetrics"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// garbageCollect deletes the oldest completed PipelineRuns of the Pipeline of the
+// finished PipelineRun beyond default-max-completed-pipelineruns-per-pipeline, then
+// deletes the PipelineRun itself once its ttlSecondsAfterFinished expired. It returns
+// a requeue event when the PipelineRun has to be deleted later. When archiving is
+// enabled, only PipelineRuns whose record was archived are deleted.
+func (c *Reconciler) garbageCollect(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	if metav1.GetControllerOf(pr) != nil {
+		// PipelineRuns controlled by another resource are deleted along with it.
+		return nil
+	}
+	if deletionBlockedByArchive(ctx, pr) {
+		return nil
+	}
+	deleted, err := c.deleteCompletedPipelineRunsOverLimit(ctx, pr)
+	if err != nil || deleted {
+		return err
+	}
+	return c.deleteExpiredPipelineRun(ctx, pr)
+}
+
+// deleteExpiredPipelineRun deletes the finished PipelineRun if its ttlSecondsAfterFinished
+// expired, or returns a requeue event for when it expires.
+func (c *Reconciler) deleteExpiredPipelineRun(ctx context.Context, pr *v1beta1.PipelineRun) error {
+	if pr.Spec.TTLSecondsAfterFinished == nil || pr.Status.CompletionTime == nil {
+		return nil
+	}
+	ttl := time.Duration(*pr.Spec.TTLSecondsAfterFinished) * time.Second
+	if remaining := ttl - c.Clock.Since(pr.Status.CompletionTime.Time); remaining > 0 {
+		return controller.NewRequeueAfter(remaining)
+	}
+	logging.FromContext(ctx).Infof("Deleting PipelineRun %s/%s, its ttlSecondsAfterFinished of %s expired", pr.Namespace, pr.Name, ttl)
+	return c.deletePipelineRun(ctx, pr, pipelinerunmetrics.DeletionReasonTTLExpired)
+}
+
+// deleteCompletedPipelineRunsOverLimit deletes the oldest completed PipelineRuns of the
+// Pipeline of the PipelineRun so that at most default-max-completed-pipelineruns-per-pipeline
+// are kept. It returns true if the PipelineRun itself was deleted. This limit is in alpha.
+func (c *Reconciler) deleteCompletedPipelineRunsOverLimit(ctx context.Context, pr *v1beta1.PipelineRun) (bool, error) {
+	cfg := config.FromContextOrDefaults(ctx)
+	if cfg.FeatureFlags.EnableAPIFields != config.AlphaAPIFields {
+		return false, nil
+	}
+	limit := cfg.Defaults.DefaultMaxCompletedPipelineRunsPerPipeline
+	pipelineName, ok := pr.Labels[pipeline.PipelineLabelKey]
+	if limit <= config.NoMaxCompletedPipelineRunsPerPipeline || !ok {
+		return false, nil
+	}
+	prs, err := c.pipelineRunLister.PipelineRuns(pr.Namespace).List(labels.SelectorFromSet(labels.Set{pipeline.PipelineLabelKey: pipelineName}))
+	if err != nil {
+		return false, fmt.Errorf("failed to list PipelineRuns of Pipeline %s: %w", pipelineName, err)
+	}
+	var completed []*v1beta1.PipelineRun
+	for _, p := range prs {
+		if p.IsDone() && p.Status.CompletionTime != nil && p.DeletionTimestamp == nil && metav1.GetControllerOf(p) == nil && !deletionBlockedByArchive(ctx, p) {
+			completed = append(completed, p)
+		}
+	}
+	if len(completed) <= limit {
+		return false, nil
+	}
+	// Keep the most recently completed PipelineRuns.
+	sort.Slice(completed, func(i, j int) bool {
+		if completed[i].Status.CompletionTime.Equal(completed[j].Status.CompletionTime) {
+			return completed[i].Name > completed[j].Name
+		}
+		return completed[j].Status.CompletionTime.Before(completed[i].Status.CompletionTime)
+	})
+	deleted := false
+	for _, p := range completed[limit:] {
+		logging.FromContext(ctx).Infof("Deleting PipelineRun %s/%s, Pipeline %s has more than %d completed PipelineRuns", p.Namespace, p.Name, pipelineName, limit)
+		if err := c.deletePipelineRun(ctx, p, pipelinerunmetrics.DeletionReasonMaxCompletedExceeded); err != nil {
+			return deleted, err
+		}
+		if p.Name == pr.Name {
+			deleted = true
+		}
+	}
+	return deleted, nil
+}
+
+func (c *Reconciler) deletePipelineRun(ctx context.Context, pr *v1beta1.PipelineRun, reason string) error {
+	err := c.PipelineClientSet.TektonV1beta1().PipelineRuns(pr.Namespace).Delete(ctx, pr.Name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete PipelineRun %s: %w", pr.Name, err)
+	}
+	if err := c.metrics.DeletedCount(ctx, pr, reason); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to log the metrics : %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/archive"
+)
+
+// archiveTaskRun writes the record of the finished TaskRun to the storage configured
+// in config-archive, unless archiving is disabled or the record was already archived.
+// The outcome is recorded in the annotations of the TaskRun.
+func (c *Reconciler) archiveTaskRun(ctx context.Context, tr *v1beta1.TaskRun) error {
+	cfg := config.FromContextOrDefaults(ctx).Archive
+	if !cfg.Enabled() || archive.IsArchived(tr) {
+		return nil
+	}
+	a, err := archive.New(ctx, c.KubeClientSet, cfg)
+	if err != nil {
+		return err
+	}
+	return archive.Run(ctx, a, tr, v1beta1.SchemeGroupVersion.WithKind("TaskRun"))
+}
+
+// deletionBlockedByArchive returns true if the finished TaskRun can't be deleted yet
+// because its record still has to be archived.
+func deletionBlockedByArchive(ctx context.Context, tr *v1beta1.TaskRun) bool {
+	return config.FromContextOrDefaults(ctx).Archive.Enabled() && !archive.IsArchived(tr)
+}
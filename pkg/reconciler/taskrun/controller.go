@@ -23,6 +23,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	pipelineclient "github.com/tektoncd/pipeline/pkg/client/injection/client"
+	pipelineruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/pipelinerun"
 	taskruninformer "github.com/tektoncd/pipeline/pkg/client/injection/informers/pipeline/v1beta1/taskrun"
 	taskrunreconciler "github.com/tektoncd/pipeline/pkg/client/injection/reconciler/pipeline/v1beta1/taskrun"
 	resourceinformer "github.com/tektoncd/pipeline/pkg/client/resource/injection/informers/resource/v1alpha1/pipelineresource"
@@ -30,6 +31,7 @@ import (
 	cloudeventclient "github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/volumeclaim"
 	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
+	"github.com/tektoncd/pipeline/pkg/tracing"
 	resolutionclient "github.com/tektoncd/resolution/pkg/client/injection/client"
 	resolutioninformer "github.com/tektoncd/resolution/pkg/client/injection/informers/resolution/v1alpha1/resolutionrequest"
 	resolution "github.com/tektoncd/resolution/pkg/resource"
@@ -37,12 +39,17 @@ import (
 	"k8s.io/client-go/tools/cache"
 	kubeclient "knative.dev/pkg/client/injection/kube/client"
 	limitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange"
+	pvcinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim"
 	filteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered"
 	"knative.dev/pkg/configmap"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/logging"
+	"knative.dev/pkg/system"
 )
 
+// TracerProviderName is the name of the service reported by the TaskRun reconciler spans
+const TracerProviderName = "taskrun-reconciler"
+
 // NewController instantiates a new controller.Impl from knative.dev/pkg/controller
 func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(context.Context, configmap.Watcher) *controller.Impl {
 	return func(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
@@ -54,28 +61,37 @@ func NewController(opts *pipeline.Options, clock clock.PassiveClock) func(contex
 		resourceInformer := resourceinformer.Get(ctx)
 		limitrangeInformer := limitrangeinformer.Get(ctx)
 		resolutionInformer := resolutioninformer.Get(ctx)
-		configStore := config.NewStore(logger.Named("config-store"), taskrunmetrics.MetricsOnStore(logger))
+		tracerProvider := tracing.New(TracerProviderName, logger)
+		configStore := config.NewStore(logger.Named("config-store"), taskrunmetrics.MetricsOnStore(logger), tracerProvider.OnStore())
 		configStore.WatchConfigs(cmw)
 
-		entrypointCache, err := pod.NewEntrypointCache(kubeclientset)
+		var entrypointCache pod.EntrypointCache
+		var err error
+		if opts.EntrypointCache.ConfigMapName != "" {
+			entrypointCache, err = pod.NewPersistentEntrypointCache(kubeclientset, cmw, system.Namespace(), opts.EntrypointCache)
+		} else {
+			entrypointCache, err = pod.NewEntrypointCache(kubeclientset)
+		}
 		if err != nil {
 			logger.Fatalf("Error creating entrypoint cache: %v", err)
 		}
 
 		c := &Reconciler{
-			KubeClientSet:       kubeclientset,
-			PipelineClientSet:   pipelineclientset,
-			Images:              opts.Images,
-			Clock:               clock,
-			taskRunLister:       taskRunInformer.Lister(),
-			resourceLister:      resourceInformer.Lister(),
-			limitrangeLister:    limitrangeInformer.Lister(),
-			cloudEventClient:    cloudeventclient.Get(ctx),
-			metrics:             taskrunmetrics.Get(ctx),
-			entrypointCache:     entrypointCache,
-			podLister:           podInformer.Lister(),
-			pvcHandler:          volumeclaim.NewPVCHandler(kubeclientset, logger),
+			KubeClientSet:     kubeclientset,
+			PipelineClientSet: pipelineclientset,
+			Images:            opts.Images,
+			Clock:             clock,
+			taskRunLister:     taskRunInformer.Lister(),
+			resourceLister:    resourceInformer.Lister(),
+			limitrangeLister:  limitrangeInformer.Lister(),
+			cloudEventClient:  cloudeventclient.Get(ctx),
+			metrics:           taskrunmetrics.Get(ctx),
+			entrypointCache:   entrypointCache,
+			podLister:         podInformer.Lister(),
+			pvcHandler: volumeclaim.NewPVCHandler(kubeclientset, pvcinformer.Get(ctx).Lister(), podInformer.Lister(),
+				pipelineruninformer.Get(ctx).Lister(), taskRunInformer.Lister(), logger),
 			resolutionRequester: resolution.NewCRDRequester(resolutionclient.Get(ctx), resolutionInformer.Lister()),
+			tracerProvider:      tracerProvider,
 		}
 		impl := taskrunreconciler.NewImpl(ctx, c, func(impl *controller.Impl) controller.Options {
 			return controller.Options{
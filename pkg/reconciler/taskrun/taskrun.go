@@ -48,6 +48,8 @@ import (
 	_ "github.com/tektoncd/pipeline/pkg/taskrunmetrics/fake" // Make sure the taskrunmetrics are setup
 	"github.com/tektoncd/pipeline/pkg/workspace"
 	resolution "github.com/tektoncd/resolution/pkg/resource"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
@@ -82,6 +84,7 @@ type Reconciler struct {
 	metrics             *taskrunmetrics.Recorder
 	pvcHandler          volumeclaim.PvcHandler
 	resolutionRequester resolution.Requester
+	tracerProvider      trace.TracerProvider
 }
 
 // Check that our Reconciler implements taskrunreconciler.Interface
@@ -95,6 +98,11 @@ var (
 func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkgreconciler.Event {
 	logger := logging.FromContext(ctx)
 	ctx = cloudevent.ToContext(ctx, c.cloudEventClient)
+	ctx, rootSpan := initTracing(ctx, c.tracerProvider, tr)
+	defer rootSpan.End()
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "TaskRun:ReconcileKind")
+	defer span.End()
+	span.SetAttributes(attribute.String("taskrun", tr.Name), attribute.String("namespace", tr.Namespace))
 
 	// Read the initial condition
 	before := tr.Status.GetCondition(apis.ConditionSucceeded)
@@ -139,7 +147,15 @@ func (c *Reconciler) ReconcileKind(ctx context.Context, tr *v1beta1.TaskRun) pkg
 			return err
 		}
 
-		return c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil)
+		if err := c.archiveTaskRun(ctx, tr); err != nil {
+			logger.Errorf("Failed to archive TaskRun %s: %v", tr.Name, err)
+			return c.finishReconcileUpdateEmitEvents(ctx, tr, before, err)
+		}
+
+		if err := c.finishReconcileUpdateEmitEvents(ctx, tr, before, nil); err != nil {
+			return err
+		}
+		return c.deleteExpiredTaskRun(ctx, tr)
 	}
 
 	// If the TaskRun is cancelled, kill resources and update status
@@ -390,7 +406,10 @@ func (c *Reconciler) prepare(ctx context.Context, tr *v1beta1.TaskRun) (*v1beta1
 		return nil, nil, controller.NewPermanentError(err)
 	}
 
-	if _, usesAssistant := tr.Annotations[workspace.AnnotationAffinityAssistantName]; usesAssistant {
+	// When coscheduling PipelineRuns, the Affinity Assistant mounts all the PersistentVolumeClaims of the
+	// PipelineRun, so a TaskRun can bind several of them.
+	coschedulingPipelineRuns := tr.Annotations[workspace.AnnotationCoschedule] == config.CoschedulePipelineRuns
+	if _, usesAssistant := tr.Annotations[workspace.AnnotationAffinityAssistantName]; usesAssistant && !coschedulingPipelineRuns {
 		if err := workspace.ValidateOnlyOnePVCIsUsed(tr.Spec.Workspaces); err != nil {
 			logger.Errorf("TaskRun %q workspaces incompatible with Affinity Assistant: %v", tr.Name, err)
 			tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
@@ -426,6 +445,9 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 
 	ts := updateTaskSpecParamsContextsResults(ctx, tr, rtr)
 	tr.Status.TaskSpec = ts
+	// Substitute the params in the keys of the cache workspaces, from which the names of their PVCs
+	// are derived. This is used by createPod below. Changes to the Spec are not updated.
+	tr.Spec.Workspaces = volumeclaim.ApplyCacheKeyParameters(tr.Spec.Workspaces, tr.Spec.Params, ts.Params)
 
 	// Get the TaskRun's Pod if it should have one. Otherwise, create the Pod.
 	var pod *corev1.Pod
@@ -463,6 +485,13 @@ func (c *Reconciler) reconcile(ctx context.Context, tr *v1beta1.TaskRun, rtr *re
 
 	if pod == nil {
 		if tr.HasVolumeClaimTemplate() {
+			if err := volumeclaim.ValidateVolumeClaimTemplates(ctx, tr.Spec.Workspaces, tr.Namespace); err != nil {
+				logger.Errorf("TaskRun %s workspaces are not allowed: %v", tr.Name, err)
+				tr.Status.MarkResourceFailed(volumeclaim.ReasonVolumeClaimTemplateNotAllowed,
+					fmt.Errorf("TaskRun %s workspaces are not allowed: %s",
+						fmt.Sprintf("%s/%s", tr.Namespace, tr.Name), err))
+				return controller.NewPermanentError(err)
+			}
 			if err := c.pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, tr.Spec.Workspaces, *kmeta.NewControllerRef(tr), tr.Namespace); err != nil {
 				logger.Errorf("Failed to create PVC for TaskRun %s: %v", tr.Name, err)
 				tr.Status.MarkResourceFailed(volumeclaim.ReasonCouldntCreateWorkspacePVC,
@@ -582,6 +611,9 @@ func (c *Reconciler) handlePodCreationError(tr *v1beta1.TaskRun, err error) erro
 		return controller.NewRequeueAfter(time.Minute)
 	case isTaskRunValidationFailed(err):
 		tr.Status.MarkResourceFailed(podconvert.ReasonFailedValidation, err)
+	case errors.Is(err, computeresources.ErrInsufficientTaskLevelRequests):
+		err = controller.NewPermanentError(err)
+		tr.Status.MarkResourceFailed(podconvert.ReasonExceededLimitRange, err)
 	case k8serrors.IsAlreadyExists(err):
 		tr.Status.MarkResourceOngoing(podconvert.ReasonPending, fmt.Sprint("tried to create pod, but it already exists"))
 	default:
@@ -619,6 +651,12 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 		return nil
 	}
 
+	// Record the resources reserved by the pod until now, as it won't be reconciled
+	// once it's deleted.
+	if pod, err := c.podLister.Pods(tr.Namespace).Get(tr.Status.PodName); err == nil {
+		tr.Status.ResourceUsage = podconvert.MakeResourceUsage(&tr.Status, pod)
+	}
+
 	// tr.Status.PodName will be empty if the pod was never successfully created. This condition
 	// can be reached, for example, by the pod never being schedulable due to limits imposed by
 	// a namespace's ResourceQuota.
@@ -659,6 +697,8 @@ func (c *Reconciler) failTaskRun(ctx context.Context, tr *v1beta1.TaskRun, reaso
 // createPod creates a Pod based on the Task's configuration, with pvcName as a volumeMount
 // TODO(dibyom): Refactor resource setup/substitution logic to its own function in the resources package
 func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1beta1.TaskRun, rtr *resources.ResolvedTaskResources) (*corev1.Pod, error) {
+	ctx, span := c.tracerProvider.Tracer(TracerName).Start(ctx, "createPod")
+	defer span.End()
 	logger := logging.FromContext(ctx)
 	inputResources, err := resourceImplBinding(rtr.Inputs, c.Images)
 	if err != nil {
@@ -720,7 +760,7 @@ func (c *Reconciler) createPod(ctx context.Context, ts *v1beta1.TaskSpec, tr *v1
 		EntrypointCache: c.entrypointCache,
 	}
 	pod, err := podbuilder.Build(ctx, tr, *ts,
-		computeresources.NewTransformer(ctx, tr.Namespace, c.limitrangeLister),
+		computeresources.NewTransformer(ctx, tr.Namespace, c.limitrangeLister, tr.Spec.ComputeResources),
 		affinityassistant.NewTransformer(ctx, tr.Annotations),
 	)
 	if err != nil {
@@ -826,21 +866,27 @@ func updateStoppedSidecarStatus(pod *corev1.Pod, tr *v1beta1.TaskRun) error {
 }
 
 // applyVolumeClaimTemplates and return WorkspaceBindings were templates is translated to PersistentVolumeClaims
+// and caches to the PersistentVolumeClaims of the caches
 func applyVolumeClaimTemplates(workspaceBindings []v1beta1.WorkspaceBinding, owner metav1.OwnerReference) []v1beta1.WorkspaceBinding {
 	taskRunWorkspaceBindings := make([]v1beta1.WorkspaceBinding, 0, len(workspaceBindings))
 	for _, wb := range workspaceBindings {
-		if wb.VolumeClaimTemplate == nil {
+		if wb.Cache == nil && wb.VolumeClaimTemplate == nil {
 			taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, wb)
 			continue
 		}
 
-		// apply template
-		b := v1beta1.WorkspaceBinding{
-			Name:    wb.Name,
-			SubPath: wb.SubPath,
-			PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+		// replace the template or the cache by its claim, keeping the other fields of the binding
+		b := wb
+		if wb.Cache != nil {
+			b.Cache = nil
+			b.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
+				ClaimName: volumeclaim.GetCachePersistentVolumeClaimName(wb.Cache),
+			}
+		} else {
+			b.VolumeClaimTemplate = nil
+			b.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{
 				ClaimName: volumeclaim.GetPersistentVolumeClaimName(wb.VolumeClaimTemplate, wb, owner),
-			},
+			}
 		}
 		taskRunWorkspaceBindings = append(taskRunWorkspaceBindings, b)
 	}
@@ -38,6 +38,8 @@ import (
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/pod"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
 	resourcev1alpha1 "github.com/tektoncd/pipeline/pkg/apis/resource/v1alpha1"
+	"github.com/tektoncd/pipeline/pkg/archive"
+	"github.com/tektoncd/pipeline/pkg/internal/computeresources"
 	podconvert "github.com/tektoncd/pipeline/pkg/pod"
 	"github.com/tektoncd/pipeline/pkg/reconciler/events/cloudevent"
 	"github.com/tektoncd/pipeline/pkg/reconciler/taskrun/resources"
@@ -49,6 +51,7 @@ import (
 	"github.com/tektoncd/pipeline/test/names"
 	"github.com/tektoncd/pipeline/test/parse"
 	resolutioncommon "github.com/tektoncd/resolution/pkg/common"
+	"go.opentelemetry.io/otel/trace"
 	corev1 "k8s.io/api/core/v1"
 	k8sapierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -467,7 +470,7 @@ func getRunName(tr *v1beta1.TaskRun) string {
 }
 
 func ensureConfigurationConfigMapsExist(d *test.Data) {
-	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists bool
+	var defaultsExists, featureFlagsExists, artifactBucketExists, artifactPVCExists, metricsExists, tracingExists, archiveExists, stepLogsExists bool
 	for _, cm := range d.ConfigMaps {
 		if cm.Name == config.GetDefaultsConfigName() {
 			defaultsExists = true
@@ -484,6 +487,15 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 		if cm.Name == config.GetMetricsConfigName() {
 			metricsExists = true
 		}
+		if cm.Name == config.GetTracingConfigName() {
+			tracingExists = true
+		}
+		if cm.Name == config.GetArchiveConfigName() {
+			archiveExists = true
+		}
+		if cm.Name == config.GetStepLogsConfigName() {
+			stepLogsExists = true
+		}
 	}
 	if !defaultsExists {
 		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
@@ -515,6 +527,24 @@ func ensureConfigurationConfigMapsExist(d *test.Data) {
 			Data:       map[string]string{},
 		})
 	}
+	if !tracingExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetTracingConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !archiveExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetArchiveConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
+	if !stepLogsExists {
+		d.ConfigMaps = append(d.ConfigMaps, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: config.GetStepLogsConfigName(), Namespace: system.Namespace()},
+			Data:       map[string]string{},
+		})
+	}
 }
 
 // getTaskRunController returns an instance of the TaskRun controller/reconciler that has been seeded with
@@ -2075,6 +2105,178 @@ status:
 	}
 }
 
+func TestReconcileOnCompletedTaskRunWithTTL(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		spec        string
+		controlled  bool
+		wantDelete  bool
+		wantRequeue time.Duration
+	}{{
+		name:       "without ttlSecondsAfterFinished",
+		wantDelete: false,
+	}, {
+		name:       "ttlSecondsAfterFinished expired",
+		spec:       "ttlSecondsAfterFinished: 60",
+		wantDelete: true,
+	}, {
+		name:        "ttlSecondsAfterFinished not expired",
+		spec:        "ttlSecondsAfterFinished: 3600",
+		wantDelete:  false,
+		wantRequeue: 30 * time.Minute,
+	}, {
+		name:       "controlled by a PipelineRun",
+		spec:       "ttlSecondsAfterFinished: 60",
+		controlled: true,
+		wantDelete: false,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			taskRun := parse.MustParseTaskRun(t, fmt.Sprintf(`
+metadata:
+  name: test-taskrun-run-success
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  %s
+status:
+  conditions:
+  - message: Build succeeded
+    reason: Build succeeded
+    status: "True"
+    type: Succeeded
+  startTime: "2021-12-31T23:00:00Z"
+  completionTime: "2021-12-31T23:30:00Z"
+`, tc.spec))
+			if tc.controlled {
+				trueB := true
+				taskRun.OwnerReferences = []metav1.OwnerReference{{
+					APIVersion: "tekton.dev/v1beta1",
+					Kind:       "PipelineRun",
+					Name:       "test-pipelinerun",
+					Controller: &trueB,
+				}}
+			}
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+			}
+
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if tc.wantRequeue != 0 {
+				if ok, requeue := controller.IsRequeueKey(err); !ok || requeue != tc.wantRequeue {
+					t.Errorf("expected a requeue after %s, got %v", tc.wantRequeue, err)
+				}
+			} else if err != nil {
+				t.Fatalf("Unexpected error when reconciling completed TaskRun : %v", err)
+			}
+
+			_, err = clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if deleted := k8sapierrors.IsNotFound(err); deleted != tc.wantDelete {
+				t.Errorf("expected TaskRun deletion %t, got %t", tc.wantDelete, deleted)
+			}
+		})
+	}
+}
+
+func TestReconcileOnCompletedTaskRunArchivesRecord(t *testing.T) {
+	for _, tc := range []struct {
+		name           string
+		location       string
+		spec           string
+		wantErr        bool
+		wantDelete     bool
+		wantAnnotation string
+	}{{
+		name:           "archived",
+		location:       "file://%s",
+		wantAnnotation: archive.StatusArchived,
+	}, {
+		name:       "archived before being deleted",
+		location:   "file://%s",
+		spec:       "ttlSecondsAfterFinished: 60",
+		wantDelete: true,
+	}, {
+		name:           "archiving failed blocks the deletion",
+		location:       "file://%s/not-a-directory",
+		spec:           "ttlSecondsAfterFinished: 60",
+		wantErr:        true,
+		wantAnnotation: archive.StatusFailed,
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			dir := t.TempDir()
+			// The records can't be written under a regular file.
+			if err := os.WriteFile(filepath.Join(dir, "not-a-directory"), nil, 0644); err != nil {
+				t.Fatal(err)
+			}
+			taskRun := parse.MustParseTaskRun(t, fmt.Sprintf(`
+metadata:
+  name: test-taskrun-run-success
+  namespace: foo
+  uid: "1234"
+spec:
+  taskRef:
+    name: test-task
+  %s
+status:
+  conditions:
+  - message: Build succeeded
+    reason: Build succeeded
+    status: "True"
+    type: Succeeded
+  startTime: "2021-12-31T23:00:00Z"
+  completionTime: "2021-12-31T23:30:00Z"
+`, tc.spec))
+			d := test.Data{
+				TaskRuns: []*v1beta1.TaskRun{taskRun},
+				Tasks:    []*v1beta1.Task{simpleTask},
+				ConfigMaps: []*corev1.ConfigMap{{
+					ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetArchiveConfigName()},
+					Data: map[string]string{
+						"location": fmt.Sprintf(tc.location, dir),
+					},
+				}},
+			}
+
+			testAssets, cancel := getTaskRunController(t, d)
+			defer cancel()
+			c := testAssets.Controller
+			clients := testAssets.Clients
+
+			err := c.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("Reconcile() = %v, expected error %t", err, tc.wantErr)
+			}
+
+			record := filepath.Join(dir, "foo", "taskrun", "test-taskrun-run-success-1234.json")
+			if _, err := os.Stat(record); (err == nil) == tc.wantErr {
+				t.Errorf("expected the record to be archived %t, got %v", !tc.wantErr, err)
+			}
+
+			reconciledRun, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+			if deleted := k8sapierrors.IsNotFound(err); deleted != tc.wantDelete {
+				t.Fatalf("expected TaskRun deletion %t, got %t", tc.wantDelete, deleted)
+			}
+			if tc.wantDelete {
+				return
+			}
+			if got := reconciledRun.Annotations[archive.StatusAnnotationKey]; got != tc.wantAnnotation {
+				t.Errorf("expected the archive status %q, got %q", tc.wantAnnotation, got)
+			}
+			if tc.wantAnnotation == archive.StatusArchived {
+				if got, want := reconciledRun.Annotations[archive.LocationAnnotationKey], "file://"+record; got != want {
+					t.Errorf("expected the archive location %q, got %q", want, got)
+				}
+			}
+		})
+	}
+}
+
 func TestReconcileOnCancelledTaskRun(t *testing.T) {
 	taskRun := parse.MustParseTaskRun(t, `
 metadata:
@@ -2463,7 +2665,9 @@ spec:
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		pvcHandler: volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Informers.PVC.Lister(), testAssets.Informers.Pod.Lister(),
+			testAssets.Informers.PipelineRun.Lister(), testAssets.Informers.TaskRun.Lister(), testAssets.Logger),
+		tracerProvider: trace.NewNoopTracerProvider(),
 	}
 
 	rtr := &resources.ResolvedTaskResources{
@@ -2566,7 +2770,9 @@ spec:
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		pvcHandler: volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Informers.PVC.Lister(), testAssets.Informers.Pod.Lister(),
+			testAssets.Informers.PipelineRun.Lister(), testAssets.Informers.TaskRun.Lister(), testAssets.Logger),
+		tracerProvider: trace.NewNoopTracerProvider(),
 	}
 
 	rtr := &resources.ResolvedTaskResources{
@@ -2614,7 +2820,9 @@ status:
 		cloudEventClient:  testAssets.Clients.CloudEvents,
 		metrics:           nil, // Not used
 		entrypointCache:   nil, // Not used
-		pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+		pvcHandler: volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Informers.PVC.Lister(), testAssets.Informers.Pod.Lister(),
+			testAssets.Informers.PipelineRun.Lister(), testAssets.Informers.TaskRun.Lister(), testAssets.Logger),
+		tracerProvider: trace.NewNoopTracerProvider(),
 	}
 
 	testcases := []struct {
@@ -2635,6 +2843,12 @@ status:
 		expectedType:   apis.ConditionSucceeded,
 		expectedStatus: corev1.ConditionFalse,
 		expectedReason: podconvert.ReasonFailedValidation,
+	}, {
+		description:    "task-level compute resources lower than the limitrange minimum fail the taskrun",
+		err:            fmt.Errorf("translating TaskSpec to Pod: %w", computeresources.ErrInsufficientTaskLevelRequests),
+		expectedType:   apis.ConditionSucceeded,
+		expectedStatus: corev1.ConditionFalse,
+		expectedReason: podconvert.ReasonExceededLimitRange,
 	}, {
 		description:    "errors other than exceeded quota fail the taskrun",
 		err:            errors.New("this is a fatal error"),
@@ -3542,6 +3756,64 @@ spec:
 	}
 }
 
+// TestReconcileWithWorkspacesOfCoscheduledPipelineRun tests that a TaskRun of a PipelineRun whose Affinity
+// Assistant was created for the whole PipelineRun can use more than one PVC-backed workspace, whatever the
+// current value of the coschedule feature flag.
+func TestReconcileWithWorkspacesOfCoscheduledPipelineRun(t *testing.T) {
+	taskWithTwoWorkspaces := parse.MustParseTask(t, `
+metadata:
+  name: test-task-two-workspaces
+  namespace: foo
+spec:
+  steps:
+  - command:
+    - /mycmd
+    image: foo
+    name: simple-step
+  workspaces:
+  - description: task workspace
+    name: ws1
+  - description: another workspace
+    name: ws2
+`)
+	taskRun := parse.MustParseTaskRun(t, `
+metadata:
+  annotations:
+    pipeline.tekton.dev/affinity-assistant: dummy-affinity-assistant
+    pipeline.tekton.dev/affinity-assistant-coschedule: pipelineruns
+  name: taskrun-with-two-workspaces
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task-two-workspaces
+  workspaces:
+  - name: ws1
+    persistentVolumeClaim:
+      claimName: pvc1
+  - name: ws2
+    persistentVolumeClaim:
+      claimName: pvc2
+`)
+
+	d := test.Data{
+		Tasks:    []*v1beta1.Task{taskWithTwoWorkspaces},
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+	createServiceAccount(t, testAssets, "default", "foo")
+	_ = testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun))
+
+	ttt, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	if ttt.Status.PodName == "" {
+		t.Errorf("expected a pod to be created for TaskRun %s, got conditions %v", taskRun.Name, ttt.Status.Conditions)
+	}
+}
+
 // TestReconcileWorkspaceWithVolumeClaimTemplate tests a reconcile of a TaskRun that has
 // a Workspace with VolumeClaimTemplate and check that it is translated to a created PersistentVolumeClaim.
 func TestReconcileWorkspaceWithVolumeClaimTemplate(t *testing.T) {
@@ -3613,6 +3885,82 @@ spec:
 	}
 }
 
+// TestReconcileReadOnlyWorkspaceWithVolumeClaimTemplate tests that a readOnly binding of a
+// Workspace with VolumeClaimTemplate is mounted read-only in the pod of the TaskRun.
+func TestReconcileReadOnlyWorkspaceWithVolumeClaimTemplate(t *testing.T) {
+	taskWithWorkspace := parse.MustParseTask(t, `
+metadata:
+  name: test-task-with-workspace
+  namespace: foo
+spec:
+  steps:
+  - command:
+    - /mycmd
+    image: foo
+    name: simple-step
+  workspaces:
+  - description: a test task workspace
+    name: ws1
+`)
+	taskRun := parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun-read-only-workspace
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task-with-workspace
+  workspaces:
+  - name: ws1
+    readOnly: true
+    volumeClaimTemplate:
+      metadata:
+        creationTimestamp: null
+        name: mypvc
+`)
+	d := test.Data{
+		Tasks:    []*v1beta1.Task{taskWithWorkspace},
+		TaskRuns: []*v1beta1.TaskRun{taskRun},
+		ConfigMaps: []*corev1.ConfigMap{{
+			ObjectMeta: metav1.ObjectMeta{Namespace: system.Namespace(), Name: config.GetFeatureFlagsConfigName()},
+			Data: map[string]string{
+				"enable-api-fields": config.AlphaAPIFields,
+			},
+		}},
+	}
+	testAssets, cancel := getTaskRunController(t, d)
+	defer cancel()
+	clients := testAssets.Clients
+	createServiceAccount(t, testAssets, "default", "foo")
+
+	if err := testAssets.Controller.Reconciler.Reconcile(testAssets.Ctx, getRunName(taskRun)); err == nil {
+		t.Error("Wanted a wrapped requeue error, but got nil.")
+	} else if ok, _ := controller.IsRequeueKey(err); !ok {
+		t.Errorf("expected no error reconciling valid TaskRun but got %v", err)
+	}
+
+	tr, err := clients.Pipeline.TektonV1beta1().TaskRuns(taskRun.Namespace).Get(testAssets.Ctx, taskRun.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected TaskRun %s to exist but instead got error when getting it: %v", taskRun.Name, err)
+	}
+	pod, err := clients.Kube.CoreV1().Pods(tr.Namespace).Get(testAssets.Ctx, tr.Status.PodName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("expected pod %s to exist but instead got error when getting it: %v", tr.Status.PodName, err)
+	}
+	found := false
+	for _, vm := range pod.Spec.Containers[0].VolumeMounts {
+		if vm.MountPath != "/workspace/ws1" {
+			continue
+		}
+		found = true
+		if !vm.ReadOnly {
+			t.Errorf("expected the volume mount of workspace ws1 to be read-only")
+		}
+	}
+	if !found {
+		t.Errorf("expected a volume mount for workspace ws1 in %v", pod.Spec.Containers[0].VolumeMounts)
+	}
+}
+
 func TestFailTaskRun(t *testing.T) {
 	testCases := []struct {
 		name               string
@@ -3622,6 +3970,7 @@ func TestFailTaskRun(t *testing.T) {
 		message            string
 		expectedStatus     apis.Condition
 		expectedStepStates []v1beta1.StepState
+		expectedUsage      *v1beta1.ResourceUsage
 	}{{
 		name: "no-pod-scheduled",
 		taskRun: parse.MustParseTaskRun(t, `
@@ -3933,6 +4282,57 @@ status:
 				},
 			},
 		},
+	}, {
+		name: "resource-usage-timeout",
+		taskRun: parse.MustParseTaskRun(t, `
+metadata:
+  name: test-taskrun-run-timeout
+  namespace: foo
+spec:
+  taskRef:
+    name: test-task
+  timeout: 10s
+status:
+  conditions:
+  - status: Unknown
+    type: Succeeded
+  podName: foo-is-bar
+  startTime: "2022-01-01T00:00:00Z"
+  steps:
+  - running:
+      startedAt: "2022-01-01T00:00:00Z"
+`),
+		pod: &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "foo",
+				Name:      "foo-is-bar",
+			},
+			Spec: corev1.PodSpec{Containers: []corev1.Container{{
+				Name: "step-foo",
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+					corev1.ResourceMemory: resource.MustParse("1G"),
+				}},
+			}}},
+			Status: corev1.PodStatus{StartTime: &metav1.Time{Time: testClock.Now().Add(-10 * time.Second)}},
+		},
+		reason:  v1beta1.TaskRunReasonTimedOut,
+		message: "TaskRun test-taskrun-run-timeout failed to finish within 10s",
+		expectedStatus: apis.Condition{
+			Type:    apis.ConditionSucceeded,
+			Status:  corev1.ConditionFalse,
+			Reason:  v1beta1.TaskRunReasonTimedOut.String(),
+			Message: "TaskRun test-taskrun-run-timeout failed to finish within 10s",
+		},
+		expectedUsage: &v1beta1.ResourceUsage{
+			Requests: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+				corev1.ResourceMemory: resource.MustParse("1G"),
+			},
+			Duration:        &metav1.Duration{Duration: 10 * time.Second},
+			CPUSeconds:      resource.NewMilliQuantity(5000, resource.DecimalSI),
+			MemoryGBSeconds: resource.NewMilliQuantity(10000, resource.DecimalSI),
+		},
 	}}
 
 	for _, tc := range testCases {
@@ -3955,10 +4355,12 @@ status:
 				taskRunLister:     testAssets.Informers.TaskRun.Lister(),
 				resourceLister:    testAssets.Informers.PipelineResource.Lister(),
 				limitrangeLister:  testAssets.Informers.LimitRange.Lister(),
+				podLister:         testAssets.Informers.Pod.Lister(),
 				cloudEventClient:  testAssets.Clients.CloudEvents,
 				metrics:           nil, // Not used
 				entrypointCache:   nil, // Not used
-				pvcHandler:        volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Logger),
+				pvcHandler: volumeclaim.NewPVCHandler(testAssets.Clients.Kube, testAssets.Informers.PVC.Lister(), testAssets.Informers.Pod.Lister(),
+					testAssets.Informers.PipelineRun.Lister(), testAssets.Informers.TaskRun.Lister(), testAssets.Logger),
 			}
 
 			err := c.failTaskRun(testAssets.Ctx, tc.taskRun, tc.reason, tc.message)
@@ -3975,6 +4377,9 @@ status:
 					t.Errorf("test %s failed: %s", tc.name, diff.PrintWantGot(c))
 				}
 			}
+			if d := cmp.Diff(tc.expectedUsage, tc.taskRun.Status.ResourceUsage); d != "" {
+				t.Errorf("resource usage %s", diff.PrintWantGot(d))
+			}
 		})
 	}
 }
@@ -0,0 +1,73 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"knative.dev/pkg/logging"
+)
+
+const (
+	// TracerName is the name of the tracer used by the TaskRun reconciler
+	TracerName = "TaskRunReconciler"
+	// SpanContextAnnotation is the annotation used to persist the span context
+	// of the parent span of a TaskRun trace. It is set by the PipelineRun
+	// reconciler on the TaskRuns it creates so that both share a trace.
+	SpanContextAnnotation = "tekton.dev/taskrunSpanContext"
+)
+
+// initTracing returns a context carrying the span context of the TaskRun's
+// trace. The span context is read from the TaskRun annotations if present.
+// Otherwise a new root span is started and its context stored in the
+// annotations, which are persisted at the end of the reconcile. The returned
+// span is the parent of the spans of the reconcile and must be ended once the
+// reconcile is done; it doesn't record anything when the span context comes
+// from the annotations.
+func initTracing(ctx context.Context, tracerProvider trace.TracerProvider, tr *v1beta1.TaskRun) (context.Context, trace.Span) {
+	logger := logging.FromContext(ctx)
+
+	if spanContext, ok := tr.Annotations[SpanContextAnnotation]; ok {
+		tctx, err := tracing.ContextWithSpanContext(ctx, spanContext)
+		if err == nil {
+			return tctx, trace.SpanFromContext(tctx)
+		}
+		logger.Warnf("Ignoring span context of TaskRun %s: %v", tr.Name, err)
+	}
+
+	tctx, span := tracerProvider.Tracer(TracerName).Start(ctx, "TaskRun:Reconciler")
+	span.SetAttributes(attribute.String("taskrun", tr.Name), attribute.String("namespace", tr.Namespace))
+
+	spanContext, err := tracing.MarshalSpanContext(tctx)
+	if err != nil {
+		logger.Warnf("Unable to marshal span context of TaskRun %s: %v", tr.Name, err)
+		return tctx, span
+	}
+	if spanContext == "" {
+		// The tracer provider did not produce a valid span: tracing is disabled.
+		return ctx, span
+	}
+	if tr.Annotations == nil {
+		tr.Annotations = map[string]string{}
+	}
+	tr.Annotations[SpanContextAnnotation] = spanContext
+	return tctx, span
+}
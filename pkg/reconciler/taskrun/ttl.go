@@ -0,0 +1,56 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package taskrun
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/taskrunmetrics"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// deleteExpiredTaskRun deletes the finished TaskRun if its ttlSecondsAfterFinished
+// expired, or returns a requeue event for when it expires. TaskRuns controlled by
+// another resource, such as a PipelineRun, are deleted along with it instead, and
+// TaskRuns are only deleted once their record is archived when archiving is enabled.
+func (c *Reconciler) deleteExpiredTaskRun(ctx context.Context, tr *v1beta1.TaskRun) error {
+	if tr.Spec.TTLSecondsAfterFinished == nil || tr.Status.CompletionTime == nil || metav1.GetControllerOf(tr) != nil || deletionBlockedByArchive(ctx, tr) {
+		return nil
+	}
+	ttl := time.Duration(*tr.Spec.TTLSecondsAfterFinished) * time.Second
+	if remaining := ttl - c.Clock.Since(tr.Status.CompletionTime.Time); remaining > 0 {
+		return controller.NewRequeueAfter(remaining)
+	}
+	logging.FromContext(ctx).Infof("Deleting TaskRun %s/%s, its ttlSecondsAfterFinished of %s expired", tr.Namespace, tr.Name, ttl)
+	err := c.PipelineClientSet.TektonV1beta1().TaskRuns(tr.Namespace).Delete(ctx, tr.Name, metav1.DeleteOptions{})
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete TaskRun %s: %w", tr.Name, err)
+	}
+	if err := c.metrics.DeletedCount(ctx, tr, taskrunmetrics.DeletionReasonTTLExpired); err != nil {
+		logging.FromContext(ctx).Warnf("Failed to log the metrics : %v", err)
+	}
+	return nil
+}
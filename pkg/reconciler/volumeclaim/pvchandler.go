@@ -20,41 +20,87 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"sort"
+	"time"
 
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"github.com/tektoncd/pipeline/pkg/apis/pipeline"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/pkg/substitution"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
 	errorutils "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	clientset "k8s.io/client-go/kubernetes"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 )
 
 const (
 	// ReasonCouldntCreateWorkspacePVC indicates that a Pipeline expects a workspace from a
 	// volumeClaimTemplate but couldn't create a claim.
 	ReasonCouldntCreateWorkspacePVC = "CouldntCreateWorkspacePVC"
+	// ReasonVolumeClaimTemplateNotAllowed indicates that a workspace volumeClaimTemplate
+	// doesn't comply with the policy configured for the namespace.
+	ReasonVolumeClaimTemplateNotAllowed = "VolumeClaimTemplateNotAllowed"
+
+	// CacheKeyLabelKey is the label identifying the PVCs of cache workspaces, set
+	// to the hash of their cache key.
+	CacheKeyLabelKey = pipeline.GroupName + "/cacheKey"
+	// CacheKeyAnnotationKey is the annotation holding the cache key of the PVC
+	// of a cache workspace.
+	CacheKeyAnnotationKey = pipeline.GroupName + "/cacheKey"
+	// CacheLastUsedAnnotationKey is the annotation holding the last time the PVC
+	// of a cache workspace was bound to a run.
+	CacheLastUsedAnnotationKey = pipeline.GroupName + "/cacheLastUsed"
+	// WorkspacePVCCleanupAnnotationKey is the annotation of a PipelineRun overriding the
+	// "workspace-pvc-cleanup" feature flag for its PVCs.
+	WorkspacePVCCleanupAnnotationKey = pipeline.GroupName + "/workspacePVCCleanup"
 )
 
 // PvcHandler is used to create PVCs for workspaces
 type PvcHandler interface {
 	CreatePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
+	GetReadWriteOnceWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, namespace string) (sets.String, error)
+	DeletePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error
+	PruneCachePersistentVolumeClaims(ctx context.Context, owns func(namespace string) bool) error
 }
 
 type defaultPVCHandler struct {
-	clientset clientset.Interface
-	logger    *zap.SugaredLogger
+	clientset         clientset.Interface
+	pvcLister         corev1listers.PersistentVolumeClaimLister
+	podLister         corev1listers.PodLister
+	pipelineRunLister listers.PipelineRunLister
+	taskRunLister     listers.TaskRunLister
+	logger            *zap.SugaredLogger
 }
 
-// NewPVCHandler returns a new defaultPVCHandler
-func NewPVCHandler(clientset clientset.Interface, logger *zap.SugaredLogger) PvcHandler {
-	return &defaultPVCHandler{clientset, logger}
+// NewPVCHandler returns a new defaultPVCHandler, which reads the PVCs, the pods and the runs of the namespaces
+// from the informer caches through the given listers.
+func NewPVCHandler(clientset clientset.Interface, pvcLister corev1listers.PersistentVolumeClaimLister, podLister corev1listers.PodLister,
+	pipelineRunLister listers.PipelineRunLister, taskRunLister listers.TaskRunLister, logger *zap.SugaredLogger) PvcHandler {
+	return &defaultPVCHandler{
+		clientset:         clientset,
+		pvcLister:         pvcLister,
+		podLister:         podLister,
+		pipelineRunLister: pipelineRunLister,
+		taskRunLister:     taskRunLister,
+		logger:            logger,
+	}
 }
 
 // CreatePersistentVolumeClaimsForWorkspaces checks if a PVC named <claim-name>-<workspace-name>-<owner-name> exists;
 // where claim-name is provided by the user in the volumeClaimTemplate, and owner-name is the name of the
 // resource with the volumeClaimTemplate declared, a PipelineRun or TaskRun. If the PVC did not exist, a new PVC
 // with that name is created with the provided OwnerReference.
+// The PVCs of cache workspaces are created without OwnerReference if they don't exist, and are marked as used
+// otherwise. The least recently used caches of the namespace are then deleted according to the configured
+// TTL and maximum count.
 func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error {
 	var errs []error
 	for _, claim := range getPersistentVolumeClaims(wb, ownerReference, namespace) {
@@ -72,9 +118,382 @@ func (c *defaultPVCHandler) CreatePersistentVolumeClaimsForWorkspaces(ctx contex
 			errs = append(errs, fmt.Errorf("failed to retrieve PVC %s: %s", claim.Name, err))
 		}
 	}
+
+	cacheClaims := getCachePersistentVolumeClaims(wb, namespace, time.Now())
+	for _, claim := range cacheClaims {
+		if err := c.useCachePersistentVolumeClaim(ctx, claim); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(cacheClaims) > 0 {
+		// Failing to evict caches must not fail the run using a cache.
+		if err := c.pruneCachePersistentVolumeClaims(ctx, namespace, cacheClaims); err != nil {
+			c.logger.Errorf("Failed to prune cache PersistentVolumeClaims in namespace %s: %v", namespace, err)
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// DeletePersistentVolumeClaimsForWorkspaces deletes the PVCs created from the volumeClaimTemplates of the workspace
+// bindings for the owner, unless they are already being deleted. The PVCs of cache workspaces and the PVCs provided
+// by users are kept.
+func (c *defaultPVCHandler) DeletePersistentVolumeClaimsForWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) error {
+	var errs []error
+	for _, claim := range getPersistentVolumeClaims(wb, ownerReference, namespace) {
+		pvc, err := c.pvcLister.PersistentVolumeClaims(namespace).Get(claim.Name)
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			errs = append(errs, fmt.Errorf("failed to retrieve PVC %s: %s", claim.Name, err))
+			continue
+		case pvc.DeletionTimestamp != nil:
+			continue
+		}
+
+		err = c.clientset.CoreV1().PersistentVolumeClaims(namespace).Delete(ctx, claim.Name, metav1.DeleteOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			continue
+		case err != nil:
+			errs = append(errs, fmt.Errorf("failed to delete PVC %s: %s", claim.Name, err))
+			continue
+		}
+		c.logger.Infof("Deleted PersistentVolumeClaim %s in namespace %s", claim.Name, namespace)
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// ValidateVolumeClaimTemplates checks that the volumeClaimTemplates of the workspace bindings comply with the
+// VolumeClaimTemplatePolicy configured for the namespace.
+func ValidateVolumeClaimTemplates(ctx context.Context, wb []v1beta1.WorkspaceBinding, namespace string) error {
+	policies := config.FromContextOrDefaults(ctx).Defaults.DefaultVolumeClaimTemplatePolicies
+	if policies == nil {
+		return nil
+	}
+	policy := policies.ForNamespace(namespace)
+	for _, workspaceBinding := range wb {
+		if workspaceBinding.VolumeClaimTemplate != nil {
+			if err := policy.Validate(workspaceBinding.VolumeClaimTemplate); err != nil {
+				return fmt.Errorf("volumeClaimTemplate of workspace %s is not allowed: %w", workspaceBinding.Name, err)
+			}
+		}
+		if workspaceBinding.Cache != nil {
+			if err := policy.Validate(&workspaceBinding.Cache.VolumeClaimTemplate); err != nil {
+				return fmt.Errorf("cache volumeClaimTemplate of workspace %s is not allowed: %w", workspaceBinding.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// GetReadWriteOnceWorkspaces returns the names of the workspaces bound to a PersistentVolumeClaim which can only
+// be mounted by a single node at a time, according to the access modes of the claim. The claims of
+// volumeClaimTemplate and cache workspaces are not looked up since their access modes are those of the template.
+func (c *defaultPVCHandler) GetReadWriteOnceWorkspaces(ctx context.Context, wb []v1beta1.WorkspaceBinding, namespace string) (sets.String, error) {
+	workspaces := sets.NewString()
+	for _, workspaceBinding := range wb {
+		var accessModes []corev1.PersistentVolumeAccessMode
+		switch {
+		case workspaceBinding.PersistentVolumeClaim != nil:
+			claim, err := c.pvcLister.PersistentVolumeClaims(namespace).Get(workspaceBinding.PersistentVolumeClaim.ClaimName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to retrieve PVC %s: %w", workspaceBinding.PersistentVolumeClaim.ClaimName, err)
+			}
+			accessModes = claim.Spec.AccessModes
+		case workspaceBinding.VolumeClaimTemplate != nil:
+			accessModes = workspaceBinding.VolumeClaimTemplate.Spec.AccessModes
+		case workspaceBinding.Cache != nil:
+			accessModes = workspaceBinding.Cache.VolumeClaimTemplate.Spec.AccessModes
+		default:
+			continue
+		}
+		if IsReadWriteOnce(accessModes) {
+			workspaces.Insert(workspaceBinding.Name)
+		}
+	}
+	return workspaces, nil
+}
+
+// IsReadWriteOnce returns true if a PersistentVolumeClaim with the given access modes can only be mounted
+// by a single node at a time.
+func IsReadWriteOnce(accessModes []corev1.PersistentVolumeAccessMode) bool {
+	if len(accessModes) == 0 {
+		return false
+	}
+	for _, accessMode := range accessModes {
+		if accessMode != corev1.ReadWriteOnce && accessMode != corev1.ReadWriteOncePod {
+			return false
+		}
+	}
+	return true
+}
+
+// useCachePersistentVolumeClaim creates the PVC of a cache workspace, or marks
+// the existing PVC as used.
+func (c *defaultPVCHandler) useCachePersistentVolumeClaim(ctx context.Context, claim *corev1.PersistentVolumeClaim) error {
+	_, err := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Create(ctx, claim, metav1.CreateOptions{})
+	switch {
+	case err == nil:
+		c.logger.Infof("Created cache PersistentVolumeClaim %s in namespace %s", claim.Name, claim.Namespace)
+		return nil
+	case !apierrors.IsAlreadyExists(err):
+		return fmt.Errorf("failed to create PVC %s: %s", claim.Name, err)
+	}
+
+	patch := fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`, CacheLastUsedAnnotationKey, claim.Annotations[CacheLastUsedAnnotationKey])
+	if _, err := c.clientset.CoreV1().PersistentVolumeClaims(claim.Namespace).Patch(ctx, claim.Name, types.MergePatchType, []byte(patch), metav1.PatchOptions{}); err != nil {
+		return fmt.Errorf("failed to update PVC %s: %s", claim.Name, err)
+	}
+	return nil
+}
+
+// PruneCachePersistentVolumeClaims prunes the PVCs of the cache workspaces of the namespaces that owns returns true
+// for, so that the expired caches are deleted even when no run uses a cache in their namespace anymore.
+func (c *defaultPVCHandler) PruneCachePersistentVolumeClaims(ctx context.Context, owns func(namespace string) bool) error {
+	pvcs, err := c.pvcLister.List(cacheSelector())
+	if err != nil {
+		return err
+	}
+	namespaces := sets.NewString()
+	for _, pvc := range pvcs {
+		if owns(pvc.Namespace) {
+			namespaces.Insert(pvc.Namespace)
+		}
+	}
+	var errs []error
+	for _, namespace := range namespaces.List() {
+		if err := c.pruneCachePersistentVolumeClaims(ctx, namespace, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to prune cache PVCs in namespace %s: %w", namespace, err))
+		}
+	}
+	return errorutils.NewAggregate(errs)
+}
+
+// cacheSelector selects the PVCs of the cache workspaces.
+func cacheSelector() labels.Selector {
+	requirement, _ := labels.NewRequirement(CacheKeyLabelKey, selection.Exists, nil)
+	return labels.NewSelector().Add(*requirement)
+}
+
+// pruneCachePersistentVolumeClaims deletes the PVCs of the cache workspaces of the
+// namespace that were not used within the configured TTL, then the least recently
+// used PVCs above the configured maximum count. The PVCs in use by the run being
+// reconciled, by the runs that are not done and by the pods that are not terminated
+// are kept.
+func (c *defaultPVCHandler) pruneCachePersistentVolumeClaims(ctx context.Context, namespace string, inUse map[string]*corev1.PersistentVolumeClaim) error {
+	defaults := config.FromContextOrDefaults(ctx).Defaults
+	ttl := time.Duration(defaults.DefaultCacheWorkspaceTTLMinutes) * time.Minute
+	maxCount := defaults.DefaultMaxCacheWorkspacesCount
+
+	pvcs, err := c.pvcLister.PersistentVolumeClaims(namespace).List(cacheSelector())
+	if err != nil {
+		return err
+	}
+	if len(pvcs) == 0 {
+		return nil
+	}
+	inUseNames, err := c.getPersistentVolumeClaimsInUse(namespace)
+	if err != nil {
+		return err
+	}
+	for _, claim := range inUse {
+		inUseNames.Insert(claim.Name)
+	}
+
+	now := time.Now()
+	var candidates []*corev1.PersistentVolumeClaim
+	var errs []error
+	count := 0
+	for _, pvc := range pvcs {
+		if pvc.DeletionTimestamp != nil {
+			continue
+		}
+		if inUseNames.Has(pvc.Name) {
+			count++
+			continue
+		}
+		if ttl > 0 && now.Sub(cacheLastUsed(pvc)) > ttl {
+			errs = append(errs, c.deleteCachePersistentVolumeClaim(ctx, pvc))
+			continue
+		}
+		count++
+		candidates = append(candidates, pvc)
+	}
+
+	if maxCount > 0 && count > maxCount {
+		sort.Slice(candidates, func(i, j int) bool {
+			return cacheLastUsed(candidates[i]).Before(cacheLastUsed(candidates[j]))
+		})
+		for _, pvc := range candidates {
+			if count <= maxCount {
+				break
+			}
+			errs = append(errs, c.deleteCachePersistentVolumeClaim(ctx, pvc))
+			count--
+		}
+	}
 	return errorutils.NewAggregate(errs)
 }
 
+// getPersistentVolumeClaimsInUse returns the names of the PVCs of the namespace bound by the PipelineRuns
+// and TaskRuns that are not done, or mounted by the pods that are not terminated.
+func (c *defaultPVCHandler) getPersistentVolumeClaimsInUse(namespace string) (sets.String, error) {
+	claims := sets.NewString()
+	prs, err := c.pipelineRunLister.PipelineRuns(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list PipelineRuns: %w", err)
+	}
+	for _, pr := range prs {
+		if pr.IsDone() {
+			continue
+		}
+		var paramSpecs []v1beta1.ParamSpec
+		if pr.Status.PipelineSpec != nil {
+			paramSpecs = pr.Status.PipelineSpec.Params
+		}
+		claims.Insert(getClaimNames(ApplyCacheKeyParameters(pr.Spec.Workspaces, pr.Spec.Params, paramSpecs))...)
+	}
+	trs, err := c.taskRunLister.TaskRuns(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list TaskRuns: %w", err)
+	}
+	for _, tr := range trs {
+		if tr.IsDone() {
+			continue
+		}
+		var paramSpecs []v1beta1.ParamSpec
+		if tr.Status.TaskSpec != nil {
+			paramSpecs = tr.Status.TaskSpec.Params
+		}
+		claims.Insert(getClaimNames(ApplyCacheKeyParameters(tr.Spec.Workspaces, tr.Spec.Params, paramSpecs))...)
+	}
+	pods, err := c.podLister.Pods(namespace).List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods: %w", err)
+	}
+	for _, pod := range pods {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				claims.Insert(volume.PersistentVolumeClaim.ClaimName)
+			}
+		}
+	}
+	return claims, nil
+}
+
+// getClaimNames returns the names of the PVCs bound by the workspace bindings which are
+// caches or PVCs.
+func getClaimNames(wb []v1beta1.WorkspaceBinding) []string {
+	var names []string
+	for _, workspaceBinding := range wb {
+		switch {
+		case workspaceBinding.Cache != nil:
+			names = append(names, GetCachePersistentVolumeClaimName(workspaceBinding.Cache))
+		case workspaceBinding.PersistentVolumeClaim != nil:
+			names = append(names, workspaceBinding.PersistentVolumeClaim.ClaimName)
+		}
+	}
+	return names
+}
+
+func (c *defaultPVCHandler) deleteCachePersistentVolumeClaim(ctx context.Context, pvc *corev1.PersistentVolumeClaim) error {
+	if err := c.clientset.CoreV1().PersistentVolumeClaims(pvc.Namespace).Delete(ctx, pvc.Name, metav1.DeleteOptions{}); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete PVC %s: %s", pvc.Name, err)
+	}
+	c.logger.Infof("Deleted cache PersistentVolumeClaim %s in namespace %s", pvc.Name, pvc.Namespace)
+	return nil
+}
+
+// cacheLastUsed returns the last time the PVC of a cache workspace was used, or
+// its creation time if it can't be determined.
+func cacheLastUsed(pvc *corev1.PersistentVolumeClaim) time.Time {
+	if t, err := time.Parse(time.RFC3339, pvc.Annotations[CacheLastUsedAnnotationKey]); err == nil {
+		return t
+	}
+	return pvc.CreationTimestamp.Time
+}
+
+// ApplyCacheKeyParameters returns the workspace bindings of a run with its params substituted in the keys
+// of the caches, so that a cache key can be derived from a param such as the hash of a lockfile. The
+// paramSpecs are the params declared by the Pipeline or the Task of the run, whose defaults are used
+// for the params the run doesn't set.
+func ApplyCacheKeyParameters(wb []v1beta1.WorkspaceBinding, params []v1beta1.Param, paramSpecs []v1beta1.ParamSpec) []v1beta1.WorkspaceBinding {
+	hasCache := false
+	for _, workspaceBinding := range wb {
+		if workspaceBinding.Cache != nil {
+			hasCache = true
+			break
+		}
+	}
+	if !hasCache {
+		return wb
+	}
+
+	replacements := map[string]string{}
+	addReplacements := func(name string, value v1beta1.ArrayOrString) {
+		switch value.Type {
+		case v1beta1.ParamTypeObject:
+			for k, v := range value.ObjectVal {
+				replacements[fmt.Sprintf("params.%s.%s", name, k)] = v
+			}
+		case v1beta1.ParamTypeArray:
+			// A cache key is a string, which an array can't be substituted in.
+		default:
+			for _, pattern := range []string{"params.%s", "params[%q]", "params['%s']"} {
+				replacements[fmt.Sprintf(pattern, name)] = value.StringVal
+			}
+		}
+	}
+	for _, p := range paramSpecs {
+		if p.Default != nil {
+			addReplacements(p.Name, *p.Default)
+		}
+	}
+	for _, p := range params {
+		addReplacements(p.Name, p.Value)
+	}
+
+	bindings := make([]v1beta1.WorkspaceBinding, 0, len(wb))
+	for _, workspaceBinding := range wb {
+		if workspaceBinding.Cache != nil {
+			workspaceBinding = *workspaceBinding.DeepCopy()
+			workspaceBinding.Cache.Key = substitution.ApplyReplacements(workspaceBinding.Cache.Key, replacements)
+		}
+		bindings = append(bindings, workspaceBinding)
+	}
+	return bindings
+}
+
+func getCachePersistentVolumeClaims(workspaceBindings []v1beta1.WorkspaceBinding, namespace string, now time.Time) map[string]*corev1.PersistentVolumeClaim {
+	claims := make(map[string]*corev1.PersistentVolumeClaim)
+	for _, workspaceBinding := range workspaceBindings {
+		if workspaceBinding.Cache == nil {
+			continue
+		}
+
+		claim := workspaceBinding.Cache.VolumeClaimTemplate.DeepCopy()
+		claim.Name = GetCachePersistentVolumeClaimName(workspaceBinding.Cache)
+		claim.Namespace = namespace
+		// The claim outlives the run, so it isn't owned by it.
+		claim.OwnerReferences = nil
+		if claim.Labels == nil {
+			claim.Labels = map[string]string{}
+		}
+		claim.Labels[CacheKeyLabelKey] = getCacheKeyIdentity(workspaceBinding.Cache.Key)
+		if claim.Annotations == nil {
+			claim.Annotations = map[string]string{}
+		}
+		claim.Annotations[CacheKeyAnnotationKey] = workspaceBinding.Cache.Key
+		claim.Annotations[CacheLastUsedAnnotationKey] = now.UTC().Format(time.RFC3339)
+		claims[workspaceBinding.Name] = claim
+	}
+	return claims
+}
+
 func getPersistentVolumeClaims(workspaceBindings []v1beta1.WorkspaceBinding, ownerReference metav1.OwnerReference, namespace string) map[string]*corev1.PersistentVolumeClaim {
 	claims := make(map[string]*corev1.PersistentVolumeClaim)
 	for _, workspaceBinding := range workspaceBindings {
@@ -108,3 +527,19 @@ func getPersistentVolumeClaimIdentity(workspaceName, ownerName string) string {
 	hashString := fmt.Sprintf("%x", hashBytes)
 	return hashString[:10]
 }
+
+// GetCachePersistentVolumeClaimName gets the name of the PersistentVolumeClaim of a cache Workspace. The name
+// only depends on the name of the template and on the cache key, so that every PipelineRun or TaskRun using
+// the same cache key in a namespace binds the same PersistentVolumeClaim.
+func GetCachePersistentVolumeClaimName(cache *v1beta1.CacheWorkspaceBinding) string {
+	if cache.VolumeClaimTemplate.Name == "" {
+		return fmt.Sprintf("%s-%s", "cache", getCacheKeyIdentity(cache.Key))
+	}
+	return fmt.Sprintf("%s-%s", cache.VolumeClaimTemplate.Name, getCacheKeyIdentity(cache.Key))
+}
+
+func getCacheKeyIdentity(key string) string {
+	hashBytes := sha256.Sum256([]byte(key))
+	hashString := fmt.Sprintf("%x", hashBytes)
+	return hashString[:10]
+}
@@ -19,14 +19,27 @@ package volumeclaim
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/tektoncd/pipeline/pkg/apis/config"
 	"github.com/tektoncd/pipeline/pkg/apis/pipeline/v1beta1"
+	listers "github.com/tektoncd/pipeline/pkg/client/listers/pipeline/v1beta1"
+	"github.com/tektoncd/pipeline/test/diff"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	fakek8s "k8s.io/client-go/kubernetes/fake"
+	corev1listers "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 const actionCreate = "create"
@@ -34,6 +47,51 @@ const actionCreate = "create"
 // check that defaultPVCHandler implements PvcHandler
 var _ PvcHandler = (*defaultPVCHandler)(nil)
 
+// newTestPVCHandler returns a defaultPVCHandler whose listers hold the PVCs and the pods of the clientset and
+// the given PipelineRuns and TaskRuns.
+func newTestPVCHandler(t *testing.T, clientset *fakek8s.Clientset, pipelineObjects ...runtime.Object) *defaultPVCHandler {
+	t.Helper()
+	newIndexer := func() cache.Indexer {
+		return cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	}
+	pvcs, pods, pipelineRuns, taskRuns := newIndexer(), newIndexer(), newIndexer(), newIndexer()
+	pvcList, err := clientset.CoreV1().PersistentVolumeClaims("").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range pvcList.Items {
+		if err := pvcs.Add(&pvcList.Items[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	podList, err := clientset.CoreV1().Pods("").List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i := range podList.Items {
+		if err := pods.Add(&podList.Items[i]); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	for _, o := range pipelineObjects {
+		indexer := taskRuns
+		if _, ok := o.(*v1beta1.PipelineRun); ok {
+			indexer = pipelineRuns
+		}
+		if err := indexer.Add(o); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	return &defaultPVCHandler{
+		clientset:         clientset,
+		pvcLister:         corev1listers.NewPersistentVolumeClaimLister(pvcs),
+		podLister:         corev1listers.NewPodLister(pods),
+		pipelineRunLister: listers.NewPipelineRunLister(pipelineRuns),
+		taskRunLister:     listers.NewTaskRunLister(taskRuns),
+		logger:            zap.NewExample().Sugar(),
+	}
+}
+
 // TestCreatePersistentVolumeClaimsForWorkspaces tests that given a TaskRun with volumeClaimTemplate workspace,
 // a PVC is created, with the expected name and that it has the expected OwnerReference.
 func TestCreatePersistentVolumeClaimsForWorkspaces(t *testing.T) {
@@ -73,7 +131,7 @@ func TestCreatePersistentVolumeClaimsForWorkspaces(t *testing.T) {
 	ownerRef := metav1.OwnerReference{UID: types.UID(ownerName)}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
 
 	// when
 
@@ -138,7 +196,7 @@ func TestCreatePersistentVolumeClaimsForWorkspacesWithoutMetadata(t *testing.T)
 	ownerRef := metav1.OwnerReference{UID: types.UID(ownerName)}
 	namespace := "ns"
 	fakekubeclient := fakek8s.NewSimpleClientset()
-	pvcHandler := defaultPVCHandler{fakekubeclient, zap.NewExample().Sugar()}
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
 
 	// when
 
@@ -159,3 +217,465 @@ func TestCreatePersistentVolumeClaimsForWorkspacesWithoutMetadata(t *testing.T)
 		t.Fatalf("unexpected PVC name on created PVC; exptected: %s got: %s", expectedPVCName, pvc.Name)
 	}
 }
+
+// TestCreatePersistentVolumeClaimsForCacheWorkspaces tests that given a cache workspace, a PVC is created
+// without OwnerReference the first time, and that it is reused afterwards.
+func TestCreatePersistentVolumeClaimsForCacheWorkspaces(t *testing.T) {
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name: "cache",
+		Cache: &v1beta1.CacheWorkspaceBinding{
+			Key: "go-mod-1234",
+			VolumeClaimTemplate: corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "gocache",
+				},
+			},
+		},
+	}}
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	namespace := "ns"
+	fakekubeclient := fakek8s.NewSimpleClientset()
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+
+	for _, owner := range []string{"pipelinerun1", "pipelinerun2"} {
+		if err := pvcHandler.CreatePersistentVolumeClaimsForWorkspaces(ctx, workspaces, metav1.OwnerReference{UID: types.UID(owner)}, namespace); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	pvcs, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pvcs.Items) != 1 {
+		t.Fatalf("expected the cache PVC to be reused, got %d PVCs", len(pvcs.Items))
+	}
+	pvc := pvcs.Items[0]
+	expectedPVCName := "gocache-" + getCacheKeyIdentity("go-mod-1234")
+	if pvc.Name != expectedPVCName {
+		t.Errorf("unexpected PVC name on created PVC; expected: %s got: %s", expectedPVCName, pvc.Name)
+	}
+	if len(pvc.OwnerReferences) != 0 {
+		t.Errorf("expected no ownerreferences on the cache PVC, got %v", pvc.OwnerReferences)
+	}
+	if pvc.Labels[CacheKeyLabelKey] == "" || pvc.Annotations[CacheKeyAnnotationKey] != "go-mod-1234" || pvc.Annotations[CacheLastUsedAnnotationKey] == "" {
+		t.Errorf("expected the cache PVC to be labeled and annotated with its key, got labels %v annotations %v", pvc.Labels, pvc.Annotations)
+	}
+}
+
+// TestPruneCachePersistentVolumeClaims tests that the expired cache PVCs are deleted, then the least recently
+// used above the maximum count, and that the cache PVCs in use are kept.
+func TestPruneCachePersistentVolumeClaims(t *testing.T) {
+	now := time.Now()
+	cachePVC := func(name string, lastUsed time.Duration) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "ns",
+				Labels:      map[string]string{CacheKeyLabelKey: name},
+				Annotations: map[string]string{CacheLastUsedAnnotationKey: now.Add(-lastUsed).UTC().Format(time.RFC3339)},
+			},
+		}
+	}
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		cachePVC("in-use", 3*time.Hour),
+		cachePVC("expired", 2*time.Hour),
+		cachePVC("old", 50*time.Minute),
+		cachePVC("recent", time.Minute),
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "not-a-cache", Namespace: "ns"}},
+	)
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultCacheWorkspaceTTLMinutes: 60,
+			DefaultMaxCacheWorkspacesCount:  2,
+		},
+	})
+	inUse := map[string]*corev1.PersistentVolumeClaim{"ws": cachePVC("in-use", 0)}
+	if err := pvcHandler.pruneCachePersistentVolumeClaims(ctx, "ns", inUse); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pvcs, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, pvc := range pvcs.Items {
+		got = append(got, pvc.Name)
+	}
+	want := []string{"in-use", "not-a-cache", "recent"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected PVCs %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestPruneCachePersistentVolumeClaimsInUse tests that the expired cache PVCs bound by the runs that are not done
+// or mounted by the pods that are not terminated are kept, and that those of the done runs are deleted.
+func TestPruneCachePersistentVolumeClaimsInUse(t *testing.T) {
+	lastUsed := time.Now().Add(-2 * time.Hour).UTC().Format(time.RFC3339)
+	cachePVC := func(name string) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   "ns",
+				Labels:      map[string]string{CacheKeyLabelKey: name},
+				Annotations: map[string]string{CacheLastUsedAnnotationKey: lastUsed},
+			},
+		}
+	}
+	cacheBinding := func(key string) []v1beta1.WorkspaceBinding {
+		return []v1beta1.WorkspaceBinding{{
+			Name: "cache",
+			Cache: &v1beta1.CacheWorkspaceBinding{
+				Key:                 key,
+				VolumeClaimTemplate: corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "cache"}},
+			},
+		}}
+	}
+	cacheName := func(key string) string {
+		return "cache-" + getCacheKeyIdentity(key)
+	}
+	done := duckv1beta1.Status{Conditions: duckv1beta1.Conditions{{Type: apis.ConditionSucceeded, Status: corev1.ConditionTrue}}}
+
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		cachePVC(cacheName("pipelinerun-running")),
+		cachePVC(cacheName("pipelinerun-done")),
+		cachePVC(cacheName("taskrun-running")),
+		cachePVC("taskrun-claim"),
+		cachePVC("pod-running"),
+		cachePVC("pod-succeeded"),
+		&corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "other-namespace",
+				Namespace:   "other",
+				Labels:      map[string]string{CacheKeyLabelKey: "other-namespace"},
+				Annotations: map[string]string{CacheLastUsedAnnotationKey: lastUsed},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "ns"},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "cache",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pod-running"}},
+			}}},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "succeeded", Namespace: "ns"},
+			Spec: corev1.PodSpec{Volumes: []corev1.Volume{{
+				Name:         "cache",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "pod-succeeded"}},
+			}}},
+			Status: corev1.PodStatus{Phase: corev1.PodSucceeded},
+		},
+	)
+	pipelineObjects := []runtime.Object{
+		&v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "ns"},
+			Spec:       v1beta1.PipelineRunSpec{Workspaces: cacheBinding("pipelinerun-$(params.state)")},
+			Status: v1beta1.PipelineRunStatus{PipelineRunStatusFields: v1beta1.PipelineRunStatusFields{
+				PipelineSpec: &v1beta1.PipelineSpec{Params: []v1beta1.ParamSpec{{
+					Name:    "state",
+					Type:    v1beta1.ParamTypeString,
+					Default: v1beta1.NewArrayOrString("running"),
+				}}},
+			}},
+		},
+		&v1beta1.PipelineRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "done", Namespace: "ns"},
+			Spec:       v1beta1.PipelineRunSpec{Workspaces: cacheBinding("pipelinerun-done")},
+			Status:     v1beta1.PipelineRunStatus{Status: done},
+		},
+		&v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: "running", Namespace: "ns"},
+			Spec: v1beta1.TaskRunSpec{Workspaces: append(cacheBinding("taskrun-running"), v1beta1.WorkspaceBinding{
+				Name:                  "claim",
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "taskrun-claim"},
+			})},
+		},
+	}
+	pvcHandler := newTestPVCHandler(t, fakekubeclient, pipelineObjects...)
+
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{DefaultCacheWorkspaceTTLMinutes: 60},
+	})
+	// The namespaces pruned by other controller replicas are skipped.
+	if err := pvcHandler.PruneCachePersistentVolumeClaims(ctx, func(namespace string) bool { return namespace == "ns" }); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := fakekubeclient.CoreV1().PersistentVolumeClaims("other").Get(ctx, "other-namespace", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected the cache PVC of a namespace which isn't owned to be kept: %v", err)
+	}
+
+	pvcs, err := fakekubeclient.CoreV1().PersistentVolumeClaims("ns").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, pvc := range pvcs.Items {
+		got = append(got, pvc.Name)
+	}
+	want := []string{cacheName("pipelinerun-running"), cacheName("taskrun-running"), "pod-running", "taskrun-claim"}
+	if d := cmp.Diff(want, got, cmpopts.SortSlices(func(i, j string) bool { return i < j })); d != "" {
+		t.Errorf("unexpected PVCs %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestApplyCacheKeyParameters(t *testing.T) {
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name:     "source",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}, {
+		Name: "cache",
+		Cache: &v1beta1.CacheWorkspaceBinding{
+			Key: "go-$(params.lockfile-hash)-$(params[\"go-version\"])-$(params.platform.os)",
+		},
+	}}
+	params := []v1beta1.Param{{
+		Name:  "lockfile-hash",
+		Value: *v1beta1.NewArrayOrString("1234"),
+	}, {
+		Name:  "platform",
+		Value: *v1beta1.NewObject(map[string]string{"os": "linux"}),
+	}}
+	paramSpecs := []v1beta1.ParamSpec{{
+		Name:    "lockfile-hash",
+		Type:    v1beta1.ParamTypeString,
+		Default: v1beta1.NewArrayOrString("0000"),
+	}, {
+		Name:    "go-version",
+		Type:    v1beta1.ParamTypeString,
+		Default: v1beta1.NewArrayOrString("1.17"),
+	}}
+
+	got := ApplyCacheKeyParameters(workspaces, params, paramSpecs)
+
+	want := []v1beta1.WorkspaceBinding{{
+		Name:     "source",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}, {
+		Name:  "cache",
+		Cache: &v1beta1.CacheWorkspaceBinding{Key: "go-1234-1.17-linux"},
+	}}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected workspaces %s", diff.PrintWantGot(d))
+	}
+	if workspaces[1].Cache.Key != "go-$(params.lockfile-hash)-$(params[\"go-version\"])-$(params.platform.os)" {
+		t.Errorf("expected the workspaces of the run not to be modified, got key %q", workspaces[1].Cache.Key)
+	}
+}
+
+// TestDeletePersistentVolumeClaimsForWorkspaces tests that the PVCs created from the volumeClaimTemplates of an
+// owner are deleted, and that the PVCs provided by users or of cache workspaces are kept.
+func TestDeletePersistentVolumeClaimsForWorkspaces(t *testing.T) {
+	ownerRef := metav1.OwnerReference{UID: types.UID("pipelinerun1")}
+	namespace := "ns"
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name: "myws",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc1"},
+		},
+	}, {
+		Name: "missing",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc2"},
+		},
+	}, {
+		Name: "bring-my-own-pvc",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+			ClaimName: "myown",
+		},
+	}, {
+		Name: "cache",
+		Cache: &v1beta1.CacheWorkspaceBinding{
+			Key: "go-mod",
+		},
+	}}
+	cacheClaimName := GetCachePersistentVolumeClaimName(workspaces[3].Cache)
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "pvc1-" + getPersistentVolumeClaimIdentity("myws", "pipelinerun1"), Namespace: namespace}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "myown", Namespace: namespace}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: cacheClaimName, Namespace: namespace}},
+	)
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+
+	if err := pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(context.Background(), workspaces, ownerRef, namespace); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pvcs, err := fakekubeclient.CoreV1().PersistentVolumeClaims(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var got []string
+	for _, pvc := range pvcs.Items {
+		got = append(got, pvc.Name)
+	}
+	want := []string{cacheClaimName, "myown"}
+	if d := cmp.Diff(want, got); d != "" {
+		t.Errorf("unexpected PVCs %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestDeletePersistentVolumeClaimsForWorkspacesBeingDeleted tests that the PVCs which are already being deleted,
+// e.g. while they are still mounted by the completed pods of the owner, are not deleted again.
+func TestDeletePersistentVolumeClaimsForWorkspacesBeingDeleted(t *testing.T) {
+	ownerRef := metav1.OwnerReference{UID: types.UID("pipelinerun1")}
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name: "myws",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "pvc1"},
+		},
+	}}
+	deletionTimestamp := metav1.Now()
+	fakekubeclient := fakek8s.NewSimpleClientset(&corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "pvc1-" + getPersistentVolumeClaimIdentity("myws", "pipelinerun1"),
+			Namespace:         "ns",
+			DeletionTimestamp: &deletionTimestamp,
+			Finalizers:        []string{"kubernetes.io/pvc-protection"},
+		},
+	})
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+
+	if err := pvcHandler.DeletePersistentVolumeClaimsForWorkspaces(context.Background(), workspaces, ownerRef, "ns"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, action := range fakekubeclient.Actions() {
+		if action.GetVerb() == "delete" {
+			t.Errorf("unexpected action %v on a PVC being deleted", action)
+		}
+	}
+}
+
+func TestValidateVolumeClaimTemplates(t *testing.T) {
+	maxStorage := resource.MustParse("10Gi")
+	ctx := config.ToContext(context.Background(), &config.Config{
+		Defaults: &config.Defaults{
+			DefaultVolumeClaimTemplatePolicies: &config.VolumeClaimTemplatePolicies{
+				VolumeClaimTemplatePolicy: config.VolumeClaimTemplatePolicy{MaxStorage: &maxStorage},
+				Namespaces: map[string]config.VolumeClaimTemplatePolicy{
+					"builds": {AllowedStorageClassNames: []string{"fast"}},
+				},
+			},
+		},
+	})
+	storageClassName := "standard"
+	wb := []v1beta1.WorkspaceBinding{{
+		Name:     "scratch",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}, {
+		Name: "source",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{
+			Spec: corev1.PersistentVolumeClaimSpec{
+				StorageClassName: &storageClassName,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("1Gi")},
+				},
+			},
+		},
+	}}
+
+	if err := ValidateVolumeClaimTemplates(ctx, wb, "default"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	err := ValidateVolumeClaimTemplates(ctx, wb, "builds")
+	if err == nil {
+		t.Fatal("expected an error for a storage class not allowed in the namespace")
+	}
+	want := `volumeClaimTemplate of workspace source is not allowed: storage class "standard" is not allowed, must be one of [fast]`
+	if err.Error() != want {
+		t.Errorf("expected error %q, got %q", want, err)
+	}
+
+	cache := []v1beta1.WorkspaceBinding{{
+		Name: "gocache",
+		Cache: &v1beta1.CacheWorkspaceBinding{
+			Key: "go-mod-1234",
+			VolumeClaimTemplate: corev1.PersistentVolumeClaim{
+				Spec: corev1.PersistentVolumeClaimSpec{
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse("20Gi")},
+					},
+				},
+			},
+		},
+	}}
+	err = ValidateVolumeClaimTemplates(ctx, cache, "default")
+	if err == nil {
+		t.Fatal("expected an error for a cache storage above the maximum")
+	}
+	want = `cache volumeClaimTemplate of workspace gocache is not allowed: `
+	if !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("expected error starting with %q, got %q", want, err)
+	}
+}
+
+// TestGetReadWriteOnceWorkspaces tests that the workspaces bound to claims which can only be mounted by a
+// single node are found from the access modes of the claims or of their templates.
+func TestGetReadWriteOnceWorkspaces(t *testing.T) {
+	rwo := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}
+	rwx := []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}
+	workspaces := []v1beta1.WorkspaceBinding{{
+		Name:                  "rwo-claim",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "rwo"},
+	}, {
+		Name:                  "rwx-claim",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "rwx"},
+	}, {
+		Name:                "rwo-template",
+		VolumeClaimTemplate: &corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{AccessModes: rwo}},
+	}, {
+		Name: "rwx-cache",
+		Cache: &v1beta1.CacheWorkspaceBinding{
+			Key:                 "key",
+			VolumeClaimTemplate: corev1.PersistentVolumeClaim{Spec: corev1.PersistentVolumeClaimSpec{AccessModes: rwx}},
+		},
+	}, {
+		Name:     "empty-dir",
+		EmptyDir: &corev1.EmptyDirVolumeSource{},
+	}}
+	fakekubeclient := fakek8s.NewSimpleClientset(
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "rwo", Namespace: "ns"}, Spec: corev1.PersistentVolumeClaimSpec{AccessModes: rwo}},
+		&corev1.PersistentVolumeClaim{ObjectMeta: metav1.ObjectMeta{Name: "rwx", Namespace: "ns"}, Spec: corev1.PersistentVolumeClaimSpec{AccessModes: rwx}},
+	)
+	pvcHandler := newTestPVCHandler(t, fakekubeclient)
+
+	got, err := pvcHandler.GetReadWriteOnceWorkspaces(context.Background(), workspaces, "ns")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := []string{"rwo-claim", "rwo-template"}
+	if d := cmp.Diff(want, got.List()); d != "" {
+		t.Errorf("unexpected ReadWriteOnce workspaces %s", diff.PrintWantGot(d))
+	}
+
+	if _, err := pvcHandler.GetReadWriteOnceWorkspaces(context.Background(), []v1beta1.WorkspaceBinding{{
+		Name:                  "missing-claim",
+		PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "missing"},
+	}}, "ns"); err == nil {
+		t.Error("expected an error for a missing claim")
+	}
+}
+
+func TestIsReadWriteOnce(t *testing.T) {
+	for _, tc := range []struct {
+		accessModes []corev1.PersistentVolumeAccessMode
+		want        bool
+	}{
+		{accessModes: nil, want: false},
+		{accessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce}, want: true},
+		{accessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOncePod}, want: true},
+		{accessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce, corev1.ReadOnlyMany}, want: false},
+		{accessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteMany}, want: false},
+	} {
+		if got := IsReadWriteOnce(tc.accessModes); got != tc.want {
+			t.Errorf("IsReadWriteOnce(%v) = %t, want %t", tc.accessModes, got, tc.want)
+		}
+	}
+}
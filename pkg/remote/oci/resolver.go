@@ -31,6 +31,7 @@ import (
 	ociremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/tektoncd/pipeline/pkg/client/clientset/versioned/scheme"
 	"github.com/tektoncd/pipeline/pkg/remote"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -90,6 +91,9 @@ func (o *Resolver) List(ctx context.Context) ([]remote.ResolvedObject, error) {
 
 // Get retrieves a specific object with the given Kind and name
 func (o *Resolver) Get(ctx context.Context, kind, name string) (runtime.Object, error) {
+	ctx, span := remote.StartSpan(ctx, "BundleResolution")
+	defer span.End()
+	span.SetAttributes(attribute.String("bundle", o.imageReference), attribute.String("kind", kind), attribute.String("name", name))
 	timeoutCtx, cancel := context.WithTimeout(ctx, o.timeout)
 	defer cancel()
 	img, err := o.retrieveImage(timeoutCtx)
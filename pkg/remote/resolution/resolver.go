@@ -22,6 +22,7 @@ import (
 	"github.com/tektoncd/pipeline/pkg/remote"
 	resolutioncommon "github.com/tektoncd/resolution/pkg/common"
 	remoteresource "github.com/tektoncd/resolution/pkg/resource"
+	"go.opentelemetry.io/otel/attribute"
 	"k8s.io/apimachinery/pkg/runtime"
 	"knative.dev/pkg/kmeta"
 )
@@ -56,6 +57,9 @@ func NewResolver(requester remoteresource.Requester, owner kmeta.OwnerRefable, r
 
 // Get implements remote.Resolver.
 func (resolver *Resolver) Get(ctx context.Context, _, _ string) (runtime.Object, error) {
+	ctx, span := remote.StartSpan(ctx, "RemoteResolution")
+	defer span.End()
+	span.SetAttributes(attribute.String("resolver", resolver.resolverName), attribute.String("target", resolver.targetNamespace+"/"+resolver.targetName))
 	resolverName := remoteresource.ResolverName(resolver.resolverName)
 	req, err := buildRequest(resolver.resolverName, resolver.owner, resolver.targetName, resolver.targetNamespace, resolver.params)
 	if err != nil {
@@ -16,9 +16,13 @@ package remote
 import (
 	"context"
 
+	"go.opentelemetry.io/otel/trace"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
+// TracerName is the name of the tracer used for spans emitted by Resolvers.
+const TracerName = "RemoteResolver"
+
 // ResolvedObject is returned by Resolver.List representing a Tekton resource stored in a remote location.
 type ResolvedObject struct {
 	Kind       string
@@ -33,3 +37,10 @@ type Resolver interface {
 	List(ctx context.Context) ([]ResolvedObject, error)
 	Get(ctx context.Context, kind, name string) (runtime.Object, error)
 }
+
+// StartSpan starts a span for a remote resolution as a child of the span
+// carried by ctx, using the same tracer provider. If ctx carries no span the
+// returned span is a no-op.
+func StartSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return trace.SpanFromContext(ctx).TracerProvider().Tracer(TracerName).Start(ctx, name)
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package s3copy copies files between the local file system and the buckets of an
+// S3-compatible storage, such as AWS S3 or MinIO.
+package s3copy
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/tektoncd/pipeline/pkg/internal/objectstore"
+)
+
+// modeMetadataKey is the metadata of the objects holding the permissions of the files
+// they were uploaded from, which are restored when they are downloaded.
+const modeMetadataKey = "mode"
+
+// parseS3URL returns the bucket and the key of an s3://bucket/key URL.
+func parseS3URL(s string) (string, string, bool) {
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme != "s3" || u.Host == "" {
+		return "", "", false
+	}
+	return u.Host, strings.TrimPrefix(u.Path, "/"), true
+}
+
+// Options configures the access to the S3-compatible storage.
+type Options struct {
+	// Endpoint is the endpoint of the storage, defaulting to the AWS S3 endpoint
+	// of the Region.
+	Endpoint string
+	Region   string
+	// PathStyle addresses the buckets in the path of the requests instead of in
+	// the host name.
+	PathStyle bool
+	// Credentials sign the requests, which are anonymous when nil.
+	Credentials *aws.Credentials
+	// Recursive copies the content of a local directory, or all the objects under
+	// a key.
+	Recursive bool
+}
+
+// Copy copies src to dst, where exactly one of them is an s3://bucket/key URL and the
+// other one a local path. When opts.Recursive is set, the content of the src directory
+// is uploaded under the dst key, or the objects under the src key are downloaded into
+// the dst directory.
+func Copy(ctx context.Context, src, dst string, opts Options) error {
+	client, err := objectstore.NewS3Client(opts.Endpoint, opts.Region, opts.Credentials)
+	if err != nil {
+		return err
+	}
+	client.PathStyle = opts.PathStyle
+	recursive := opts.Recursive
+
+	srcBucket, srcKey, srcIsS3 := parseS3URL(src)
+	dstBucket, dstKey, dstIsS3 := parseS3URL(dst)
+	switch {
+	case srcIsS3 && !dstIsS3:
+		if recursive {
+			return downloadDir(ctx, client, srcBucket, srcKey, dst)
+		}
+		if fi, err := os.Stat(dst); err == nil && fi.IsDir() {
+			dst = filepath.Join(dst, path.Base(srcKey))
+		}
+		return download(ctx, client, srcBucket, srcKey, dst)
+	case !srcIsS3 && dstIsS3:
+		fi, err := os.Stat(src)
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() {
+			if !recursive {
+				return fmt.Errorf("%s is a directory, copying it requires -r", src)
+			}
+			return uploadDir(ctx, client, src, dstBucket, dstKey)
+		}
+		if dstKey == "" || strings.HasSuffix(dstKey, "/") {
+			dstKey += filepath.Base(src)
+		}
+		return upload(ctx, client, src, fi.Mode(), dstBucket, dstKey)
+	default:
+		return fmt.Errorf("exactly one of %q and %q must be an s3://bucket/key URL", src, dst)
+	}
+}
+
+func uploadDir(ctx context.Context, client *objectstore.S3Client, dir, bucket, prefix string) error {
+	return filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || !fi.Mode().IsRegular() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		return upload(ctx, client, p, fi.Mode(), bucket, path.Join(prefix, filepath.ToSlash(rel)))
+	})
+}
+
+func upload(ctx context.Context, client *objectstore.S3Client, p string, mode os.FileMode, bucket, key string) error {
+	f, err := os.Open(p)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	contentType := mime.TypeByExtension(filepath.Ext(p))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	metadata := map[string]string{modeMetadataKey: strconv.FormatUint(uint64(mode.Perm()), 8)}
+	_, err = client.PutWithMetadata(ctx, bucket, key, f, contentType, metadata)
+	return err
+}
+
+func downloadDir(ctx context.Context, client *objectstore.S3Client, bucket, prefix, dir string) error {
+	if prefix != "" && !strings.HasSuffix(prefix, "/") {
+		prefix += "/"
+	}
+	keys, err := client.List(ctx, bucket, prefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		rel := strings.TrimPrefix(key, prefix)
+		// Keys ending with a slash are the placeholders of empty directories.
+		if rel == "" || strings.HasSuffix(rel, "/") {
+			continue
+		}
+		p := filepath.Join(dir, filepath.FromSlash(rel))
+		if !strings.HasPrefix(p, filepath.Clean(dir)+string(filepath.Separator)) {
+			return fmt.Errorf("object %s would be written outside of %s", key, dir)
+		}
+		if err := download(ctx, client, bucket, key, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func download(ctx context.Context, client *objectstore.S3Client, bucket, key, p string) error {
+	o, err := client.Get(ctx, bucket, key)
+	if err != nil {
+		return err
+	}
+	defer o.Body.Close()
+	mode := os.FileMode(0644)
+	if m, err := strconv.ParseUint(o.Metadata[modeMetadataKey], 8, 32); err == nil {
+		mode = os.FileMode(m).Perm()
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(p, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, mode)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, o.Body); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	// The permissions of an existing file are not changed when it is opened.
+	return os.Chmod(p, mode)
+}
@@ -0,0 +1,198 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package s3copy
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+	"github.com/tektoncd/pipeline/pkg/internal/objectstore/objectstoretest"
+	"github.com/tektoncd/pipeline/test/diff"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]os.FileMode) {
+	t.Helper()
+	for name, mode := range files {
+		p := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(p, []byte(name), mode); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func readFiles(t *testing.T, dir string) map[string]os.FileMode {
+	t.Helper()
+	files := map[string]os.FileMode{}
+	if err := filepath.Walk(dir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil || fi.IsDir() {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, p)
+		b, err := ioutil.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		if string(b) != filepath.ToSlash(rel) {
+			t.Errorf("Unexpected content %q of %s", b, rel)
+		}
+		files[filepath.ToSlash(rel)] = fi.Mode().Perm()
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// copyRoundTrip uploads a directory to the location, downloads it back and checks
+// that the files and their permissions are preserved.
+func copyRoundTrip(t *testing.T, opts Options, location string) {
+	t.Helper()
+	ctx := context.Background()
+	opts.Recursive = true
+	files := map[string]os.FileMode{
+		"README.md":         0644,
+		"hack/build.sh":     0755,
+		"pkg/a/a.go":        0600,
+		"pkg/a/testdata/go": 0644,
+	}
+	src := t.TempDir()
+	writeFiles(t, src, files)
+	if err := Copy(ctx, src, location, opts); err != nil {
+		t.Fatalf("Copy() = %v", err)
+	}
+
+	dst := t.TempDir()
+	if err := Copy(ctx, location, dst, opts); err != nil {
+		t.Fatalf("Copy() = %v", err)
+	}
+	if d := cmp.Diff(files, readFiles(t, dst)); d != "" {
+		t.Errorf("Downloaded files %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestCopyRecursive(t *testing.T) {
+	server := objectstoretest.NewServer()
+	defer server.Close()
+	copyRoundTrip(t, Options{
+		Endpoint:    server.URL,
+		Region:      "us-east-1",
+		PathStyle:   true,
+		Credentials: &aws.Credentials{AccessKeyID: "minio", SecretAccessKey: "minio123"},
+	}, "s3://artifacts/run/source")
+
+	var keys []string
+	for k := range server.Bucket("artifacts") {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	if d := cmp.Diff([]string{"run/source/README.md", "run/source/hack/build.sh", "run/source/pkg/a/a.go", "run/source/pkg/a/testdata/go"}, keys); d != "" {
+		t.Errorf("Uploaded objects %s", diff.PrintWantGot(d))
+	}
+}
+
+// TestCopyMinIO copies files to and from an existing bucket of a MinIO server, e.g. one
+// started with `docker run -p 9000:9000 minio/minio server /data`.
+func TestCopyMinIO(t *testing.T) {
+	endpoint, bucket := os.Getenv("MINIO_ENDPOINT"), os.Getenv("MINIO_BUCKET")
+	if endpoint == "" || bucket == "" {
+		t.Skip("MINIO_ENDPOINT and MINIO_BUCKET variables are not set.")
+	}
+	copyRoundTrip(t, Options{
+		Endpoint:  endpoint,
+		Region:    "us-east-1",
+		PathStyle: true,
+		Credentials: &aws.Credentials{
+			AccessKeyID:     os.Getenv("MINIO_ACCESS_KEY"),
+			SecretAccessKey: os.Getenv("MINIO_SECRET_KEY"),
+		},
+	}, fmt.Sprintf("s3://%s/%s", bucket, strings.ToLower(t.Name())))
+}
+
+func TestCopyFile(t *testing.T) {
+	server := objectstoretest.NewServer()
+	defer server.Close()
+	opts := Options{Endpoint: server.URL, Region: "us-east-1", PathStyle: true}
+	ctx := context.Background()
+
+	src := t.TempDir()
+	writeFiles(t, src, map[string]os.FileMode{"app.tar.gz": 0644})
+	if err := Copy(ctx, filepath.Join(src, "app.tar.gz"), "s3://artifacts/releases/", opts); err != nil {
+		t.Fatalf("Copy() = %v", err)
+	}
+	if _, ok := server.Bucket("artifacts")["releases/app.tar.gz"]; !ok {
+		t.Errorf("Expected the file to be uploaded to releases/app.tar.gz, got %v", server.Bucket("artifacts"))
+	}
+
+	// The object is downloaded into an existing directory.
+	dst := t.TempDir()
+	if err := Copy(ctx, "s3://artifacts/releases/app.tar.gz", dst, opts); err != nil {
+		t.Fatalf("Copy() = %v", err)
+	}
+	if d := cmp.Diff(map[string]os.FileMode{"app.tar.gz": 0644}, readFiles(t, dst)); d != "" {
+		t.Errorf("Downloaded files %s", diff.PrintWantGot(d))
+	}
+}
+
+func TestCopyErrors(t *testing.T) {
+	server := objectstoretest.NewServer()
+	defer server.Close()
+	opts := Options{Endpoint: server.URL, Region: "us-east-1", PathStyle: true}
+	dir := t.TempDir()
+
+	for _, tc := range []struct {
+		name     string
+		src, dst string
+		wantErr  string
+	}{{
+		name:    "both local",
+		src:     dir,
+		dst:     t.TempDir(),
+		wantErr: "must be an s3://bucket/key URL",
+	}, {
+		name:    "both remote",
+		src:     "s3://artifacts/a",
+		dst:     "s3://artifacts/b",
+		wantErr: "must be an s3://bucket/key URL",
+	}, {
+		name:    "directory without -r",
+		src:     dir,
+		dst:     "s3://artifacts/a",
+		wantErr: "requires -r",
+	}, {
+		name:    "missing object",
+		src:     "s3://artifacts/missing",
+		dst:     dir,
+		wantErr: "404",
+	}} {
+		t.Run(tc.name, func(t *testing.T) {
+			if err := Copy(context.Background(), tc.src, tc.dst, opts); err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+				t.Errorf("Copy() = %v, want error containing %q", err, tc.wantErr)
+			}
+		})
+	}
+}
@@ -47,13 +47,17 @@ var (
 	namespaceTag   = tag.MustNewKey("namespace")
 	statusTag      = tag.MustNewKey("status")
 	podTag         = tag.MustNewKey("pod")
-
-	trDurationView      *view.View
-	prTRDurationView    *view.View
-	trCountView         *view.View
-	runningTRsCountView *view.View
-	podLatencyView      *view.View
-	cloudEventsView     *view.View
+	reasonTag      = tag.MustNewKey("reason")
+
+	trDurationView        *view.View
+	prTRDurationView      *view.View
+	trCountView           *view.View
+	runningTRsCountView   *view.View
+	podLatencyView        *view.View
+	cloudEventsView       *view.View
+	trCPUSecondsView      *view.View
+	trMemoryGBSecondsView *view.View
+	trDeletedCountView    *view.View
 
 	trDuration = stats.Float64(
 		"taskrun_duration_seconds",
@@ -80,6 +84,24 @@ var (
 	cloudEvents = stats.Int64("cloudevent_count",
 		"number of cloud events sent including retries",
 		stats.UnitDimensionless)
+
+	trCPUSeconds = stats.Float64("taskrun_cpu_seconds",
+		"The CPU requested by the taskrun's pod multiplied by its runtime in seconds",
+		stats.UnitDimensionless)
+
+	trMemoryGBSeconds = stats.Float64("taskrun_memory_gb_seconds",
+		"The memory in GB requested by the taskrun's pod multiplied by its runtime in seconds",
+		stats.UnitDimensionless)
+
+	trDeletedCount = stats.Float64("taskrun_deleted_count",
+		"number of finished taskruns deleted by the controller",
+		stats.UnitDimensionless)
+)
+
+const (
+	// DeletionReasonTTLExpired indicates that a TaskRun was deleted because
+	// its ttlSecondsAfterFinished expired.
+	DeletionReasonTTLExpired = "TTLExpired"
 )
 
 // Recorder is used to actually record TaskRun metrics
@@ -213,6 +235,24 @@ func viewRegister(cfg *config.Metrics) error {
 		Aggregation: view.Sum(),
 		TagKeys:     append([]tag.Key{statusTag, namespaceTag}, append(trunTag, prunTag...)...),
 	}
+	trCPUSecondsView = &view.View{
+		Description: trCPUSeconds.Description(),
+		Measure:     trCPUSeconds,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{namespaceTag},
+	}
+	trMemoryGBSecondsView = &view.View{
+		Description: trMemoryGBSeconds.Description(),
+		Measure:     trMemoryGBSeconds,
+		Aggregation: view.Sum(),
+		TagKeys:     []tag.Key{namespaceTag},
+	}
+	trDeletedCountView = &view.View{
+		Description: trDeletedCount.Description(),
+		Measure:     trDeletedCount,
+		Aggregation: view.Count(),
+		TagKeys:     []tag.Key{namespaceTag, reasonTag},
+	}
 	return view.Register(
 		trDurationView,
 		prTRDurationView,
@@ -220,6 +260,9 @@ func viewRegister(cfg *config.Metrics) error {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		trCPUSecondsView,
+		trMemoryGBSecondsView,
+		trDeletedCountView,
 	)
 }
 
@@ -231,6 +274,9 @@ func viewUnregister() {
 		runningTRsCountView,
 		podLatencyView,
 		cloudEventsView,
+		trCPUSecondsView,
+		trMemoryGBSecondsView,
+		trDeletedCountView,
 	)
 }
 
@@ -308,6 +354,19 @@ func (r *Recorder) DurationAndCount(ctx context.Context, tr *v1beta1.TaskRun, be
 		status = "failed"
 	}
 
+	if usage := tr.Status.ResourceUsage; usage != nil {
+		usageCtx, err := tag.New(ctx, tag.Insert(namespaceTag, tr.Namespace))
+		if err != nil {
+			return err
+		}
+		if usage.CPUSeconds != nil {
+			metrics.Record(usageCtx, trCPUSeconds.M(usage.CPUSeconds.AsApproximateFloat64()))
+		}
+		if usage.MemoryGBSeconds != nil {
+			metrics.Record(usageCtx, trMemoryGBSeconds.M(usage.MemoryGBSeconds.AsApproximateFloat64()))
+		}
+	}
+
 	if ok, pipeline, pipelinerun := tr.IsPartOfPipeline(); ok {
 		ctx, err := tag.New(
 			ctx,
@@ -425,6 +484,29 @@ func (r *Recorder) RecordPodLatency(ctx context.Context, pod *corev1.Pod, tr *v1
 	return nil
 }
 
+// DeletedCount logs the deletion of a finished TaskRun by the controller
+// for the given reason
+// returns an error if it fails to log the metrics
+func (r *Recorder) DeletedCount(ctx context.Context, tr *v1beta1.TaskRun, reason string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if !r.initialized {
+		return fmt.Errorf("ignoring the metrics recording for %s , failed to initialize the metrics recorder", tr.Name)
+	}
+
+	ctx, err := tag.New(
+		ctx,
+		tag.Insert(namespaceTag, tr.Namespace),
+		tag.Insert(reasonTag, reason))
+	if err != nil {
+		return err
+	}
+	metrics.Record(ctx, trDeletedCount.M(1))
+
+	return nil
+}
+
 // CloudEvents logs the number of cloud events sent for TaskRun
 // returns an error if it fails to log the metrics
 func (r *Recorder) CloudEvents(ctx context.Context, tr *v1beta1.TaskRun) error {
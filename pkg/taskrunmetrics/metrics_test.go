@@ -31,6 +31,7 @@ import (
 	ttesting "github.com/tektoncd/pipeline/pkg/reconciler/testing"
 	"go.uber.org/zap"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -645,8 +646,69 @@ func TestRecordCloudEvents(t *testing.T) {
 	}
 }
 
+func TestRecordResourceUsage(t *testing.T) {
+	unregisterMetrics()
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	cpuSeconds := resource.MustParse("120")
+	memoryGBSeconds := resource.MustParse("30.5")
+	for _, name := range []string{"taskrun-1", "taskrun-2"} {
+		tr := &v1beta1.TaskRun{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "ns"},
+			Spec: v1beta1.TaskRunSpec{
+				TaskRef: &v1beta1.TaskRef{Name: "task-1"},
+			},
+			Status: v1beta1.TaskRunStatus{
+				Status: duckv1beta1.Status{
+					Conditions: duckv1beta1.Conditions{apis.Condition{
+						Type:   apis.ConditionSucceeded,
+						Status: corev1.ConditionTrue,
+					}},
+				},
+				TaskRunStatusFields: v1beta1.TaskRunStatusFields{
+					StartTime:      &startTime,
+					CompletionTime: &completionTime,
+					ResourceUsage: &v1beta1.ResourceUsage{
+						CPUSeconds:      &cpuSeconds,
+						MemoryGBSeconds: &memoryGBSeconds,
+					},
+				},
+			},
+		}
+		if err := metrics.DurationAndCount(ctx, tr, nil); err != nil {
+			t.Fatalf("DurationAndCount: %v", err)
+		}
+	}
+
+	wantTags := map[string]string{"namespace": "ns"}
+	metricstest.CheckSumData(t, "taskrun_cpu_seconds", wantTags, 240)
+	metricstest.CheckSumData(t, "taskrun_memory_gb_seconds", wantTags, 61)
+}
+
+func TestRecordTaskRunDeletedCount(t *testing.T) {
+	unregisterMetrics()
+
+	ctx := getConfigContext()
+	metrics, err := NewRecorder(ctx)
+	if err != nil {
+		t.Fatalf("NewRecorder: %v", err)
+	}
+
+	tr := &v1beta1.TaskRun{
+		ObjectMeta: metav1.ObjectMeta{Name: "taskrun-1", Namespace: "ns"},
+	}
+	if err := metrics.DeletedCount(ctx, tr, DeletionReasonTTLExpired); err != nil {
+		t.Errorf("DeletedCount: %v", err)
+	}
+	metricstest.CheckCountData(t, "taskrun_deleted_count", map[string]string{"namespace": "ns", "reason": DeletionReasonTTLExpired}, 1)
+}
+
 func unregisterMetrics() {
-	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count")
+	metricstest.Unregister("taskrun_duration_seconds", "pipelinerun_taskrun_duration_seconds", "taskrun_count", "running_taskruns_count", "taskruns_pod_latency", "cloudevent_count", "taskrun_cpu_seconds", "taskrun_memory_gb_seconds", "taskrun_deleted_count")
 
 	// Allow the recorder singleton to be recreated.
 	once = sync.Once{}
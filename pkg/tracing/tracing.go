@@ -0,0 +1,172 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sync"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.10.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+)
+
+// propagator is used to serialize span contexts into the annotations of
+// PipelineRuns and TaskRuns so that traces survive across reconciles and
+// can be continued by child resources.
+var propagator = propagation.TraceContext{}
+
+// TracerProvider is a trace.TracerProvider which can be reconfigured at
+// runtime from the config-tracing ConfigMap.
+type TracerProvider struct {
+	service string
+	logger  *zap.SugaredLogger
+
+	mu       sync.RWMutex
+	provider trace.TracerProvider
+	cfg      *config.Tracing
+}
+
+var _ trace.TracerProvider = (*TracerProvider)(nil)
+
+// New returns a TracerProvider for the given service. Tracing is disabled
+// until a config-tracing ConfigMap enabling it is observed by OnStore.
+func New(service string, logger *zap.SugaredLogger) *TracerProvider {
+	return &TracerProvider{
+		service:  service,
+		logger:   logger,
+		provider: trace.NewNoopTracerProvider(),
+	}
+}
+
+// Tracer implements trace.TracerProvider.
+func (t *TracerProvider) Tracer(name string, options ...trace.TracerOption) trace.Tracer {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.provider.Tracer(name, options...)
+}
+
+// OnStore returns a function that can be passed to config.NewStore to
+// reconfigure the TracerProvider whenever config-tracing changes.
+func (t *TracerProvider) OnStore() func(name string, value interface{}) {
+	return func(name string, value interface{}) {
+		if name != config.GetTracingConfigName() {
+			return
+		}
+		cfg, ok := value.(*config.Tracing)
+		if !ok {
+			t.logger.Error("Tracing config is not of type *config.Tracing")
+			return
+		}
+
+		t.mu.RLock()
+		unchanged := cfg.Equals(t.cfg)
+		t.mu.RUnlock()
+		if unchanged {
+			return
+		}
+
+		provider, err := createTracerProvider(t.service, cfg)
+		if err != nil {
+			t.logger.Errorf("Unable to create tracer provider: %v", err)
+			return
+		}
+		t.reinitialize(provider, cfg)
+	}
+}
+
+func (t *TracerProvider) reinitialize(provider trace.TracerProvider, cfg *config.Tracing) {
+	t.mu.Lock()
+	old := t.provider
+	t.provider = provider
+	t.cfg = cfg
+	t.mu.Unlock()
+
+	t.logger.Infof("Reinitialized tracer provider with config %+v", cfg)
+	if p, ok := old.(*tracesdk.TracerProvider); ok {
+		if err := p.Shutdown(context.Background()); err != nil {
+			t.logger.Errorf("Unable to shutdown previous tracer provider: %v", err)
+		}
+	}
+}
+
+func createTracerProvider(service string, cfg *config.Tracing) (trace.TracerProvider, error) {
+	if !cfg.Enabled {
+		return trace.NewNoopTracerProvider(), nil
+	}
+
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracing endpoint %q: %w", cfg.Endpoint, err)
+	}
+	opts := []otlptracehttp.Option{otlptracehttp.WithEndpoint(u.Host)}
+	if u.Path != "" {
+		opts = append(opts, otlptracehttp.WithURLPath(u.Path))
+	}
+	if u.Scheme == "http" {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	// The exporter only connects when spans are flushed, so creating it does
+	// not block on the collector being reachable.
+	exp, err := otlptracehttp.New(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tracesdk.NewTracerProvider(
+		tracesdk.WithBatcher(exp),
+		tracesdk.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceNameKey.String(service),
+		)),
+	), nil
+}
+
+// MarshalSpanContext serializes the span context carried by ctx so that it
+// can be stored in an annotation. It returns an empty string if ctx carries
+// no valid span context, e.g. when tracing is disabled.
+func MarshalSpanContext(ctx context.Context) (string, error) {
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	if len(carrier) == 0 {
+		return "", nil
+	}
+	b, err := json.Marshal(carrier)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// ContextWithSpanContext returns a copy of ctx carrying the span context
+// previously serialized by MarshalSpanContext, making it the parent of any
+// span subsequently started from the returned context.
+func ContextWithSpanContext(ctx context.Context, spanContext string) (context.Context, error) {
+	carrier := propagation.MapCarrier{}
+	if err := json.Unmarshal([]byte(spanContext), &carrier); err != nil {
+		return ctx, fmt.Errorf("unable to unmarshal span context %q: %w", spanContext, err)
+	}
+	return propagator.Extract(ctx, carrier), nil
+}
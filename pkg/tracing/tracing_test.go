@@ -0,0 +1,107 @@
+/*
+Copyright 2022 The Tekton Authors
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	"github.com/tektoncd/pipeline/pkg/apis/config"
+	tracesdk "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	logtesting "knative.dev/pkg/logging/testing"
+)
+
+func TestNewIsNoop(t *testing.T) {
+	tp := New("test-service", logtesting.TestLogger(t))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+	if span.SpanContext().IsValid() {
+		t.Errorf("expected a no-op span, got valid span context %v", span.SpanContext())
+	}
+}
+
+func TestOnStore(t *testing.T) {
+	tp := New("test-service", logtesting.TestLogger(t))
+	onStore := tp.OnStore()
+
+	// Unrelated configmaps are ignored.
+	onStore(config.GetFeatureFlagsConfigName(), &config.Tracing{Enabled: true, Endpoint: config.DefaultTracingEndpoint})
+	if _, ok := tp.provider.(*tracesdk.TracerProvider); ok {
+		t.Fatal("tracer provider reconfigured from an unrelated configmap")
+	}
+
+	onStore(config.GetTracingConfigName(), &config.Tracing{Enabled: true, Endpoint: "http://localhost:4318/v1/traces"})
+	sdkProvider, ok := tp.provider.(*tracesdk.TracerProvider)
+	if !ok {
+		t.Fatalf("expected an SDK tracer provider once tracing is enabled, got %T", tp.provider)
+	}
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+	if !span.SpanContext().IsValid() {
+		t.Error("expected a valid span context once tracing is enabled")
+	}
+	span.End()
+
+	// The same configuration keeps the current provider.
+	onStore(config.GetTracingConfigName(), &config.Tracing{Enabled: true, Endpoint: "http://localhost:4318/v1/traces"})
+	if tp.provider != sdkProvider {
+		t.Error("tracer provider recreated although the configuration did not change")
+	}
+
+	onStore(config.GetTracingConfigName(), &config.Tracing{Enabled: false, Endpoint: "http://localhost:4318/v1/traces"})
+	if _, ok := tp.provider.(*tracesdk.TracerProvider); ok {
+		t.Error("expected a no-op tracer provider once tracing is disabled")
+	}
+}
+
+func TestSpanContextRoundTrip(t *testing.T) {
+	ctx, span := tracesdk.NewTracerProvider().Tracer("test").Start(context.Background(), "span")
+	defer span.End()
+
+	spanContext, err := MarshalSpanContext(ctx)
+	if err != nil {
+		t.Fatalf("MarshalSpanContext() = %v", err)
+	}
+	if spanContext == "" {
+		t.Fatal("expected a non-empty span context")
+	}
+
+	got, err := ContextWithSpanContext(context.Background(), spanContext)
+	if err != nil {
+		t.Fatalf("ContextWithSpanContext() = %v", err)
+	}
+	gotSpanContext := trace.SpanContextFromContext(got)
+	if gotSpanContext.TraceID() != span.SpanContext().TraceID() || gotSpanContext.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("got span context %v, want %v", gotSpanContext, span.SpanContext())
+	}
+}
+
+func TestMarshalSpanContextWithoutSpan(t *testing.T) {
+	spanContext, err := MarshalSpanContext(context.Background())
+	if err != nil {
+		t.Fatalf("MarshalSpanContext() = %v", err)
+	}
+	if spanContext != "" {
+		t.Errorf("expected an empty span context, got %q", spanContext)
+	}
+}
+
+func TestContextWithSpanContextInvalid(t *testing.T) {
+	if _, err := ContextWithSpanContext(context.Background(), "not-json"); err == nil {
+		t.Error("expected an error for an invalid span context")
+	}
+}
@@ -27,4 +27,8 @@ const (
 
 	// AnnotationAffinityAssistantName is used to pass the instance name of an Affinity Assistant to TaskRun pods
 	AnnotationAffinityAssistantName = "pipeline.tekton.dev/affinity-assistant"
+
+	// AnnotationCoschedule records on a PipelineRun and its TaskRuns the "coschedule" mode its Affinity Assistants
+	// were created with, so that they are used and deleted the same way if the feature flag changes meanwhile
+	AnnotationCoschedule = "pipeline.tekton.dev/affinity-assistant-coschedule"
 )
@@ -136,7 +136,7 @@ func Apply(ctx context.Context, ts v1beta1.TaskSpec, wb []v1beta1.WorkspaceBindi
 			Name:      vv.Name,
 			MountPath: w.GetMountPath(),
 			SubPath:   wb[i].SubPath,
-			ReadOnly:  w.ReadOnly,
+			ReadOnly:  w.ReadOnly || wb[i].ReadOnly,
 		}
 
 		if alphaAPIEnabled {
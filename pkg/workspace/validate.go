@@ -75,6 +75,9 @@ func ValidateOnlyOnePVCIsUsed(wb []v1beta1.WorkspaceBinding) error {
 		if w.VolumeClaimTemplate != nil {
 			workspaceVolumes[w.Name] = true
 		}
+		if w.Cache != nil {
+			workspaceVolumes[w.Name] = true
+		}
 	}
 
 	if len(workspaceVolumes) > 1 {
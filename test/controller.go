@@ -59,6 +59,7 @@ import (
 	fakekubeclient "knative.dev/pkg/client/injection/kube/client/fake"
 	fakeconfigmapinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/configmap/fake"
 	fakelimitrangeinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/limitrange/fake"
+	fakepvcinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/persistentvolumeclaim/fake"
 	fakefilteredpodinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/pod/filtered/fake"
 	fakeserviceaccountinformer "knative.dev/pkg/client/injection/kube/informers/core/v1/serviceaccount/fake"
 	"knative.dev/pkg/controller"
@@ -79,6 +80,7 @@ type Data struct {
 	ConfigMaps        []*corev1.ConfigMap
 	ServiceAccounts   []*corev1.ServiceAccount
 	LimitRange        []*corev1.LimitRange
+	PVCs              []*corev1.PersistentVolumeClaim
 }
 
 // Clients holds references to clients which are useful for reconciler tests.
@@ -103,6 +105,7 @@ type Informers struct {
 	ConfigMap         coreinformers.ConfigMapInformer
 	ServiceAccount    coreinformers.ServiceAccountInformer
 	LimitRange        coreinformers.LimitRangeInformer
+	PVC               coreinformers.PersistentVolumeClaimInformer
 	ResolutionRequest resolutioninformersv1alpha1.ResolutionRequestInformer
 }
 
@@ -186,6 +189,7 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 		ConfigMap:         fakeconfigmapinformer.Get(ctx),
 		ServiceAccount:    fakeserviceaccountinformer.Get(ctx),
 		LimitRange:        fakelimitrangeinformer.Get(ctx),
+		PVC:               fakepvcinformer.Get(ctx),
 		ResolutionRequest: fakeresolutionrequestinformer.Get(ctx),
 	}
 
@@ -248,6 +252,13 @@ func SeedTestData(t *testing.T, ctx context.Context, d Data) (Clients, Informers
 			t.Fatal(err)
 		}
 	}
+	c.Kube.PrependReactor("*", "persistentvolumeclaims", AddToInformer(t, i.PVC.Informer().GetIndexer()))
+	for _, pvc := range d.PVCs {
+		pvc := pvc.DeepCopy() // Avoid assumptions that the informer's copy is modified.
+		if _, err := c.Kube.CoreV1().PersistentVolumeClaims(pvc.Namespace).Create(ctx, pvc, metav1.CreateOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
 	for _, n := range d.Namespaces {
 		n := n.DeepCopy() // Avoid assumptions that the informer's copy is modified.
 		if _, err := c.Kube.CoreV1().Namespaces().Create(ctx, n, metav1.CreateOptions{}); err != nil {